@@ -50,6 +50,14 @@ if [ $? -ne 0 ]; then
     exit 1
 fi
 
+# 构建站点地图工具
+echo "🗺️  构建站点地图工具..."
+go build -o sitemap-tool cmd/sitemap/main.go
+if [ $? -ne 0 ]; then
+    echo "❌ 站点地图工具构建失败"
+    exit 1
+fi
+
 echo "✅ 构建完成！"
 echo ""
 echo "🎉 可用工具："
@@ -59,6 +67,7 @@ echo "  ./creeper -serve -port 3000  # 在指定端口启动服务器"
 echo "  ./creeper -deploy            # 生成并自动部署"
 echo "  ./cover-gen                  # 封面生成器"
 echo "  ./deploy-tool                # 部署工具"
+echo "  ./sitemap-tool               # 列出生成站点的所有页面地址"
 echo ""
 echo "📚 封面生成器使用示例："
 echo "  ./cover-gen -title \"我的小说\" -theme fantasy"
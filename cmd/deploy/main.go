@@ -4,6 +4,8 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"creeper/internal/deploy"
 )
@@ -16,6 +18,8 @@ func main() {
 		deployType = flag.String("type", "cloudflare", "部署类型 (cloudflare|github|vercel|netlify)")
 		status     = flag.Bool("status", false, "查看部署状态")
 		list       = flag.Bool("list", false, "列出部署历史")
+		rollback   = flag.Bool("rollback", false, "回滚到之前的部署")
+		steps      = flag.Int("steps", 1, "回滚的部署次数，配合 -rollback 使用")
 	)
 	flag.Parse()
 
@@ -26,6 +30,11 @@ func main() {
 		}
 		fmt.Printf("✅ 部署配置已创建: %s\n", *configPath)
 		fmt.Printf("📝 请编辑配置文件并填入正确的参数\n")
+		if *deployType == "cloudflare" {
+			fmt.Printf("💡 如需将构建元数据写入 Workers KV，请设置 cloudflare.kv_namespace_id，\n")
+			fmt.Printf("   并确保 API 令牌拥有 Account > Workers KV Storage > Edit 权限\n")
+			fmt.Printf("   元数据键名: %s\n", deploy.KVMetadataKey)
+		}
 		return
 	}
 
@@ -62,11 +71,22 @@ func main() {
 		return
 	}
 
+	// 回滚部署
+	if *rollback {
+		fmt.Printf("⏪ 回滚 %d 次部署...\n", *steps)
+		if err := deployManager.Rollback(*steps); err != nil {
+			log.Fatalf("回滚失败: %v", err)
+		}
+		fmt.Printf("✅ 回滚完成！\n")
+		fmt.Printf("🌐 访问地址: %s\n", deployManager.GetDeploymentURL())
+		return
+	}
+
 	// 执行部署
 	fmt.Printf("🚀 开始部署到 %s...\n", deployConfig.Type)
 	fmt.Printf("📁 站点目录: %s\n", *siteDir)
 
-	if err := deployManager.Deploy(*siteDir); err != nil {
+	if err := deployManager.Deploy(*siteDir, printProgress); err != nil {
 		log.Fatalf("部署失败: %v", err)
 	}
 
@@ -75,6 +95,28 @@ func main() {
 	fmt.Printf("🌐 访问地址: %s\n", deploymentURL)
 }
 
+// progressBarWidth 进度条宽度（字符数）
+const progressBarWidth = 30
+
+// printProgress 在标准错误输出中绘制部署进度条
+func printProgress(event deploy.ProgressEvent) {
+	percentage := event.Percentage
+	if percentage < 0 {
+		percentage = 0
+	} else if percentage > 100 {
+		percentage = 100
+	}
+
+	filled := progressBarWidth * percentage / 100
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	// \033[K 清除上一次输出的剩余字符
+	fmt.Fprintf(os.Stderr, "\r[%s] %3d%% %s\033[K", bar, percentage, event.Message)
+
+	if percentage == 100 {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
 // initDeployConfig 初始化部署配置
 func initDeployConfig(deployType, configPath string) error {
 	var deployTypeEnum deploy.DeployType
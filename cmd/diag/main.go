@@ -0,0 +1,162 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"creeper/internal/chain"
+	"creeper/internal/config"
+	"creeper/internal/di"
+	"creeper/internal/factory"
+	"creeper/internal/generator"
+	"creeper/internal/parser"
+)
+
+func main() {
+	var (
+		configPath = flag.String("config", "config.yaml", "配置文件路径")
+		deps       = flag.Bool("deps", false, "以 DOT 格式输出服务依赖图（可用 Graphviz 渲染）")
+		output     = flag.String("o", "", "输出文件路径（默认输出到标准输出）")
+	)
+	flag.Parse()
+
+	if !*deps {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	container, err := buildContainer(*configPath)
+	if err != nil {
+		log.Fatalf("构建依赖注入容器失败: %v", err)
+	}
+	graph := container.GetDependencyGraph()
+
+	for _, cycle := range findCycles(graph) {
+		fmt.Fprintf(os.Stderr, "⚠️  检测到循环依赖: %s\n", strings.Join(cycle, " -> "))
+	}
+
+	dot := renderDOT(graph)
+	if *output == "" {
+		fmt.Print(dot)
+		return
+	}
+
+	if err := os.WriteFile(*output, []byte(dot), 0644); err != nil {
+		log.Fatalf("写入依赖图失败: %v", err)
+	}
+	fmt.Printf("✅ 依赖图已写入 %s，可使用 dot -Tsvg %s -o deps.svg 渲染\n", *output, *output)
+}
+
+// buildContainer 按主程序的方式注册服务（与 main_enhanced.go 中的 initializeDI 保持一致）
+func buildContainer(configPath string) (*di.Container, error) {
+	builder := di.NewServiceBuilder()
+
+	builder.AddSingleton((*config.Config)(nil), func(container *di.Container) (interface{}, error) {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			cfg = config.Default()
+		}
+		return cfg, nil
+	})
+
+	builder.AddTransient((*parser.Parser)(nil), func(container *di.Container) (interface{}, error) {
+		return parser.New(), nil
+	})
+
+	builder.AddSingleton((*generator.Generator)(nil), func(container *di.Container) (interface{}, error) {
+		cfg, err := container.Resolve((*config.Config)(nil))
+		if err != nil {
+			return nil, err
+		}
+
+		factoryRegistry := factory.NewGeneratorFactoryRegistry()
+		suite, err := factoryRegistry.CreateGeneratorSuite(factory.EnhancedGenerator, cfg.(*config.Config))
+		if err != nil {
+			return nil, err
+		}
+
+		return suite.Generator, nil
+	}).DependsOn((*config.Config)(nil))
+
+	builder.AddSingleton((*chain.ErrorManager)(nil), func(container *di.Container) (interface{}, error) {
+		return chain.NewErrorManager(), nil
+	})
+
+	return builder.Build()
+}
+
+// renderDOT 将依赖图渲染为 Graphviz DOT 格式
+func renderDOT(graph map[string][]string) string {
+	names := make([]string, 0, len(graph))
+	for name := range graph {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("digraph creeper {\n")
+	sb.WriteString("    rankdir=LR;\n")
+	sb.WriteString("    node [shape=box, style=rounded];\n")
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("    %q;\n", name))
+	}
+	for _, name := range names {
+		for _, dep := range graph[name] {
+			sb.WriteString(fmt.Sprintf("    %q -> %q;\n", name, dep))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// findCycles 在依赖图中查找循环依赖
+func findCycles(graph map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	names := make([]string, 0, len(graph))
+	for name := range graph {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	state := make(map[string]int)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range graph[name] {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == dep {
+						cycle := append([]string{}, stack[i:]...)
+						cycles = append(cycles, append(cycle, dep))
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRenderDOT(t *testing.T) {
+	// 配置文件不存在时工厂函数会回退到默认配置；依赖图不应创建任何服务
+	container, err := buildContainer(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("buildContainer() error = %v", err)
+	}
+
+	want := `digraph creeper {
+    rankdir=LR;
+    node [shape=box, style=rounded];
+    "Config";
+    "ErrorManager";
+    "Generator";
+    "Parser";
+    "Generator" -> "Config";
+}
+`
+	if got := renderDOT(container.GetDependencyGraph()); got != want {
+		t.Errorf("renderDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFindCycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph map[string][]string
+		want  [][]string
+	}{
+		{
+			name:  "acyclic",
+			graph: map[string][]string{"A": {"B"}, "B": {}},
+		},
+		{
+			name:  "two services",
+			graph: map[string][]string{"A": {"B"}, "B": {"A"}},
+			want:  [][]string{{"A", "B", "A"}},
+		},
+		{
+			name:  "self loop",
+			graph: map[string][]string{"A": {"A"}},
+			want:  [][]string{{"A", "A"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findCycles(tt.graph); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findCycles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"reflect"
+
+	"github.com/invopop/jsonschema"
+
+	"creeper/internal/config"
+)
+
+func main() {
+	output := flag.String("o", "", "写入的文件路径（默认输出到标准输出），如 schemas/config-schema.json")
+	flag.Parse()
+
+	data, err := json.MarshalIndent(configSchema(), "", "  ")
+	if err != nil {
+		log.Fatalf("序列化 Schema 失败: %v", err)
+	}
+	data = append(data, '\n')
+
+	if *output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		log.Fatalf("写入 %s 失败: %v", *output, err)
+	}
+}
+
+// configSchema 反射 config.Config 生成 config.yaml 的 JSON Schema
+// 属性名取自 yaml 标签，说明取自 desc 标签；所有配置项都有默认值，因此均为可选
+func configSchema() *jsonschema.Schema {
+	reflector := &jsonschema.Reflector{
+		FieldNameTag:               "yaml",
+		RequiredFromJSONSchemaTags: true,
+		LookupComment:              fieldDescription,
+	}
+
+	schema := reflector.Reflect(&config.Config{})
+	schema.Title = "Creeper 配置"
+	return schema
+}
+
+// fieldDescription 读取字段的 desc 标签
+func fieldDescription(t reflect.Type, fieldName string) string {
+	if fieldName == "" || t.Kind() != reflect.Struct {
+		return ""
+	}
+	field, ok := t.FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	return field.Tag.Get("desc")
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"creeper/internal/config"
+)
+
+// chapterFileRegex 章节页面文件名，分页后的 chapter-N-part-M.html 不参与编号检查
+var chapterFileRegex = regexp.MustCompile(`^chapter-(\d+)\.html$`)
+
+func main() {
+	var (
+		configPath = flag.String("config", "config.yaml", "配置文件路径")
+		siteDir    = flag.String("dir", "", "生成的站点目录（默认使用配置中的 output_dir）")
+		asJSON     = flag.Bool("json", false, "以 JSON 数组输出")
+	)
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Printf("加载配置失败，使用默认配置: %v", err)
+		cfg = config.Default()
+	}
+
+	dir := *siteDir
+	if dir == "" {
+		dir = cfg.OutputDir
+	}
+
+	pages, err := listPages(dir)
+	if err != nil {
+		log.Fatalf("读取站点目录失败: %v", err)
+	}
+
+	for _, warning := range findChapterGaps(pages) {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", warning)
+	}
+
+	urls := make([]string, 0, len(pages))
+	for _, page := range pages {
+		urls = append(urls, pageURL(cfg.Site.BaseURL, page))
+	}
+	sort.Strings(urls)
+
+	if *asJSON {
+		data, err := json.MarshalIndent(urls, "", "  ")
+		if err != nil {
+			log.Fatalf("序列化失败: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	for _, u := range urls {
+		fmt.Println(u)
+	}
+}
+
+// listPages 列出站点目录下所有 .html 文件的相对路径（以 / 分隔）
+func listPages(dir string) ([]string, error) {
+	var pages []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".html") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// pageURL 拼接页面地址，index.html 使用目录地址
+func pageURL(baseURL, page string) string {
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	if page == "index.html" {
+		page = ""
+	} else if strings.HasSuffix(page, "/index.html") {
+		page = strings.TrimSuffix(page, "index.html")
+	}
+
+	segments := strings.Split(page, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return baseURL + strings.Join(segments, "/")
+}
+
+// findChapterGaps 检查每个目录中 chapter-N.html 的编号是否连续，返回缺失章节的警告
+func findChapterGaps(pages []string) []string {
+	chapters := make(map[string][]int)
+	for _, page := range pages {
+		dir, name := filepath.Split(page)
+		if match := chapterFileRegex.FindStringSubmatch(name); match != nil {
+			id, _ := strconv.Atoi(match[1])
+			chapters[dir] = append(chapters[dir], id)
+		}
+	}
+
+	dirs := make([]string, 0, len(chapters))
+	for dir := range chapters {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var warnings []string
+	for _, dir := range dirs {
+		ids := chapters[dir]
+		sort.Ints(ids)
+
+		next := 1
+		for _, id := range ids {
+			if id > next {
+				warnings = append(warnings, fmt.Sprintf("%s 缺少 %s，其后的章节成为孤立页面", dir, chapterRange(next, id-1)))
+			}
+			next = id + 1
+		}
+	}
+	return warnings
+}
+
+// chapterRange 缺失章节的描述，如 chapter-3.html 或 chapter-3.html ~ chapter-5.html
+func chapterRange(from, to int) string {
+	if from == to {
+		return fmt.Sprintf("chapter-%d.html", from)
+	}
+	return fmt.Sprintf("chapter-%d.html ~ chapter-%d.html", from, to)
+}
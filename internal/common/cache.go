@@ -0,0 +1,99 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEvictInterval 后台清理过期条目的间隔
+const cacheEvictInterval = time.Minute
+
+// cacheEntry 缓存条目，expiresAt 为零值时永不过期
+// 以指针保存，过期删除时用 CompareAndDelete 比较，避免删掉期间被重新写入的值
+type cacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// expired 条目在 now 时是否已过期
+func (e *cacheEntry[V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// Cache 并发安全的键值缓存，条目可设置过期时间
+// 首次写入带过期时间的条目时启动后台协程，每分钟清理一次过期条目；读取时也会忽略已过期的条目
+type Cache[K comparable, V any] struct {
+	entries   sync.Map
+	evictOnce sync.Once
+}
+
+// NewCache 创建缓存
+func NewCache[K comparable, V any]() *Cache[K, V] {
+	return &Cache[K, V]{}
+}
+
+// Set 设置永不过期的缓存
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.entries.Store(key, &cacheEntry[V]{value: value})
+}
+
+// SetWithTTL 设置缓存，ttl 后过期；ttl 不大于 0 时等同 Set
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	if ttl <= 0 {
+		c.Set(key, value)
+		return
+	}
+	c.entries.Store(key, &cacheEntry[V]{value: value, expiresAt: time.Now().Add(ttl)})
+	c.evictOnce.Do(func() {
+		go c.evictLoop()
+	})
+}
+
+// Get 获取缓存，不存在或已过期时返回零值与 false
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var zero V
+	raw, ok := c.entries.Load(key)
+	if !ok {
+		return zero, false
+	}
+	entry := raw.(*cacheEntry[V])
+	if entry.expired(time.Now()) {
+		c.entries.CompareAndDelete(key, raw)
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// Exists 检查键是否存在且未过期
+func (c *Cache[K, V]) Exists(key K) bool {
+	_, ok := c.Get(key)
+	return ok
+}
+
+// Delete 删除缓存
+func (c *Cache[K, V]) Delete(key K) {
+	c.entries.Delete(key)
+}
+
+// Clear 清空缓存
+func (c *Cache[K, V]) Clear() {
+	c.entries.Range(func(key, _ any) bool {
+		c.entries.Delete(key)
+		return true
+	})
+}
+
+// evictLoop 定期删除过期条目
+func (c *Cache[K, V]) evictLoop() {
+	ticker := time.NewTicker(cacheEvictInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		c.entries.Range(func(key, raw any) bool {
+			if raw.(*cacheEntry[V]).expired(now) {
+				c.entries.CompareAndDelete(key, raw)
+			}
+			return true
+		})
+	}
+}
@@ -2,6 +2,8 @@ package common
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
@@ -255,6 +257,48 @@ func (dr *DirectoryResource) GetChildByType(componentType string) []ResourceComp
 	return result
 }
 
+// Walk 以深度优先顺序遍历以当前目录为根的资源树，对每个目录和文件调用 fn
+// 子组件按名称排序以保证遍历顺序稳定；与 filepath.Walk 一致，fn 返回 filepath.SkipDir
+// 时跳过该目录（对文件返回时跳过其所在目录的剩余内容），返回 filepath.SkipAll 时停止遍历
+func (dr *DirectoryResource) Walk(fn func(path string, component ResourceComponent) error) error {
+	err := walkResource(dr, fn)
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walkResource 递归遍历资源组件
+func walkResource(component ResourceComponent, fn func(path string, component ResourceComponent) error) error {
+	if err := fn(component.GetPath(), component); err != nil {
+		if err == filepath.SkipDir && component.IsDirectory() {
+			return nil
+		}
+		return err
+	}
+
+	if !component.IsDirectory() {
+		return nil
+	}
+
+	children := component.GetChildren()
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].GetName() < children[j].GetName()
+	})
+
+	for _, child := range children {
+		if err := walkResource(child, fn); err != nil {
+			if err == filepath.SkipDir {
+				// 文件返回 SkipDir：跳过当前目录剩余的子组件
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ResourceTree 资源树
 type ResourceTree struct {
 	root   ResourceComponent
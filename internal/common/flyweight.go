@@ -2,12 +2,35 @@ package common
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 )
 
+// CSSKeyPrefix 按需生成 CSS 片段的享元键前缀，如 css:.btn-primary
+const CSSKeyPrefix = "css:"
+
+// CSSPalette 生成 CSS 片段所用的主题颜色
+type CSSPalette struct {
+	PrimaryColor    string
+	SecondaryColor  string
+	BackgroundColor string
+	TextColor       string
+}
+
+// DefaultCSSPalette 默认主题颜色，与 default_theme 一致
+func DefaultCSSPalette() CSSPalette {
+	return CSSPalette{
+		PrimaryColor:    "#2c3e50",
+		SecondaryColor:  "#3498db",
+		BackgroundColor: "#ffffff",
+		TextColor:       "#333333",
+	}
+}
+
 // FlyweightFactory 享元工厂
 type FlyweightFactory struct {
 	flyweights map[string]Flyweight
+	palette    CSSPalette
 	mutex      sync.RWMutex
 }
 
@@ -15,6 +38,20 @@ type FlyweightFactory struct {
 func NewFlyweightFactory() *FlyweightFactory {
 	return &FlyweightFactory{
 		flyweights: make(map[string]Flyweight),
+		palette:    DefaultCSSPalette(),
+	}
+}
+
+// SetPalette 设置当前主题颜色，已生成的 css: 片段会被丢弃并在下次获取时重新生成
+func (ff *FlyweightFactory) SetPalette(palette CSSPalette) {
+	ff.mutex.Lock()
+	defer ff.mutex.Unlock()
+
+	ff.palette = palette
+	for key := range ff.flyweights {
+		if strings.HasPrefix(key, CSSKeyPrefix) {
+			delete(ff.flyweights, key)
+		}
 	}
 }
 
@@ -55,11 +92,52 @@ func (ff *FlyweightFactory) createFlyweight(key string) (Flyweight, error) {
 		return NewThemeFlyweight("dark", "#1a1a1a", "#4a90e2", "#2d2d2d", "#ffffff"), nil
 	case key == "light_theme":
 		return NewThemeFlyweight("light", "#f8f9fa", "#007bff", "#ffffff", "#212529"), nil
+	case strings.HasPrefix(key, CSSKeyPrefix):
+		selector := strings.TrimSpace(strings.TrimPrefix(key, CSSKeyPrefix))
+		if selector == "" {
+			return nil, fmt.Errorf("CSS 享元缺少选择器: %s", key)
+		}
+		return NewCSSFlyweight(key, buildSelectorCSS(selector, ff.palette)), nil
 	default:
 		return nil, fmt.Errorf("未知的享元类型: %s", key)
 	}
 }
 
+// selectorStyles 选择器关键字与配色规则，按顺序匹配第一个命中的规则
+var selectorStyles = []struct {
+	keywords []string
+	style    func(p CSSPalette) string
+}{
+	{[]string{"btn", "button"}, func(p CSSPalette) string {
+		return fmt.Sprintf("background-color: %s; color: %s; border-color: %s;", p.SecondaryColor, p.BackgroundColor, p.SecondaryColor)
+	}},
+	{[]string{"header", "nav", "footer"}, func(p CSSPalette) string {
+		return fmt.Sprintf("background-color: %s; color: %s;", p.PrimaryColor, p.BackgroundColor)
+	}},
+	{[]string{"link", "a:", "badge", "tag"}, func(p CSSPalette) string {
+		return fmt.Sprintf("color: %s;", p.SecondaryColor)
+	}},
+	{[]string{"title", "h1", "h2", "h3", "heading"}, func(p CSSPalette) string {
+		return fmt.Sprintf("color: %s;", p.PrimaryColor)
+	}},
+	{[]string{"border", "card", "item"}, func(p CSSPalette) string {
+		return fmt.Sprintf("background-color: %s; color: %s; border-color: %s;", p.BackgroundColor, p.TextColor, p.PrimaryColor)
+	}},
+}
+
+// buildSelectorCSS 根据主题颜色为选择器生成 CSS 规则
+func buildSelectorCSS(selector string, palette CSSPalette) string {
+	lower := strings.ToLower(selector)
+	for _, rule := range selectorStyles {
+		for _, keyword := range rule.keywords {
+			if strings.Contains(lower, keyword) {
+				return fmt.Sprintf("%s { %s }", selector, rule.style(palette))
+			}
+		}
+	}
+	return fmt.Sprintf("%s { color: %s; background-color: %s; }", selector, palette.TextColor, palette.BackgroundColor)
+}
+
 // GetFlyweightCount 获取享元对象数量
 func (ff *FlyweightFactory) GetFlyweightCount() int {
 	ff.mutex.RLock()
@@ -263,6 +341,11 @@ func (fm *FlyweightManager) GetFont(fontKey string) (*FlyweightContext, error) {
 	return NewFlyweightContext(flyweight), nil
 }
 
+// SetPalette 设置生成 CSS 片段所用的主题颜色
+func (fm *FlyweightManager) SetPalette(palette CSSPalette) {
+	fm.factory.SetPalette(palette)
+}
+
 // GetCSS 获取 CSS
 func (fm *FlyweightManager) GetCSS(cssKey string) (*FlyweightContext, error) {
 	flyweight, err := fm.factory.GetFlyweight(cssKey)
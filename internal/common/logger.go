@@ -0,0 +1,189 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel 日志级别
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String 级别名称，用于 JSON 输出
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// ParseLogLevel 解析 debug/info/warn/error（不区分大小写，warning 等同 warn）
+func ParseLogLevel(s string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("未知的日志级别: %s", s)
+}
+
+// loggerOutput 日志输出目标与格式，由同一个根日志记录器派生的记录器共享
+type loggerOutput struct {
+	mu     sync.Mutex
+	level  LogLevel
+	json   bool
+	writer io.Writer
+	text   *log.Logger
+}
+
+// Logger 全局日志记录器
+// 级别由 LOG_LEVEL（debug/info/warn/error，默认 info）控制；
+// LOG_FORMAT=json 时每条日志输出一行 JSON，包含 time、level、msg、component 字段
+type Logger struct {
+	output *loggerOutput
+	// component 日志所属组件，为空时使用调用方的包名
+	component string
+}
+
+var (
+	loggerInstance *Logger
+	loggerOnce     sync.Once
+)
+
+// GetLogger 获取日志记录器单例
+func GetLogger() *Logger {
+	loggerOnce.Do(func() {
+		output := &loggerOutput{
+			level:  LevelInfo,
+			json:   strings.EqualFold(os.Getenv("LOG_FORMAT"), "json"),
+			writer: os.Stderr,
+			text:   log.Default(),
+		}
+		if value := os.Getenv("LOG_LEVEL"); value != "" {
+			level, err := ParseLogLevel(value)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "警告：%v，使用 info\n", err)
+			}
+			output.level = level
+		}
+		loggerInstance = &Logger{output: output}
+	})
+	return loggerInstance
+}
+
+// WithComponent 返回指定组件名的日志记录器，与原记录器共享级别与输出
+func (l *Logger) WithComponent(component string) *Logger {
+	return &Logger{output: l.output, component: component}
+}
+
+// SetLevel 设置最低输出级别，影响所有共享输出的日志记录器
+func (l *Logger) SetLevel(level LogLevel) {
+	l.output.mu.Lock()
+	l.output.level = level
+	l.output.mu.Unlock()
+}
+
+// Info 记录信息日志
+func (l *Logger) Info(v ...interface{}) {
+	l.log(LevelInfo, "[INFO]", v)
+}
+
+// Warn 记录警告日志
+func (l *Logger) Warn(v ...interface{}) {
+	l.log(LevelWarn, "[WARN]", v)
+}
+
+// Error 记录错误日志
+func (l *Logger) Error(v ...interface{}) {
+	l.log(LevelError, "[ERROR]", v)
+}
+
+// Debug 记录调试日志
+func (l *Logger) Debug(v ...interface{}) {
+	l.log(LevelDebug, "[DEBUG]", v)
+}
+
+// jsonLogEntry LOG_FORMAT=json 时的一行日志
+type jsonLogEntry struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Msg       string `json:"msg"`
+	Component string `json:"component,omitempty"`
+}
+
+// log 按级别过滤并输出
+func (l *Logger) log(level LogLevel, prefix string, v []interface{}) {
+	out := l.output
+	out.mu.Lock()
+	defer out.mu.Unlock()
+
+	if level < out.level {
+		return
+	}
+
+	if !out.json {
+		out.text.Println(prefix, v)
+		return
+	}
+
+	component := l.component
+	if component == "" {
+		component = callerPackage(3)
+	}
+
+	data, err := json.Marshal(jsonLogEntry{
+		Time:      time.Now().Format(time.RFC3339),
+		Level:     level.String(),
+		Msg:       strings.TrimSuffix(fmt.Sprintln(v...), "\n"),
+		Component: component,
+	})
+	if err != nil {
+		return
+	}
+	out.writer.Write(append(data, '\n'))
+}
+
+// callerPackage 调用方函数所在包的名称，如 creeper/internal/deploy 中的函数返回 deploy
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+
+	name := fn.Name()
+	if slash := strings.LastIndex(name, "/"); slash >= 0 {
+		name = name[slash+1:]
+	}
+	if dot := strings.Index(name, "."); dot >= 0 {
+		name = name[:dot]
+	}
+	return name
+}
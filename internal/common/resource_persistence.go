@@ -0,0 +1,275 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// resourceFileVersion 资源树持久化文件的格式版本
+const resourceFileVersion = 1
+
+// 元数据值的类型标记
+const (
+	metadataTypeString   = "string"
+	metadataTypeBool     = "bool"
+	metadataTypeInt      = "int"
+	metadataTypeInt64    = "int64"
+	metadataTypeFloat64  = "float64"
+	metadataTypeTime     = "time"
+	metadataTypeDuration = "duration"
+	metadataTypeStrings  = "strings"
+	metadataTypeJSON     = "json"
+)
+
+// resourceFile 持久化文件结构
+type resourceFile struct {
+	Version int              `json:"version"`
+	SavedAt time.Time        `json:"saved_at"`
+	Root    resourceSnapshot `json:"root"`
+}
+
+// resourceSnapshot 资源组件的可序列化快照
+type resourceSnapshot struct {
+	Name         string                   `json:"name"`
+	Path         string                   `json:"path"`
+	Type         string                   `json:"type"`
+	Size         int64                    `json:"size,omitempty"`
+	CreatedTime  time.Time                `json:"created_time"`
+	ModifiedTime time.Time                `json:"modified_time"`
+	Metadata     map[string]MetadataValue `json:"metadata,omitempty"`
+	Children     []resourceSnapshot       `json:"children,omitempty"`
+}
+
+// MetadataValue 带类型标记的元数据值
+// interface{} 直接序列化为 JSON 会丢失类型（如 int 变为 float64、time.Time 变为字符串），
+// 因此以 {"type": "...", "value": ...} 的形式保存，加载时还原为原始类型
+type MetadataValue struct {
+	Value interface{}
+}
+
+// MarshalJSON 序列化为带类型标记的 JSON
+func (mv MetadataValue) MarshalJSON() ([]byte, error) {
+	var tag string
+	switch mv.Value.(type) {
+	case string:
+		tag = metadataTypeString
+	case bool:
+		tag = metadataTypeBool
+	case int:
+		tag = metadataTypeInt
+	case int64:
+		tag = metadataTypeInt64
+	case float64:
+		tag = metadataTypeFloat64
+	case time.Time:
+		tag = metadataTypeTime
+	case time.Duration:
+		tag = metadataTypeDuration
+	case []string:
+		tag = metadataTypeStrings
+	default:
+		// 其他类型按普通 JSON 保存，加载后为 map[string]interface{} 等通用类型
+		tag = metadataTypeJSON
+	}
+
+	value, err := json.Marshal(mv.Value)
+	if err != nil {
+		return nil, fmt.Errorf("序列化元数据值失败: %v", err)
+	}
+
+	return json.Marshal(struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}{tag, value})
+}
+
+// UnmarshalJSON 按类型标记还原元数据值
+func (mv *MetadataValue) UnmarshalJSON(data []byte) error {
+	var tagged struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return err
+	}
+
+	var err error
+	switch tagged.Type {
+	case metadataTypeString:
+		var v string
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	case metadataTypeBool:
+		var v bool
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	case metadataTypeInt:
+		var v int
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	case metadataTypeInt64:
+		var v int64
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	case metadataTypeFloat64:
+		var v float64
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	case metadataTypeTime:
+		var v time.Time
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	case metadataTypeDuration:
+		var v time.Duration
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	case metadataTypeStrings:
+		var v []string
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	case metadataTypeJSON:
+		var v interface{}
+		err = json.Unmarshal(tagged.Value, &v)
+		mv.Value = v
+	default:
+		return fmt.Errorf("未知的元数据类型: %s", tagged.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("解析 %s 类型的元数据值失败: %v", tagged.Type, err)
+	}
+	return nil
+}
+
+// Save 将资源树保存为 JSON 文件
+// 先写入临时文件再重命名，避免进程中断时留下不完整的文件
+func (rm *ResourceManager) Save(path string) error {
+	file := resourceFile{
+		Version: resourceFileVersion,
+		SavedAt: time.Now(),
+		Root:    snapshotResource(rm.tree.root),
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化资源树失败: %v", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("创建目录失败: %v", err)
+		}
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("写入资源文件失败: %v", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("写入资源文件失败: %v", err)
+	}
+
+	rm.logger.Info("资源树已保存到", path)
+	return nil
+}
+
+// Load 从 JSON 文件加载资源树，替换当前的资源树
+func (rm *ResourceManager) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取资源文件失败: %v", err)
+	}
+
+	var file resourceFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("解析资源文件失败: %v", err)
+	}
+	if file.Version != resourceFileVersion {
+		return fmt.Errorf("不支持的资源文件版本: %d", file.Version)
+	}
+	if file.Root.Type != "directory" {
+		return fmt.Errorf("资源文件的根节点必须是目录")
+	}
+
+	root, err := restoreResource(file.Root)
+	if err != nil {
+		return err
+	}
+
+	rm.tree = NewResourceTree(root)
+	rm.logger.Info("已加载资源树:", path)
+	return nil
+}
+
+// snapshotResource 递归生成资源组件快照，子组件按名称排序保证输出稳定
+func snapshotResource(component ResourceComponent) resourceSnapshot {
+	snapshot := resourceSnapshot{
+		Name:         component.GetName(),
+		Path:         component.GetPath(),
+		Type:         component.GetType(),
+		CreatedTime:  component.GetCreatedTime(),
+		ModifiedTime: component.GetModifiedTime(),
+	}
+
+	if metadata := component.GetMetadata(); len(metadata) > 0 {
+		snapshot.Metadata = make(map[string]MetadataValue, len(metadata))
+		for key, value := range metadata {
+			snapshot.Metadata[key] = MetadataValue{Value: value}
+		}
+	}
+
+	if !component.IsDirectory() {
+		snapshot.Size = component.GetSize()
+		return snapshot
+	}
+
+	children := component.GetChildren()
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].GetName() < children[j].GetName()
+	})
+	for _, child := range children {
+		snapshot.Children = append(snapshot.Children, snapshotResource(child))
+	}
+	return snapshot
+}
+
+// restoreResource 由快照递归重建资源组件，保留原有的创建与修改时间
+func restoreResource(snapshot resourceSnapshot) (ResourceComponent, error) {
+	metadata := make(map[string]interface{}, len(snapshot.Metadata))
+	for key, value := range snapshot.Metadata {
+		metadata[key] = value.Value
+	}
+
+	switch snapshot.Type {
+	case "file":
+		file := NewFileResource(snapshot.Name, snapshot.Path, snapshot.Size)
+		file.createdTime = snapshot.CreatedTime
+		file.modifiedTime = snapshot.ModifiedTime
+		file.metadata = metadata
+		return file, nil
+
+	case "directory":
+		dir := NewDirectoryResource(snapshot.Name, snapshot.Path)
+		dir.metadata = metadata
+		for _, childSnapshot := range snapshot.Children {
+			child, err := restoreResource(childSnapshot)
+			if err != nil {
+				return nil, err
+			}
+			if err := dir.AddChild(child); err != nil {
+				return nil, fmt.Errorf("恢复资源 %s 失败: %v", childSnapshot.Path, err)
+			}
+		}
+		// AddChild 会更新修改时间，子组件恢复完成后再设置
+		dir.createdTime = snapshot.CreatedTime
+		dir.modifiedTime = snapshot.ModifiedTime
+		return dir, nil
+
+	default:
+		return nil, fmt.Errorf("未知的资源类型: %s", snapshot.Type)
+	}
+}
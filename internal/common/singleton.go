@@ -1,54 +1,24 @@
 package common
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"io"
 	"sync"
 )
 
-// Logger 全局日志记录器
-type Logger struct {
-	logger *log.Logger
-}
-
-var (
-	loggerInstance *Logger
-	loggerOnce     sync.Once
-)
-
-// GetLogger 获取日志记录器单例
-func GetLogger() *Logger {
-	loggerOnce.Do(func() {
-		loggerInstance = &Logger{
-			logger: log.Default(),
-		}
-	})
-	return loggerInstance
-}
-
-// Info 记录信息日志
-func (l *Logger) Info(v ...interface{}) {
-	l.logger.Println("[INFO]", v)
-}
-
-// Warn 记录警告日志
-func (l *Logger) Warn(v ...interface{}) {
-	l.logger.Println("[WARN]", v)
-}
-
-// Error 记录错误日志
-func (l *Logger) Error(v ...interface{}) {
-	l.logger.Println("[ERROR]", v)
-}
-
-// Debug 记录调试日志
-func (l *Logger) Debug(v ...interface{}) {
-	l.logger.Println("[DEBUG]", v)
-}
-
 // GlobalResourceManager 全局资源管理器
 type GlobalResourceManager struct {
 	resources map[string]interface{}
-	mutex     sync.RWMutex
+	// closers 通过 RegisterCloser 登记的资源，按登记顺序保存，Shutdown 时逆序关闭
+	closers []namedCloser
+	mutex   sync.RWMutex
+}
+
+// namedCloser 登记的待关闭资源
+type namedCloser struct {
+	key    string
+	closer io.Closer
 }
 
 var (
@@ -105,14 +75,55 @@ func (rm *GlobalResourceManager) GetInt(key string) (int, bool) {
 	return 0, false
 }
 
-// Delete 删除资源
+// Delete 删除资源，通过 RegisterCloser 登记的资源同时取消登记（不会关闭）
 func (rm *GlobalResourceManager) Delete(key string) {
 	rm.mutex.Lock()
 	defer rm.mutex.Unlock()
 	delete(rm.resources, key)
+	rm.unregisterCloser(key)
+}
+
+// RegisterCloser 登记需要在 Shutdown 时关闭的资源，同时可以通过 Get(key) 获取
+// 同一个 key 重复登记时替换之前的资源，并按最新的登记顺序关闭
+func (rm *GlobalResourceManager) RegisterCloser(key string, c io.Closer) {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	rm.unregisterCloser(key)
+	rm.closers = append(rm.closers, namedCloser{key: key, closer: c})
+	rm.resources[key] = c
+}
+
+// Shutdown 按登记顺序的逆序关闭所有资源并取消登记
+// 某个资源关闭失败时继续关闭其余资源，返回所有失败合并后的错误
+func (rm *GlobalResourceManager) Shutdown() error {
+	rm.mutex.Lock()
+	closers := rm.closers
+	rm.closers = nil
+	for _, nc := range closers {
+		delete(rm.resources, nc.key)
+	}
+	rm.mutex.Unlock()
+
+	var errs []error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("关闭资源 %s 失败: %w", closers[i].key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unregisterCloser 取消登记，调用方需持有写锁
+func (rm *GlobalResourceManager) unregisterCloser(key string) {
+	for i, nc := range rm.closers {
+		if nc.key == key {
+			rm.closers = append(rm.closers[:i], rm.closers[i+1:]...)
+			return
+		}
+	}
 }
 
-// Clear 清空所有资源
+// Clear 清空所有资源，通过 RegisterCloser 登记的资源仍会在 Shutdown 时关闭
 func (rm *GlobalResourceManager) Clear() {
 	rm.mutex.Lock()
 	defer rm.mutex.Unlock()
@@ -136,61 +147,3 @@ func (rm *GlobalResourceManager) Count() int {
 	defer rm.mutex.RUnlock()
 	return len(rm.resources)
 }
-
-// ConfigCache 配置缓存
-type ConfigCache struct {
-	cache map[string]interface{}
-	mutex sync.RWMutex
-}
-
-var (
-	configInstance *ConfigCache
-	configOnce     sync.Once
-)
-
-// GetConfigCache 获取配置缓存单例
-func GetConfigCache() *ConfigCache {
-	configOnce.Do(func() {
-		configInstance = &ConfigCache{
-			cache: make(map[string]interface{}),
-		}
-	})
-	return configInstance
-}
-
-// Set 设置缓存
-func (cc *ConfigCache) Set(key string, value interface{}) {
-	cc.mutex.Lock()
-	defer cc.mutex.Unlock()
-	cc.cache[key] = value
-}
-
-// Get 获取缓存
-func (cc *ConfigCache) Get(key string) (interface{}, bool) {
-	cc.mutex.RLock()
-	defer cc.mutex.RUnlock()
-	value, exists := cc.cache[key]
-	return value, exists
-}
-
-// Delete 删除缓存
-func (cc *ConfigCache) Delete(key string) {
-	cc.mutex.Lock()
-	defer cc.mutex.Unlock()
-	delete(cc.cache, key)
-}
-
-// Clear 清空缓存
-func (cc *ConfigCache) Clear() {
-	cc.mutex.Lock()
-	defer cc.mutex.Unlock()
-	cc.cache = make(map[string]interface{})
-}
-
-// Exists 检查键是否存在
-func (cc *ConfigCache) Exists(key string) bool {
-	cc.mutex.RLock()
-	defer cc.mutex.RUnlock()
-	_, exists := cc.cache[key]
-	return exists
-}
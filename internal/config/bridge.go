@@ -1,9 +1,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"creeper/internal/common"
@@ -59,6 +61,48 @@ func (cb *ConfigBridge) LoadConfig(path string) (*Config, error) {
 	return config, nil
 }
 
+// ErrConfigNotFound 在所有搜索路径中均未找到配置文件
+var ErrConfigNotFound = errors.New("未找到配置文件")
+
+// ConfigFileNames 配置文件自动发现时依次尝试的文件名
+var ConfigFileNames = []string{
+	"config.yaml",
+	"config.yml",
+	"creeper.yaml",
+	filepath.Join(".creeper", "config.yaml"),
+}
+
+// DefaultSearchPaths 默认的配置搜索路径：当前目录和用户主目录
+func DefaultSearchPaths() []string {
+	paths := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		paths = append(paths, home)
+	}
+	return paths
+}
+
+// FindConfigFile 在搜索路径中查找第一个存在的配置文件
+func FindConfigFile(searchPaths []string) (string, error) {
+	for _, dir := range searchPaths {
+		for _, name := range ConfigFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+	}
+	return "", ErrConfigNotFound
+}
+
+// DiscoverConfig 自动发现并加载配置文件
+func (cb *ConfigBridge) DiscoverConfig(searchPaths []string) (*Config, error) {
+	path, err := FindConfigFile(searchPaths)
+	if err != nil {
+		return nil, err
+	}
+	return cb.LoadConfig(path)
+}
+
 // SaveConfig 保存配置
 func (cb *ConfigBridge) SaveConfig(config *Config, path string) error {
 	cb.logger.Info("保存配置文件:", path)
@@ -198,6 +242,11 @@ func (dcv *DefaultConfigValidator) Validate(config *Config) error {
 		return fmt.Errorf("构建配置验证失败: %w", err)
 	}
 	
+	// 验证解析配置
+	if err := dcv.validateParsingConfig(config.Parsing); err != nil {
+		return fmt.Errorf("解析配置验证失败: %w", err)
+	}
+	
 	// 验证部署配置
 	if config.Deploy != nil {
 		if err := dcv.validateDeployConfig(config.Deploy); err != nil {
@@ -269,6 +318,20 @@ func (dcv *DefaultConfigValidator) validateBuildConfig(build BuildConfig) error
 	return nil
 }
 
+// validateParsingConfig 验证解析配置中的自定义正则
+func (dcv *DefaultConfigValidator) validateParsingConfig(parsing ParsingConfig) error {
+	if _, err := regexp.Compile(parsing.ChapterRegex); err != nil {
+		return fmt.Errorf("chapter_regex 无效: %v", err)
+	}
+	if _, err := regexp.Compile(parsing.VolumeRegex); err != nil {
+		return fmt.Errorf("volume_regex 无效: %v", err)
+	}
+	if parsing.MinChapterWords < 0 {
+		return fmt.Errorf("min_chapter_words 不能为负数")
+	}
+	return nil
+}
+
 // validateDeployConfig 验证部署配置
 func (dcv *DefaultConfigValidator) validateDeployConfig(deploy *DeployConfig) error {
 	if deploy.Enabled && deploy.Type == "" {
@@ -278,6 +341,12 @@ func (dcv *DefaultConfigValidator) validateDeployConfig(deploy *DeployConfig) er
 	if deploy.Enabled && deploy.Config == "" {
 		return fmt.Errorf("启用部署时，部署配置文件路径不能为空")
 	}
+
+	switch deploy.Type {
+	case "", "cloudflare", "github", "vercel", "netlify":
+	default:
+		return fmt.Errorf("不支持的部署类型: %s", deploy.Type)
+	}
 	
 	return nil
 }
@@ -18,6 +18,16 @@ func NewConfigBuilder() *ConfigBuilder {
 	}
 }
 
+// Builder 创建配置建造者
+func Builder() *ConfigBuilder {
+	return NewConfigBuilder()
+}
+
+// BuilderWithDefaults 创建带默认值的配置建造者
+func BuilderWithDefaults() *ConfigBuilder {
+	return NewConfigBuilder().WithDefaults()
+}
+
 // WithSite 设置站点配置
 func (b *ConfigBuilder) WithSite(title, description, author, baseURL string) *ConfigBuilder {
 	b.config.Site = SiteConfig{
@@ -53,6 +63,12 @@ func (b *ConfigBuilder) WithSiteBaseURL(baseURL string) *ConfigBuilder {
 	return b
 }
 
+// WithSiteCategories 设置预定义分类
+func (b *ConfigBuilder) WithSiteCategories(categories ...Category) *ConfigBuilder {
+	b.config.Site.Categories = categories
+	return b
+}
+
 // WithDirectories 设置目录配置
 func (b *ConfigBuilder) WithDirectories(inputDir, outputDir string) *ConfigBuilder {
 	b.config.InputDir = inputDir
@@ -147,13 +163,23 @@ func (b *ConfigBuilder) WithLineHeight(lineHeight string) *ConfigBuilder {
 	return b
 }
 
-// WithBuild 设置构建配置
+// WithSpaceScale 设置间距缩放系数
+func (b *ConfigBuilder) WithSpaceScale(scale float64) *ConfigBuilder {
+	b.config.Theme.SpaceScale = scale
+	return b
+}
+
+// WithTemplateDir 设置自定义模板目录
+func (b *ConfigBuilder) WithTemplateDir(dir string) *ConfigBuilder {
+	b.config.Theme.TemplateDir = dir
+	return b
+}
+
+// WithBuild 设置压缩选项，其余构建配置保持不变
 func (b *ConfigBuilder) WithBuild(minifyHTML, minifyCSS, minifyJS bool) *ConfigBuilder {
-	b.config.Build = BuildConfig{
-		MinifyHTML: minifyHTML,
-		MinifyCSS:  minifyCSS,
-		MinifyJS:   minifyJS,
-	}
+	b.config.Build.MinifyHTML = minifyHTML
+	b.config.Build.MinifyCSS = minifyCSS
+	b.config.Build.MinifyJS = minifyJS
 	return b
 }
 
@@ -175,6 +201,42 @@ func (b *ConfigBuilder) WithMinifyJS(minify bool) *ConfigBuilder {
 	return b
 }
 
+// WithNewChapterDays 设置更新日志中标记为新章节的天数
+func (b *ConfigBuilder) WithNewChapterDays(days int) *ConfigBuilder {
+	b.config.Build.NewChapterDays = days
+	return b
+}
+
+// WithChapterTransitions 设置切换章节时是否播放过渡动画
+func (b *ConfigBuilder) WithChapterTransitions(enabled bool) *ConfigBuilder {
+	b.config.Build.ChapterTransitions = enabled
+	return b
+}
+
+// WithWorkers 设置并行解析与渲染的并发数
+func (b *ConfigBuilder) WithWorkers(workers int) *ConfigBuilder {
+	b.config.Build.Workers = workers
+	return b
+}
+
+// WithSkipCovers 设置是否跳过小说封面生成
+func (b *ConfigBuilder) WithSkipCovers(skip bool) *ConfigBuilder {
+	b.config.Build.SkipCovers = skip
+	return b
+}
+
+// WithMaxChapterWordsPerPage 设置单页章节的最大字数
+func (b *ConfigBuilder) WithMaxChapterWordsPerPage(words int) *ConfigBuilder {
+	b.config.Build.MaxChapterWordsPerPage = words
+	return b
+}
+
+// WithStrictMode 设置严格模式
+func (b *ConfigBuilder) WithStrictMode(strict bool) *ConfigBuilder {
+	b.config.Build.StrictMode = strict
+	return b
+}
+
 // WithDefaults 使用默认配置
 func (b *ConfigBuilder) WithDefaults() *ConfigBuilder {
 	defaultConfig := Default()
@@ -182,8 +244,10 @@ func (b *ConfigBuilder) WithDefaults() *ConfigBuilder {
 	return b
 }
 
-// Build 构建配置
-func (b *ConfigBuilder) Build() *Config {
+// Build 补全未设置的字段后校验并返回配置
+// 站点标题、描述、基础URL、输入输出目录与主题样式为空时总会被填充默认值，
+// 因此 Build 只校验数值范围，不会因这些字段为空而失败
+func (b *ConfigBuilder) Build() (*Config, error) {
 	// 验证必要字段
 	if b.config.Site.Title == "" {
 		b.config.Site.Title = "我的小说站点"
@@ -226,11 +290,14 @@ func (b *ConfigBuilder) Build() *Config {
 	if b.config.Theme.LineHeight == "" {
 		b.config.Theme.LineHeight = "1.6"
 	}
-	
-	return b.config
+
+	if err := b.validateRanges(); err != nil {
+		return nil, err
+	}
+	return b.config, nil
 }
 
-// Validate 验证配置
+// Validate 验证配置，用于未经 Build 补全默认值的配置
 func (b *ConfigBuilder) Validate() error {
 	if b.config.Site.Title == "" {
 		return fmt.Errorf("站点标题不能为空")
@@ -241,19 +308,48 @@ func (b *ConfigBuilder) Validate() error {
 	if b.config.OutputDir == "" {
 		return fmt.Errorf("输出目录不能为空")
 	}
+	return b.validateRanges()
+}
+
+// validateRanges 验证数值配置的范围
+func (b *ConfigBuilder) validateRanges() error {
+	if b.config.Theme.SpaceScale < 0 {
+		return fmt.Errorf("间距缩放系数不能为负数: %v", b.config.Theme.SpaceScale)
+	}
+	if b.config.Build.NewChapterDays < 0 {
+		return fmt.Errorf("新章节天数不能为负数: %d", b.config.Build.NewChapterDays)
+	}
+	if b.config.Build.Workers < 0 {
+		return fmt.Errorf("并发数不能为负数: %d", b.config.Build.Workers)
+	}
+	if b.config.Build.MaxChapterWordsPerPage < 0 {
+		return fmt.Errorf("单页章节最大字数不能为负数: %d", b.config.Build.MaxChapterWordsPerPage)
+	}
 	return nil
 }
 
 // Clone 克隆配置建造者
 func (b *ConfigBuilder) Clone() *ConfigBuilder {
-	newConfig := &Config{
-		Site:      b.config.Site,
-		Theme:     b.config.Theme,
-		Build:     b.config.Build,
-		InputDir:  b.config.InputDir,
-		OutputDir: b.config.OutputDir,
+	newConfig := *b.config
+	newConfig.Site.Categories = cloneCategories(b.config.Site.Categories)
+	if b.config.Deploy != nil {
+		deploy := *b.config.Deploy
+		newConfig.Deploy = &deploy
+	}
+	return &ConfigBuilder{config: &newConfig}
+}
+
+// cloneCategories 深拷贝分类及其子分类
+func cloneCategories(categories []Category) []Category {
+	if categories == nil {
+		return nil
+	}
+	clone := make([]Category, len(categories))
+	for i, category := range categories {
+		clone[i] = category
+		clone[i].SubCategories = cloneCategories(category.SubCategories)
 	}
-	return &ConfigBuilder{config: newConfig}
+	return clone
 }
 
 // Reset 重置配置
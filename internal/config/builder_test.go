@@ -0,0 +1,205 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildFillsDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *ConfigBuilder
+		check   func(t *testing.T, c *Config)
+	}{
+		{
+			name:    "empty builder",
+			builder: NewConfigBuilder(),
+			check: func(t *testing.T, c *Config) {
+				want := map[string][2]string{
+					"Site.Title":            {c.Site.Title, "我的小说站点"},
+					"Site.Description":      {c.Site.Description, "静态小说阅读站点"},
+					"Site.BaseURL":          {c.Site.BaseURL, "/"},
+					"InputDir":              {c.InputDir, "novels"},
+					"OutputDir":             {c.OutputDir, "dist"},
+					"Theme.Name":            {c.Theme.Name, "default"},
+					"Theme.PrimaryColor":    {c.Theme.PrimaryColor, "#2c3e50"},
+					"Theme.SecondaryColor":  {c.Theme.SecondaryColor, "#3498db"},
+					"Theme.BackgroundColor": {c.Theme.BackgroundColor, "#ffffff"},
+					"Theme.TextColor":       {c.Theme.TextColor, "#333333"},
+					"Theme.FontSize":        {c.Theme.FontSize, "16px"},
+					"Theme.LineHeight":      {c.Theme.LineHeight, "1.6"},
+				}
+				for field, v := range want {
+					if v[0] != v[1] {
+						t.Errorf("%s = %q, want %q", field, v[0], v[1])
+					}
+				}
+				if c.Theme.FontFamily == "" {
+					t.Error("Theme.FontFamily 未填充默认值")
+				}
+			},
+		},
+		{
+			name: "explicit values are kept",
+			builder: NewConfigBuilder().
+				WithSite("站点", "描述", "作者", "https://example.com/").
+				WithDirectories("src", "public").
+				WithThemeName("dark"),
+			check: func(t *testing.T, c *Config) {
+				if c.Site.Title != "站点" || c.Site.Description != "描述" || c.Site.BaseURL != "https://example.com/" {
+					t.Errorf("Site = %+v", c.Site)
+				}
+				if c.InputDir != "src" || c.OutputDir != "public" {
+					t.Errorf("InputDir, OutputDir = %q, %q", c.InputDir, c.OutputDir)
+				}
+				if c.Theme.Name != "dark" {
+					t.Errorf("Theme.Name = %q, want dark", c.Theme.Name)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			tt.check(t, c)
+		})
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	valid := func() *ConfigBuilder {
+		return NewConfigBuilder().WithSiteTitle("站点").WithDirectories("novels", "dist")
+	}
+
+	tests := []struct {
+		name    string
+		builder *ConfigBuilder
+		wantErr string
+	}{
+		{name: "valid", builder: valid()},
+		{name: "empty title", builder: valid().WithSiteTitle(""), wantErr: "站点标题不能为空"},
+		{name: "empty input dir", builder: valid().WithInputDir(""), wantErr: "输入目录不能为空"},
+		{name: "empty output dir", builder: valid().WithOutputDir(""), wantErr: "输出目录不能为空"},
+		{name: "negative space scale", builder: valid().WithSpaceScale(-0.5), wantErr: "间距缩放系数不能为负数"},
+		{name: "negative new chapter days", builder: valid().WithNewChapterDays(-1), wantErr: "新章节天数不能为负数"},
+		{name: "negative workers", builder: valid().WithWorkers(-2), wantErr: "并发数不能为负数"},
+		{name: "negative words per page", builder: valid().WithMaxChapterWordsPerPage(-100), wantErr: "单页章节最大字数不能为负数"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.builder.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildRejectsInvalidRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *ConfigBuilder
+	}{
+		{name: "negative space scale", builder: NewConfigBuilder().WithSpaceScale(-1)},
+		{name: "negative new chapter days", builder: NewConfigBuilder().WithNewChapterDays(-1)},
+		{name: "negative workers", builder: NewConfigBuilder().WithWorkers(-1)},
+		{name: "negative words per page", builder: NewConfigBuilder().WithMaxChapterWordsPerPage(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := tt.builder.Build(); err == nil {
+				t.Fatalf("Build() = %+v, want error", c)
+			}
+		})
+	}
+}
+
+func TestCloneDeepCopies(t *testing.T) {
+	original := NewConfigBuilder().WithSiteCategories(Category{
+		Name:          "玄幻",
+		SubCategories: []Category{{Name: "东方玄幻"}},
+	})
+	original.config.Deploy = &DeployConfig{Enabled: true, Type: "github", Config: "deploy.json"}
+
+	tests := []struct {
+		name   string
+		mutate func(c *Config)
+		check  func(t *testing.T, c *Config)
+	}{
+		{
+			name:   "categories",
+			mutate: func(c *Config) { c.Site.Categories[0].Name = "仙侠" },
+			check: func(t *testing.T, c *Config) {
+				if c.Site.Categories[0].Name != "玄幻" {
+					t.Errorf("原分类被修改为 %q", c.Site.Categories[0].Name)
+				}
+			},
+		},
+		{
+			name:   "appended category",
+			mutate: func(c *Config) { c.Site.Categories = append(c.Site.Categories, Category{Name: "都市"}) },
+			check: func(t *testing.T, c *Config) {
+				if len(c.Site.Categories) != 1 {
+					t.Errorf("len(Categories) = %d, want 1", len(c.Site.Categories))
+				}
+			},
+		},
+		{
+			name:   "sub categories",
+			mutate: func(c *Config) { c.Site.Categories[0].SubCategories[0].Name = "异世大陆" },
+			check: func(t *testing.T, c *Config) {
+				if got := c.Site.Categories[0].SubCategories[0].Name; got != "东方玄幻" {
+					t.Errorf("原子分类被修改为 %q", got)
+				}
+			},
+		},
+		{
+			name: "deploy",
+			mutate: func(c *Config) {
+				c.Deploy.Type = "netlify"
+				c.Deploy.Enabled = false
+			},
+			check: func(t *testing.T, c *Config) {
+				if c.Deploy.Type != "github" || !c.Deploy.Enabled {
+					t.Errorf("原部署配置被修改为 %+v", *c.Deploy)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clone := original.Clone()
+			if clone.config == original.config {
+				t.Fatal("Clone() 返回的配置与原配置相同")
+			}
+			if clone.config.Deploy == original.config.Deploy {
+				t.Fatal("Clone() 未复制 Deploy")
+			}
+			tt.mutate(clone.config)
+			tt.check(t, original.config)
+		})
+	}
+}
+
+func TestCloneNilDeploy(t *testing.T) {
+	clone := NewConfigBuilder().Clone()
+	if clone.config.Deploy != nil {
+		t.Errorf("Deploy = %+v, want nil", clone.config.Deploy)
+	}
+	if clone.config.Site.Categories != nil {
+		t.Errorf("Categories = %v, want nil", clone.config.Site.Categories)
+	}
+}
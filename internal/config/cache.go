@@ -0,0 +1,20 @@
+package config
+
+import (
+	"sync"
+
+	"creeper/internal/common"
+)
+
+var (
+	configCacheInstance *common.Cache[string, *Config]
+	configCacheOnce     sync.Once
+)
+
+// GetConfigCache 获取配置缓存单例，键为配置文件路径
+func GetConfigCache() *common.Cache[string, *Config] {
+	configCacheOnce.Do(func() {
+		configCacheInstance = common.NewCache[string, *Config]()
+	})
+	return configCacheInstance
+}
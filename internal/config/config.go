@@ -5,66 +5,178 @@ import (
 	"os"
 )
 
+// 字段的 desc 标签是 schemas/config-schema.json 中的配置说明，修改字段后重新生成
+//go:generate go run ../../cmd/schema -o ../../schemas/config-schema.json
+
 // Config 配置结构
 type Config struct {
 	// 站点基本信息
-	Site SiteConfig `yaml:"site"`
+	Site SiteConfig `yaml:"site" desc:"站点基本信息"`
 
 	// 目录配置
-	InputDir  string `yaml:"input_dir"`
-	OutputDir string `yaml:"output_dir"`
+	InputDir  string `yaml:"input_dir" desc:"小说源文件目录"`
+	OutputDir string `yaml:"output_dir" desc:"生成的站点目录"`
 
 	// 主题配置
-	Theme ThemeConfig `yaml:"theme"`
+	Theme ThemeConfig `yaml:"theme" desc:"主题配置"`
 
 	// 构建配置
-	Build BuildConfig `yaml:"build"`
+	Build BuildConfig `yaml:"build" desc:"构建配置"`
+
+	// 封面配置
+	Cover CoverConfig `yaml:"cover" desc:"封面配置"`
+
+	// 解析配置
+	Parsing ParsingConfig `yaml:"parsing" desc:"小说解析配置"`
+
+	// Markdown 渲染配置
+	Markdown MarkdownConfig `yaml:"markdown" desc:"Markdown 渲染配置"`
+
+	// 搜索配置
+	Search SearchConfig `yaml:"search" desc:"站内搜索配置"`
+
+	// 订阅源配置
+	Feed FeedConfig `yaml:"feed" desc:"Atom 订阅源配置"`
+
+	// 站点地图配置
+	Sitemap SitemapConfig `yaml:"sitemap" desc:"站点地图配置"`
+
+	// 首页分页配置
+	Pagination PaginationConfig `yaml:"pagination" desc:"首页分页配置"`
 
 	// 部署配置
-	Deploy *DeployConfig `yaml:"deploy,omitempty"`
+	Deploy *DeployConfig `yaml:"deploy,omitempty" desc:"部署配置"`
 }
 
 // DeployConfig 部署配置
 type DeployConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	Type    string `yaml:"type"`
-	Config  string `yaml:"config"`
+	Enabled bool   `yaml:"enabled" desc:"是否启用部署"`
+	Type    string `yaml:"type" desc:"部署类型：cloudflare、github、vercel 或 netlify"`
+	Config  string `yaml:"config" desc:"部署配置文件路径"`
 }
 
 // SiteConfig 站点配置
 type SiteConfig struct {
-	Title       string `yaml:"title"`
-	Description string `yaml:"description"`
-	Author      string `yaml:"author"`
-	BaseURL     string `yaml:"base_url"`
-	Categories  []Category `yaml:"categories,omitempty"`
+	Title       string `yaml:"title" desc:"站点标题"`
+	Description string `yaml:"description" desc:"站点描述"`
+	Author      string `yaml:"author" desc:"站点作者"`
+	BaseURL     string `yaml:"base_url" desc:"站点根路径或地址，如 / 或 https://example.com/"`
+	Categories  []Category `yaml:"categories,omitempty" desc:"预定义的分类"`
 }
 
 // Category 分类配置
 type Category struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-	Color       string `yaml:"color"`
-	Icon        string `yaml:"icon"`
+	Name        string `yaml:"name" desc:"分类名称"`
+	Description string `yaml:"description" desc:"分类描述"`
+	Color       string `yaml:"color" desc:"分类颜色"`
+	Icon        string `yaml:"icon" desc:"分类图标"`
+	// 子分类，用于预定义子分类的颜色、图标与描述
+	SubCategories []Category `yaml:"sub_categories,omitempty" desc:"子分类，用于预定义子分类的颜色、图标与描述"`
 }
 
 // ThemeConfig 主题配置
 type ThemeConfig struct {
-	Name            string `yaml:"name"`
-	PrimaryColor    string `yaml:"primary_color"`
-	SecondaryColor  string `yaml:"secondary_color"`
-	BackgroundColor string `yaml:"background_color"`
-	TextColor       string `yaml:"text_color"`
-	FontFamily      string `yaml:"font_family"`
-	FontSize        string `yaml:"font_size"`
-	LineHeight      string `yaml:"line_height"`
+	Name            string `yaml:"name" desc:"主题名称"`
+	PrimaryColor    string `yaml:"primary_color" desc:"主色调"`
+	SecondaryColor  string `yaml:"secondary_color" desc:"辅色调"`
+	BackgroundColor string `yaml:"background_color" desc:"背景色"`
+	TextColor       string `yaml:"text_color" desc:"文字颜色"`
+	FontFamily      string `yaml:"font_family" desc:"正文字体"`
+	FontSize        string `yaml:"font_size" desc:"正文字号，如 16px"`
+	LineHeight      string `yaml:"line_height" desc:"正文行高"`
+	// 间距缩放系数，作用于所有 --space-* 变量，默认 1.0
+	SpaceScale float64 `yaml:"space_scale" desc:"间距缩放系数，作用于所有 --space-* 变量，默认 1.0"`
+	// 自定义模板目录，其中的 <模板类型>.html 会覆盖内置模板
+	TemplateDir string `yaml:"templates_dir,omitempty" desc:"自定义模板目录，其中的 <模板类型>.html 会覆盖内置模板"`
 }
 
 // BuildConfig 构建配置
 type BuildConfig struct {
-	MinifyHTML bool `yaml:"minify_html"`
-	MinifyCSS  bool `yaml:"minify_css"`
-	MinifyJS   bool `yaml:"minify_js"`
+	MinifyHTML bool `yaml:"minify_html" desc:"压缩 HTML"`
+	MinifyCSS  bool `yaml:"minify_css" desc:"压缩 CSS"`
+	MinifyJS   bool `yaml:"minify_js" desc:"压缩 JavaScript"`
+
+	// 最近多少天内发布的章节在更新日志中标记为新章节
+	NewChapterDays int `yaml:"new_chapter_days" desc:"最近多少天内发布的章节在更新日志中标记为新章节"`
+
+	// 通过上一章/下一章切换时播放淡出与滑入动画
+	ChapterTransitions bool `yaml:"chapter_transitions" desc:"通过上一章/下一章切换时播放淡出与滑入动画"`
+
+	// 并行解析小说与渲染页面的并发数，为 0 时使用 CPU 核数
+	Workers int `yaml:"workers" desc:"并行解析小说与渲染页面的并发数，为 0 时使用 CPU 核数"`
+
+	// 生成站点时不生成小说封面，页面使用默认封面
+	SkipCovers bool `yaml:"skip_covers" desc:"不生成小说封面，页面使用默认封面"`
+
+	// 单页章节的最大字数，超过时在段落边界拆分为多页；为 0 时不拆分
+	MaxChapterWordsPerPage int `yaml:"max_chapter_words_per_page" desc:"单页章节的最大字数，超过时在段落边界拆分为多页；为 0 时不拆分"`
+
+	// 严格模式：封面生成、小说解析等失败时仍继续生成，但结束后返回错误，便于 CI 判断构建是否完全成功
+	StrictMode bool `yaml:"strict_mode" desc:"严格模式：生成中被跳过的错误在结束后作为错误返回"`
+}
+
+// CoverConfig 封面配置
+type CoverConfig struct {
+	// 未设置副标题时，使用简介的第一句作为封面副标题
+	UseDescriptionAsSubtitle bool `yaml:"use_description_as_subtitle" desc:"未设置副标题时，使用简介的第一句作为封面副标题"`
+}
+
+// SearchConfig 站内搜索配置
+type SearchConfig struct {
+	Enabled bool `yaml:"enabled" desc:"是否生成站内搜索"`
+	// 索引章节正文开头，可按内容搜索，但会增大 search-data.json
+	IncludeContent bool `yaml:"include_content" desc:"索引章节正文开头，可按内容搜索，但会增大 search-data.json"`
+	// 每个章节索引的正文字符数，为 0 时使用 500
+	MaxContentLength int `yaml:"max_content_length" desc:"每个章节索引的正文字符数，为 0 时使用 500"`
+}
+
+// FeedConfig Atom 订阅源配置
+type FeedConfig struct {
+	Enabled bool `yaml:"enabled" desc:"是否生成 Atom 订阅源"`
+	// 每个订阅源最多包含的章节数，为 0 时使用 20
+	MaxItems int `yaml:"max_items" desc:"每个订阅源最多包含的章节数，为 0 时使用 20"`
+	// 订阅源标题，为空时使用站点标题
+	Title string `yaml:"title" desc:"订阅源标题，为空时使用站点标题"`
+}
+
+// SitemapConfig 站点地图配置
+type SitemapConfig struct {
+	Enabled bool `yaml:"enabled" desc:"是否生成站点地图"`
+	// 站点的绝对地址（如 https://example.com/），站点地图要求完整的 URL；为空时使用 site.base_url
+	BaseURL string `yaml:"base_url" desc:"站点的绝对地址，为空时使用 site.base_url"`
+	// 单个站点地图文件最多包含的 URL 数，超过时拆分为多个文件并生成索引；为 0 时使用协议上限 50000
+	MaxPerFile int `yaml:"max_per_file" desc:"单个站点地图文件最多包含的 URL 数，为 0 时使用 50000"`
+}
+
+// PaginationConfig 首页分页配置
+type PaginationConfig struct {
+	Enabled bool `yaml:"enabled" desc:"首页是否分页"`
+	// 每页显示的小说数，为 0 时使用 20
+	ItemsPerPage int `yaml:"items_per_page" desc:"每页显示的小说数，为 0 时使用 20"`
+}
+
+// ParsingConfig 解析配置
+type ParsingConfig struct {
+	// 统一 TXT 内容中的弯引号与直引号，默认关闭以保留原有排版
+	NormalizeQuotes bool `yaml:"normalize_quotes" desc:"统一 TXT 内容中的弯引号与直引号"`
+	// 引号统一风格：ascii（直引号）或 unicode（成对弯引号）
+	QuoteStyle string `yaml:"quote_style" desc:"引号统一风格：ascii 或 unicode"`
+	// 根据字频估算小说阅读难度并在卡片上显示，较耗 CPU，默认关闭
+	ComputeDifficulty bool `yaml:"compute_difficulty" desc:"根据字频估算小说阅读难度"`
+	// TXT 章节标题正则，非空时替换内置的“第X章”等规则；第一个捕获组作为章节名
+	ChapterRegex string `yaml:"chapter_regex" desc:"TXT 章节标题正则，第一个捕获组作为章节名"`
+	// TXT 卷标题正则，非空时替换内置的“第X卷”等规则；第一个捕获组作为卷名
+	VolumeRegex string `yaml:"volume_regex" desc:"TXT 卷标题正则，第一个捕获组作为卷名"`
+	// 字数少于该值的 TXT 章节视为误识别的标题，并入上一章；为 0 时不合并
+	MinChapterWords int `yaml:"min_chapter_words" desc:"字数少于该值的 TXT 章节并入上一章；为 0 时不合并"`
+	// TXT 文件名“作者 - 书名.txt”中作者与书名的分隔符，为空时使用 " - "
+	FilenameSeparator string `yaml:"filename_separator" desc:"TXT 文件名“作者 - 书名.txt”中的分隔符，为空时使用 \" - \""`
+}
+
+// MarkdownConfig Markdown 渲染配置
+type MarkdownConfig struct {
+	// 渲染器：blackfriday（默认）或 goldmark（CommonMark，支持表格、脚注与排版优化）
+	Renderer string `yaml:"renderer" desc:"渲染器：blackfriday（默认）或 goldmark"`
 }
 
 // Default 返回默认配置
@@ -87,16 +199,48 @@ func Default() *Config {
 			FontFamily:      "'Segoe UI', 'PingFang SC', 'Microsoft YaHei', sans-serif",
 			FontSize:        "16px",
 			LineHeight:      "1.6",
+			SpaceScale:      1.0,
 		},
 		Build: BuildConfig{
 			MinifyHTML: true,
 			MinifyCSS:  true,
 			MinifyJS:   true,
+
+			NewChapterDays:     7,
+			ChapterTransitions: false,
+		},
+		Cover: CoverConfig{
+			UseDescriptionAsSubtitle: false,
+		},
+		Parsing: ParsingConfig{
+			NormalizeQuotes:   false,
+			QuoteStyle:        "ascii",
+			ComputeDifficulty: false,
+		},
+		Markdown: MarkdownConfig{
+			Renderer: "blackfriday",
+		},
+		Search: SearchConfig{
+			Enabled:          true,
+			IncludeContent:   true,
+			MaxContentLength: 500,
+		},
+		Feed: FeedConfig{
+			Enabled:  true,
+			MaxItems: 20,
+		},
+		Sitemap: SitemapConfig{
+			Enabled:    true,
+			MaxPerFile: 50000,
+		},
+		Pagination: PaginationConfig{
+			Enabled:      false,
+			ItemsPerPage: 20,
 		},
 	}
 }
 
-// Load 从文件加载配置
+// Load 从文件加载配置，CREEPER_* 环境变量会覆盖文件中的值（见 ApplyEnvironment）
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -108,7 +252,7 @@ func Load(path string) (*Config, error) {
 		return nil, err
 	}
 
-	return &config, nil
+	return ApplyEnvironment(&config), nil
 }
 
 // Save 保存配置到文件
@@ -120,13 +264,3 @@ func (c *Config) Save(path string) error {
 
 	return os.WriteFile(path, data, 0644)
 }
-
-// Builder 创建配置建造者
-func Builder() *ConfigBuilder {
-	return NewConfigBuilder()
-}
-
-// BuilderWithDefaults 创建带默认值的配置建造者
-func BuilderWithDefaults() *ConfigBuilder {
-	return NewConfigBuilder().WithDefaults()
-}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// envSetter 将环境变量的值写入配置
+type envSetter func(cfg *Config, value string) error
+
+// envSetters 支持的环境变量，新增变量只需在此登记
+var envSetters = map[string]envSetter{
+	"CREEPER_SITE_TITLE":       stringSetter(func(c *Config) *string { return &c.Site.Title }),
+	"CREEPER_SITE_DESCRIPTION": stringSetter(func(c *Config) *string { return &c.Site.Description }),
+	"CREEPER_SITE_AUTHOR":      stringSetter(func(c *Config) *string { return &c.Site.Author }),
+	"CREEPER_SITE_BASE_URL":    stringSetter(func(c *Config) *string { return &c.Site.BaseURL }),
+
+	"CREEPER_INPUT_DIR":  stringSetter(func(c *Config) *string { return &c.InputDir }),
+	"CREEPER_OUTPUT_DIR": stringSetter(func(c *Config) *string { return &c.OutputDir }),
+
+	"CREEPER_THEME_NAME":          stringSetter(func(c *Config) *string { return &c.Theme.Name }),
+	"CREEPER_THEME_TEMPLATES_DIR": stringSetter(func(c *Config) *string { return &c.Theme.TemplateDir }),
+
+	"CREEPER_BUILD_MINIFY_HTML":                boolSetter(func(c *Config) *bool { return &c.Build.MinifyHTML }),
+	"CREEPER_BUILD_MINIFY_CSS":                 boolSetter(func(c *Config) *bool { return &c.Build.MinifyCSS }),
+	"CREEPER_BUILD_MINIFY_JS":                  boolSetter(func(c *Config) *bool { return &c.Build.MinifyJS }),
+	"CREEPER_BUILD_WORKERS":                    intSetter(func(c *Config) *int { return &c.Build.Workers }),
+	"CREEPER_BUILD_SKIP_COVERS":                boolSetter(func(c *Config) *bool { return &c.Build.SkipCovers }),
+	"CREEPER_BUILD_MAX_CHAPTER_WORDS_PER_PAGE": intSetter(func(c *Config) *int { return &c.Build.MaxChapterWordsPerPage }),
+	"CREEPER_BUILD_STRICT_MODE":                boolSetter(func(c *Config) *bool { return &c.Build.StrictMode }),
+
+	"CREEPER_SEARCH_ENABLED":            boolSetter(func(c *Config) *bool { return &c.Search.Enabled }),
+	"CREEPER_FEED_ENABLED":              boolSetter(func(c *Config) *bool { return &c.Feed.Enabled }),
+	"CREEPER_SITEMAP_ENABLED":           boolSetter(func(c *Config) *bool { return &c.Sitemap.Enabled }),
+	"CREEPER_SITEMAP_BASE_URL":          stringSetter(func(c *Config) *string { return &c.Sitemap.BaseURL }),
+	"CREEPER_PAGINATION_ENABLED":        boolSetter(func(c *Config) *bool { return &c.Pagination.Enabled }),
+	"CREEPER_PAGINATION_ITEMS_PER_PAGE": intSetter(func(c *Config) *int { return &c.Pagination.ItemsPerPage }),
+	"CREEPER_MARKDOWN_RENDERER":         stringSetter(func(c *Config) *string { return &c.Markdown.Renderer }),
+
+	"CREEPER_DEPLOY_ENABLED": boolSetter(func(c *Config) *bool { return &deployConfig(c).Enabled }),
+	"CREEPER_DEPLOY_TYPE":    stringSetter(func(c *Config) *string { return &deployConfig(c).Type }),
+	"CREEPER_DEPLOY_CONFIG":  stringSetter(func(c *Config) *string { return &deployConfig(c).Config }),
+}
+
+// ApplyEnvironment 用 CREEPER_* 环境变量覆盖配置，便于 CI 中不修改 config.yaml 调整配置
+// 未设置的变量不影响配置；布尔值接受 true/false/1/0，无法解析的值输出警告并忽略。
+//
+// 支持的变量：
+//
+//	CREEPER_SITE_TITLE                        site.title
+//	CREEPER_SITE_DESCRIPTION                  site.description
+//	CREEPER_SITE_AUTHOR                       site.author
+//	CREEPER_SITE_BASE_URL                     site.base_url
+//	CREEPER_INPUT_DIR                         input_dir
+//	CREEPER_OUTPUT_DIR                        output_dir
+//	CREEPER_THEME_NAME                        theme.name
+//	CREEPER_THEME_TEMPLATES_DIR               theme.templates_dir
+//	CREEPER_BUILD_MINIFY_HTML                 build.minify_html
+//	CREEPER_BUILD_MINIFY_CSS                  build.minify_css
+//	CREEPER_BUILD_MINIFY_JS                   build.minify_js
+//	CREEPER_BUILD_WORKERS                     build.workers
+//	CREEPER_BUILD_SKIP_COVERS                 build.skip_covers
+//	CREEPER_BUILD_MAX_CHAPTER_WORDS_PER_PAGE  build.max_chapter_words_per_page
+//	CREEPER_BUILD_STRICT_MODE                 build.strict_mode
+//	CREEPER_SEARCH_ENABLED                    search.enabled
+//	CREEPER_FEED_ENABLED                      feed.enabled
+//	CREEPER_SITEMAP_ENABLED                   sitemap.enabled
+//	CREEPER_SITEMAP_BASE_URL                  sitemap.base_url
+//	CREEPER_PAGINATION_ENABLED                pagination.enabled
+//	CREEPER_PAGINATION_ITEMS_PER_PAGE         pagination.items_per_page
+//	CREEPER_MARKDOWN_RENDERER                 markdown.renderer
+//	CREEPER_DEPLOY_ENABLED                    deploy.enabled
+//	CREEPER_DEPLOY_TYPE                       deploy.type
+//	CREEPER_DEPLOY_CONFIG                     deploy.config
+func ApplyEnvironment(cfg *Config) *Config {
+	names := make([]string, 0, len(envSetters))
+	for name := range envSetters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := envSetters[name](cfg, value); err != nil {
+			fmt.Fprintf(os.Stderr, "警告：忽略环境变量 %s=%q: %v\n", name, value, err)
+		}
+	}
+	return cfg
+}
+
+// deployConfig 返回部署配置，未配置时创建
+func deployConfig(cfg *Config) *DeployConfig {
+	if cfg.Deploy == nil {
+		cfg.Deploy = &DeployConfig{}
+	}
+	return cfg.Deploy
+}
+
+// stringSetter 字符串字段
+func stringSetter(field func(*Config) *string) envSetter {
+	return func(cfg *Config, value string) error {
+		*field(cfg) = value
+		return nil
+	}
+}
+
+// boolSetter 布尔字段
+func boolSetter(field func(*Config) *bool) envSetter {
+	return func(cfg *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("不是有效的布尔值")
+		}
+		*field(cfg) = b
+		return nil
+	}
+}
+
+// intSetter 整数字段
+func intSetter(field func(*Config) *int) envSetter {
+	return func(cfg *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("不是有效的整数")
+		}
+		*field(cfg) = n
+		return nil
+	}
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultConfigWatchDebounce 配置文件变化后等待的时间，合并编辑器保存时产生的多次事件
+const DefaultConfigWatchDebounce = 300 * time.Millisecond
+
+// ConfigWatcher 监听配置文件，变化时重新加载并通过 Changes() 发送新配置
+type ConfigWatcher struct {
+	path     string
+	debounce time.Duration
+	changes  chan *Config
+	errors   chan error
+}
+
+// NewConfigWatcher 创建配置文件监听器
+func NewConfigWatcher(path string) *ConfigWatcher {
+	return &ConfigWatcher{
+		path:     path,
+		debounce: DefaultConfigWatchDebounce,
+		changes:  make(chan *Config),
+		errors:   make(chan error, 1),
+	}
+}
+
+// Changes 重新加载后的配置
+func (cw *ConfigWatcher) Changes() <-chan *Config {
+	return cw.changes
+}
+
+// Errors 重新加载失败（如 YAML 语法错误）时的错误，未及时读取的错误会被丢弃
+func (cw *ConfigWatcher) Errors() <-chan error {
+	return cw.errors
+}
+
+// Run 开始监听，直到 ctx 结束；结束时关闭 Changes() 与 Errors()
+// 监听配置文件所在的目录，以便识别编辑器先写临时文件再重命名的保存方式
+func (cw *ConfigWatcher) Run(ctx context.Context) error {
+	defer close(cw.changes)
+	defer close(cw.errors)
+
+	target, err := filepath.Abs(cw.path)
+	if err != nil {
+		return fmt.Errorf("解析配置文件路径失败: %v", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("创建配置文件监听器失败: %v", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(target)); err != nil {
+		return fmt.Errorf("监听配置文件目录失败: %v", err)
+	}
+
+	var (
+		timer *time.Timer
+		fire  <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if name, err := filepath.Abs(event.Name); err != nil || name != target {
+				continue
+			}
+
+			if timer == nil {
+				timer = time.NewTimer(cw.debounce)
+			} else {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(cw.debounce)
+			}
+			fire = timer.C
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			cw.reportError(fmt.Errorf("配置文件监听出错: %v", err))
+
+		case <-fire:
+			fire = nil
+
+			cfg, err := Load(cw.path)
+			if err != nil {
+				// 文件被删除或正在写入时保留当前配置，等待下一次变化
+				cw.reportError(fmt.Errorf("重新加载配置文件失败: %v", err))
+				continue
+			}
+
+			select {
+			case cw.changes <- cfg:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// reportError 发送错误，没有接收方时丢弃
+func (cw *ConfigWatcher) reportError(err error) {
+	select {
+	case cw.errors <- err:
+	default:
+	}
+}
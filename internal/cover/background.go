@@ -0,0 +1,43 @@
+package cover
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// scrimOpacity 背景图片上遮罩层的不透明度，保证文字与装饰在图片上清晰可见
+const scrimOpacity = 0.45
+
+// generateBackground 生成封面背景
+// 主题设置了 ImagePath 时以图片铺满画布并叠加半透明遮罩，否则使用渐变填充
+func (g *CoverGenerator) generateBackground(theme CoverTheme, width, height int) (string, error) {
+	if theme.ImagePath == "" {
+		return fmt.Sprintf(`<rect width="%d" height="%d" fill="url(#bgGradient)"/>`, width, height), nil
+	}
+
+	dataURI, err := imageDataURI(theme.ImagePath)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`<image href="%s" x="0" y="0" width="%d" height="%d" preserveAspectRatio="xMidYMid slice"/>
+    <rect width="%d" height="%d" fill="#000000" opacity="%.2f"/>`,
+		dataURI, width, height, width, height, scrimOpacity), nil
+}
+
+// imageDataURI 读取 JPEG 或 PNG 图片并编码为 data URI
+func imageDataURI(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("读取背景图片失败: %w", err)
+	}
+
+	mimeType := http.DetectContentType(data)
+	if mimeType != "image/jpeg" && mimeType != "image/png" {
+		return "", fmt.Errorf("背景图片 %s 不是 JPEG 或 PNG 格式: %s", path, mimeType)
+	}
+
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
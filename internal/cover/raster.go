@@ -0,0 +1,35 @@
+package cover
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/srwiley/oksvg"
+	"github.com/srwiley/rasterx"
+)
+
+// RasterizeSVG 将 SVG 栅格化为指定尺寸的 PNG
+// oksvg 不支持 <text> 元素，文字不会出现在 PNG 中，其余图形按 SVG 绘制
+func RasterizeSVG(svgData []byte, width, height int) ([]byte, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("无效的尺寸: %dx%d", width, height)
+	}
+
+	icon, err := oksvg.ReadIconStream(bytes.NewReader(svgData), oksvg.IgnoreErrorMode)
+	if err != nil {
+		return nil, fmt.Errorf("解析 SVG 失败: %w", err)
+	}
+	icon.SetTarget(0, 0, float64(width), float64(height))
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	scanner := rasterx.NewScannerGV(width, height, img, img.Bounds())
+	icon.Draw(rasterx.NewDasher(width, height, scanner), 1)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("编码 PNG 失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,74 @@
+package cover
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestRasterizeSVGRoundTrip(t *testing.T) {
+	generator := NewCoverGenerator()
+	theme, ok := generator.Theme(generator.ThemeNames()[0])
+	if !ok {
+		t.Fatal("没有可用的主题")
+	}
+
+	tests := []struct {
+		name          string
+		svgWidth      int
+		svgHeight     int
+		width, height int
+	}{
+		{name: "same size", svgWidth: 400, svgHeight: 600, width: 400, height: 600},
+		{name: "scaled up", svgWidth: 400, svgHeight: 600, width: 800, height: 1200},
+		{name: "scaled down", svgWidth: 400, svgHeight: 600, width: 200, height: 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svg, err := generator.GenerateSVG("测试小说", "副标题", "作者", theme, tt.svgWidth, tt.svgHeight)
+			if err != nil {
+				t.Fatalf("GenerateSVG() error = %v", err)
+			}
+
+			data, err := RasterizeSVG([]byte(svg), tt.width, tt.height)
+			if err != nil {
+				t.Fatalf("RasterizeSVG() error = %v", err)
+			}
+
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("解码 PNG 失败: %v", err)
+			}
+
+			bounds := img.Bounds()
+			if bounds.Dx() != tt.width || bounds.Dy() != tt.height {
+				t.Errorf("PNG 尺寸 = %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), tt.width, tt.height)
+			}
+
+			// 背景应被绘制，中心像素不应完全透明
+			if _, _, _, a := img.At(tt.width/2, tt.height/2).RGBA(); a == 0 {
+				t.Error("中心像素完全透明，背景未绘制")
+			}
+		})
+	}
+}
+
+func TestRasterizeSVGInvalidSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+	}{
+		{name: "zero width", width: 0, height: 600},
+		{name: "zero height", width: 400, height: 0},
+		{name: "negative", width: -1, height: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RasterizeSVG([]byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), tt.width, tt.height); err == nil {
+				t.Errorf("RasterizeSVG(%d, %d) error = nil, want error", tt.width, tt.height)
+			}
+		})
+	}
+}
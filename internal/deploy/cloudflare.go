@@ -7,6 +7,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"time"
@@ -24,13 +25,26 @@ type CloudflareConfig struct {
 	Framework    string `yaml:"framework"`
 	BuildCommand string `yaml:"build_command"`
 	OutputDir    string `yaml:"output_dir"`
+	// KVNamespaceID Workers KV 命名空间 ID，设置后部署完成时写入构建元数据
+	KVNamespaceID string `yaml:"kv_namespace_id,omitempty"`
+}
+
+// KVMetadataKey 构建元数据在 Workers KV 中的键名
+const KVMetadataKey = "creeper:metadata"
+
+// DeployMetadata 写入 Workers KV 的构建元数据
+type DeployMetadata struct {
+	LastDeployedAt time.Time `json:"lastDeployedAt"`
+	FileCount      int       `json:"fileCount"`
+	DeploymentURL  string    `json:"deploymentURL"`
 }
 
 // CloudflareDeployer Cloudflare 部署器
 type CloudflareDeployer struct {
-	config *CloudflareConfig
-	logger *common.Logger
-	client *http.Client
+	config   *CloudflareConfig
+	logger   *common.Logger
+	client   *http.Client
+	progress DeployProgressCallback
 }
 
 // NewCloudflareDeployer 创建 Cloudflare 部署器
@@ -44,6 +58,20 @@ func NewCloudflareDeployer(config *CloudflareConfig) *CloudflareDeployer {
 	}
 }
 
+// SetProgressCallback 设置进度回调，设置后进度不再写入日志
+func (cd *CloudflareDeployer) SetProgressCallback(callback DeployProgressCallback) {
+	cd.progress = callback
+}
+
+// reportProgress 报告部署进度，未设置回调时写入日志
+func (cd *CloudflareDeployer) reportProgress(event ProgressEvent) {
+	if cd.progress != nil {
+		cd.progress(event)
+		return
+	}
+	cd.logger.Info(event.Message)
+}
+
 // Deploy 部署到 Cloudflare Pages
 func (cd *CloudflareDeployer) Deploy(siteDir string) error {
 	cd.logger.Info("开始部署到 Cloudflare Pages")
@@ -67,7 +95,8 @@ func (cd *CloudflareDeployer) Deploy(siteDir string) error {
 	}
 
 	// 4. 上传文件
-	if err := cd.uploadFiles(deploymentID, siteDir); err != nil {
+	fileCount, err := cd.uploadFiles(deploymentID, siteDir)
+	if err != nil {
 		return fmt.Errorf("上传文件失败: %w", err)
 	}
 
@@ -76,7 +105,24 @@ func (cd *CloudflareDeployer) Deploy(siteDir string) error {
 		return fmt.Errorf("完成部署失败: %w", err)
 	}
 
-	cd.logger.Info("Cloudflare Pages 部署完成")
+	// 6. 写入构建元数据（部署已成功，失败时仅记录警告）
+	if cd.config.KVNamespaceID != "" {
+		metadata := &DeployMetadata{
+			LastDeployedAt: time.Now(),
+			FileCount:      fileCount,
+			DeploymentURL:  cd.GetDeploymentURL(),
+		}
+		if err := cd.writeKVMetadata(metadata); err != nil {
+			cd.logger.Warn("写入 Workers KV 构建元数据失败:", err)
+		}
+	}
+
+	cd.reportProgress(ProgressEvent{
+		Percentage:    100,
+		Message:       "Cloudflare Pages 部署完成",
+		FilesUploaded: fileCount,
+		TotalFiles:    fileCount,
+	})
 	return nil
 }
 
@@ -130,7 +176,7 @@ func (cd *CloudflareDeployer) validateSiteDir(siteDir string) error {
 
 // createDeployment 创建部署
 func (cd *CloudflareDeployer) createDeployment(siteDir string) (string, error) {
-	cd.logger.Info("创建 Cloudflare Pages 部署")
+	cd.reportProgress(ProgressEvent{Percentage: 5, Message: "创建 Cloudflare Pages 部署"})
 
 	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/pages/projects/%s/deployments",
 		cd.config.AccountID, cd.config.ProjectName)
@@ -186,17 +232,19 @@ func (cd *CloudflareDeployer) createDeployment(siteDir string) (string, error) {
 	return deploymentID, nil
 }
 
-// uploadFiles 上传文件
-func (cd *CloudflareDeployer) uploadFiles(deploymentID, siteDir string) error {
-	cd.logger.Info("开始上传文件")
-
+// uploadFiles 上传文件，返回上传的文件数
+func (cd *CloudflareDeployer) uploadFiles(deploymentID, siteDir string) (int, error) {
 	// 获取所有文件
 	files, err := cd.getAllFiles(siteDir)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	cd.logger.Info("需要上传", len(files), "个文件")
+	cd.reportProgress(ProgressEvent{
+		Percentage: 10,
+		Message:    fmt.Sprintf("开始上传文件，需要上传 %d 个文件", len(files)),
+		TotalFiles: len(files),
+	})
 
 	// 分批上传文件
 	batchSize := 100
@@ -207,14 +255,19 @@ func (cd *CloudflareDeployer) uploadFiles(deploymentID, siteDir string) error {
 		}
 
 		batch := files[i:end]
-		if err := cd.uploadBatch(deploymentID, batch); err != nil {
-			return fmt.Errorf("上传批次 %d 失败: %w", i/batchSize+1, err)
+		if err := cd.uploadBatch(deploymentID, siteDir, batch); err != nil {
+			return end - len(batch), fmt.Errorf("上传批次 %d 失败: %w", i/batchSize+1, err)
 		}
 
-		cd.logger.Info(fmt.Sprintf("已上传 %d/%d 个文件", end, len(files)))
+		cd.reportProgress(ProgressEvent{
+			Percentage:    uploadPercentage(10, 90, end, len(files)),
+			Message:       fmt.Sprintf("已上传 %d/%d 个文件", end, len(files)),
+			FilesUploaded: end,
+			TotalFiles:    len(files),
+		})
 	}
 
-	return nil
+	return len(files), nil
 }
 
 // getAllFiles 获取所有文件
@@ -232,6 +285,9 @@ func (cd *CloudflareDeployer) getAllFiles(dir string) ([]string, error) {
 			if err != nil {
 				return err
 			}
+			if isLocalBuildFile(relPath) {
+				return nil
+			}
 			files = append(files, relPath)
 		}
 
@@ -241,8 +297,8 @@ func (cd *CloudflareDeployer) getAllFiles(dir string) ([]string, error) {
 	return files, err
 }
 
-// uploadBatch 上传一批文件
-func (cd *CloudflareDeployer) uploadBatch(deploymentID string, files []string) error {
+// uploadBatch 上传一批文件，files 为相对 siteDir 的路径（见 getAllFiles）
+func (cd *CloudflareDeployer) uploadBatch(deploymentID, siteDir string, files []string) error {
 	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/pages/projects/%s/deployments/%s/files",
 		cd.config.AccountID, cd.config.ProjectName, deploymentID)
 
@@ -251,7 +307,7 @@ func (cd *CloudflareDeployer) uploadBatch(deploymentID string, files []string) e
 
 	// 添加文件
 	for _, file := range files {
-		filePath := filepath.Join(cd.config.OutputDir, file)
+		filePath := filepath.Join(siteDir, file)
 
 		// 读取文件内容
 		content, err := os.ReadFile(filePath)
@@ -299,7 +355,7 @@ func (cd *CloudflareDeployer) uploadBatch(deploymentID string, files []string) e
 
 // finalizeDeployment 完成部署
 func (cd *CloudflareDeployer) finalizeDeployment(deploymentID string) error {
-	cd.logger.Info("完成部署")
+	cd.reportProgress(ProgressEvent{Percentage: 95, Message: "完成部署"})
 
 	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/pages/projects/%s/deployments/%s",
 		cd.config.AccountID, cd.config.ProjectName, deploymentID)
@@ -340,6 +396,42 @@ func (cd *CloudflareDeployer) finalizeDeployment(deploymentID string) error {
 	return nil
 }
 
+// writeKVMetadata 将构建元数据写入 Workers KV
+// API 令牌需要 Account > Workers KV Storage > Edit 权限
+func (cd *CloudflareDeployer) writeKVMetadata(metadata *DeployMetadata) error {
+	cd.logger.Info("写入 Workers KV 构建元数据:", KVMetadataKey)
+
+	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/storage/kv/namespaces/%s/values/%s",
+		cd.config.AccountID, cd.config.KVNamespaceID, neturl.PathEscape(KVMetadataKey))
+
+	jsonData, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+cd.config.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Auth-Email", cd.config.Email)
+
+	resp, err := cd.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("写入 KV 失败: %s, 响应: %s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
 // GetDeploymentStatus 获取部署状态
 func (cd *CloudflareDeployer) GetDeploymentStatus(deploymentID string) (map[string]interface{}, error) {
 	url := fmt.Sprintf("https://api.cloudflare.com/client/v4/accounts/%s/pages/projects/%s/deployments/%s",
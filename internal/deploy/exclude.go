@@ -0,0 +1,12 @@
+package deploy
+
+import "path/filepath"
+
+// buildManifestFile 增量构建清单文件名，与 generator 包中的定义保持一致
+// 清单只供本地增量构建使用，不应随站点发布
+const buildManifestFile = ".creeper-build-manifest.json"
+
+// isLocalBuildFile 判断站点目录中相对路径为 relPath 的文件是否只供本地构建使用
+func isLocalBuildFile(relPath string) bool {
+	return filepath.ToSlash(relPath) == buildManifestFile
+}
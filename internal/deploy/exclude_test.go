@@ -0,0 +1,88 @@
+package deploy
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestDeployersSkipBuildManifest(t *testing.T) {
+	siteDir := t.TempDir()
+	writeSite(t, siteDir, map[string]string{
+		"index.html":                  "<h1>首页</h1>",
+		"novels/a.html":               "<h1>小说 A</h1>",
+		buildManifestFile:             "{}",
+		"novels/" + buildManifestFile: "{}",
+	})
+	want := []string{"index.html", "novels/" + buildManifestFile, "novels/a.html"}
+
+	tests := []struct {
+		name  string
+		files func(t *testing.T) []string
+	}{
+		{
+			name: "cloudflare",
+			files: func(t *testing.T) []string {
+				files, err := (&CloudflareDeployer{}).getAllFiles(siteDir)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return files
+			},
+		},
+		{
+			name: "netlify",
+			files: func(t *testing.T) []string {
+				archive, err := zipSiteDir(siteDir)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer os.Remove(archive)
+
+				reader, err := zip.OpenReader(archive)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer reader.Close()
+
+				var files []string
+				for _, f := range reader.File {
+					files = append(files, f.Name)
+				}
+				return files
+			},
+		},
+		{
+			name: "iterator",
+			files: func(t *testing.T) []string {
+				iterator := NewDirectoryIterator(siteDir)
+				if err := iterator.LoadFiles(); err != nil {
+					t.Fatal(err)
+				}
+				var files []string
+				for iterator.HasNext() {
+					if info := iterator.Next(); !info.IsDir {
+						files = append(files, info.Relative)
+					}
+				}
+				return files
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.files(t)
+			for i := range got {
+				got[i] = filepath.ToSlash(got[i])
+			}
+			sort.Strings(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("files = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package deploy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"creeper/internal/common"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// githubAPIBase GitHub REST API 地址
+const githubAPIBase = "https://api.github.com"
+
+// defaultGitHubPagesBranch 未配置分支时推送的分支
+const defaultGitHubPagesBranch = "gh-pages"
+
+// GitHubPagesDeployer GitHub Pages 部署器
+// 将站点目录提交到仓库的 gh-pages 分支（分支不存在时自动创建）并推送
+type GitHubPagesDeployer struct {
+	config *GitHubPagesConfig
+	logger *common.Logger
+	client *http.Client
+}
+
+// NewGitHubPagesDeployer 创建 GitHub Pages 部署器
+func NewGitHubPagesDeployer(config *GitHubPagesConfig) *GitHubPagesDeployer {
+	return &GitHubPagesDeployer{
+		config: config,
+		logger: common.GetLogger(),
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Deploy 部署到 GitHub Pages
+func (gpd *GitHubPagesDeployer) Deploy(siteDir string) error {
+	gpd.logger.Info("开始部署到 GitHub Pages")
+	gpd.logger.Info("仓库:", gpd.config.Repository)
+	gpd.logger.Info("站点目录:", siteDir)
+
+	// 1. 验证配置
+	if err := gpd.validateConfig(); err != nil {
+		return fmt.Errorf("配置验证失败: %w", err)
+	}
+
+	// 2. 检查站点目录
+	if info, err := os.Stat(siteDir); err != nil {
+		return fmt.Errorf("站点目录验证失败: %w", err)
+	} else if !info.IsDir() {
+		return fmt.Errorf("站点目录验证失败: %s 不是目录", siteDir)
+	}
+
+	workDir, err := os.MkdirTemp("", "creeper-gh-pages-")
+	if err != nil {
+		return fmt.Errorf("创建临时目录失败: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	// 3. 检出发布分支
+	repo, err := gpd.checkoutBranch(workDir)
+	if err != nil {
+		return fmt.Errorf("检出 %s 分支失败: %w", gpd.branch(), err)
+	}
+
+	// 4. 用站点内容替换工作区
+	if err := clearWorktree(workDir); err != nil {
+		return fmt.Errorf("清理工作区失败: %w", err)
+	}
+	if err := copyDir(siteDir, workDir); err != nil {
+		return fmt.Errorf("复制站点文件失败: %w", err)
+	}
+	// 禁用 Jekyll，保留以下划线开头的文件与目录
+	if err := os.WriteFile(filepath.Join(workDir, ".nojekyll"), nil, 0644); err != nil {
+		return fmt.Errorf("写入 .nojekyll 失败: %w", err)
+	}
+
+	// 5. 提交
+	committed, err := gpd.commit(repo)
+	if err != nil {
+		return fmt.Errorf("提交失败: %w", err)
+	}
+	if !committed {
+		gpd.logger.Info("站点内容没有变化，跳过推送")
+		return nil
+	}
+
+	// 6. 推送
+	branchRef := plumbing.NewBranchReferenceName(gpd.branch())
+	err = repo.Push(&git.PushOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec(branchRef + ":" + branchRef)},
+		Auth:       gpd.auth(),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("推送失败: %w", err)
+	}
+
+	gpd.logger.Info("GitHub Pages 部署完成:", gpd.GetDeploymentURL())
+	return nil
+}
+
+// validateConfig 验证配置
+func (gpd *GitHubPagesDeployer) validateConfig() error {
+	if gpd.config.Repository == "" {
+		return fmt.Errorf("仓库不能为空")
+	}
+	if gpd.config.Token == "" && isGitHubRemote(gpd.remoteURL()) {
+		return fmt.Errorf("Token 不能为空")
+	}
+	return nil
+}
+
+// checkoutBranch 克隆发布分支到 workDir，远程仓库为空或没有该分支时初始化一个新分支
+func (gpd *GitHubPagesDeployer) checkoutBranch(workDir string) (*git.Repository, error) {
+	branchRef := plumbing.NewBranchReferenceName(gpd.branch())
+
+	repo, err := git.PlainClone(workDir, false, &git.CloneOptions{
+		URL:           gpd.remoteURL(),
+		Auth:          gpd.auth(),
+		ReferenceName: branchRef,
+		SingleBranch:  true,
+	})
+	if err == nil {
+		return repo, nil
+	}
+	if !errors.Is(err, git.NoMatchingRefSpecError{}) && !errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return nil, err
+	}
+
+	gpd.logger.Info("远程仓库没有", gpd.branch(), "分支，将创建新分支")
+
+	// 克隆失败可能留下不完整的 .git 目录
+	if err := os.RemoveAll(filepath.Join(workDir, git.GitDirName)); err != nil {
+		return nil, err
+	}
+
+	repo, err = git.PlainInit(workDir, false)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.CreateRemote(&gitconfig.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{gpd.remoteURL()},
+	}); err != nil {
+		return nil, err
+	}
+	// HEAD 指向尚无提交的发布分支，首次提交即创建该分支
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branchRef)); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// commit 暂存所有变化并提交，没有变化时返回 false
+func (gpd *GitHubPagesDeployer) commit(repo *git.Repository) (bool, error) {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+
+	if err := worktree.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return false, err
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return false, err
+	}
+	if status.IsClean() {
+		return false, nil
+	}
+
+	now := time.Now()
+	_, err = worktree.Commit("Deploy "+now.Format(time.RFC3339), &git.CommitOptions{
+		Author: gpd.signature(now),
+	})
+	return err == nil, err
+}
+
+// signature 提交者信息
+func (gpd *GitHubPagesDeployer) signature(when time.Time) *object.Signature {
+	name := gpd.config.Username
+	if name == "" {
+		name = "creeper"
+	}
+	return &object.Signature{
+		Name:  name,
+		Email: name + "@users.noreply.github.com",
+		When:  when,
+	}
+}
+
+// auth 使用 Token 作为 HTTP Basic 认证的密码
+func (gpd *GitHubPagesDeployer) auth() transport.AuthMethod {
+	if gpd.config.Token == "" {
+		return nil
+	}
+	username := gpd.config.Username
+	if username == "" {
+		username = "x-access-token"
+	}
+	return &githttp.BasicAuth{
+		Username: username,
+		Password: gpd.config.Token,
+	}
+}
+
+// branch 发布分支
+func (gpd *GitHubPagesDeployer) branch() string {
+	if gpd.config.Branch != "" {
+		return gpd.config.Branch
+	}
+	return defaultGitHubPagesBranch
+}
+
+// ownerAndRepo 解析仓库的所有者与名称
+// repository 可写作 "repo"（所有者取 username）、"owner/repo" 或完整的 GitHub 地址
+func (gpd *GitHubPagesDeployer) ownerAndRepo() (string, string) {
+	repository := strings.TrimSuffix(gpd.config.Repository, ".git")
+	if i := strings.Index(repository, "github.com"); i >= 0 {
+		repository = strings.TrimLeft(repository[i+len("github.com"):], "/:")
+	}
+
+	if owner, repo, ok := strings.Cut(repository, "/"); ok {
+		return owner, repo
+	}
+	return gpd.config.Username, repository
+}
+
+// remoteURL 推送地址，repository 为其他 Git 地址（如本地裸仓库）时直接使用
+func (gpd *GitHubPagesDeployer) remoteURL() string {
+	repository := gpd.config.Repository
+	if strings.Contains(repository, "://") || filepath.IsAbs(repository) {
+		return repository
+	}
+
+	owner, repo := gpd.ownerAndRepo()
+	return fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
+}
+
+// GetStatus 通过 GET /repos/{owner}/{repo}/pages 获取 GitHub Pages 状态
+func (gpd *GitHubPagesDeployer) GetStatus() (map[string]interface{}, error) {
+	owner, repo := gpd.ownerAndRepo()
+	if owner == "" || repo == "" {
+		return nil, fmt.Errorf("无法从 %q 解析仓库所有者与名称", gpd.config.Repository)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pages", githubAPIBase, owner, repo)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if gpd.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+gpd.config.Token)
+	}
+
+	resp, err := gpd.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return map[string]interface{}{
+			"type":    "github_pages",
+			"status":  "not_enabled",
+			"message": "仓库未启用 GitHub Pages",
+		}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("获取 GitHub Pages 状态失败: %s", string(body))
+	}
+
+	var pages map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&pages); err != nil {
+		return nil, err
+	}
+
+	status := map[string]interface{}{
+		"type":   "github_pages",
+		"status": pages["status"],
+		"url":    pages["html_url"],
+		"branch": gpd.branch(),
+	}
+	for _, key := range []string{"cname", "https_enforced", "build_type", "source"} {
+		if value, ok := pages[key]; ok && value != nil {
+			status[key] = value
+		}
+	}
+
+	return status, nil
+}
+
+// GetDeploymentURL 获取部署URL
+func (gpd *GitHubPagesDeployer) GetDeploymentURL() string {
+	owner, repo := gpd.ownerAndRepo()
+	// <owner>.github.io 仓库发布在根路径
+	if strings.EqualFold(repo, owner+".github.io") {
+		return fmt.Sprintf("https://%s", repo)
+	}
+	return fmt.Sprintf("https://%s.github.io/%s", owner, repo)
+}
+
+// isGitHubRemote 推送地址是否为 GitHub
+func isGitHubRemote(url string) bool {
+	return strings.Contains(url, "github.com")
+}
+
+// clearWorktree 删除工作区中除 .git 以外的内容
+func clearWorktree(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Name() == git.GitDirName {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyDir 将 src 目录的内容复制到 dst
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if isLocalBuildFile(relPath) {
+			return nil
+		}
+		target := filepath.Join(dst, relPath)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		source, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer source.Close()
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, source); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
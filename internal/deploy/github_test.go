@@ -0,0 +1,162 @@
+package deploy
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// initBareRepo 在临时目录中用 git init --bare 创建远程仓库
+func initBareRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("需要 git 命令")
+	}
+
+	dir := filepath.Join(t.TempDir(), "site.git")
+	if out, err := exec.Command("git", "init", "--bare", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare 失败: %v\n%s", err, out)
+	}
+	return dir
+}
+
+// writeSite 写入站点文件，files 为相对路径到内容的映射
+func writeSite(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// pushedTree 读取远程仓库中分支最新提交的文件内容
+func pushedTree(t *testing.T, remote, branch string) map[string]string {
+	t.Helper()
+	repo, err := git.PlainOpen(remote)
+	if err != nil {
+		t.Fatalf("打开远程仓库失败: %v", err)
+	}
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		t.Fatalf("远程仓库没有 %s 分支: %v", branch, err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		content, err := f.Contents()
+		files[f.Name] = content
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestGitHubPagesDeployToBareRepository(t *testing.T) {
+	remote := initBareRepo(t)
+	deployer := NewGitHubPagesDeployer(&GitHubPagesConfig{Repository: remote})
+
+	siteDir := t.TempDir()
+	writeSite(t, siteDir, map[string]string{
+		"index.html":           "<h1>首页</h1>",
+		"novels/a/index.html":  "<h1>小说 A</h1>",
+		"static/css/style.css": "body{}",
+		buildManifestFile:      "{}",
+	})
+
+	// 增量构建清单只供本地使用，不应被推送
+	if err := deployer.Deploy(siteDir); err != nil {
+		t.Fatalf("首次部署失败: %v", err)
+	}
+
+	files := pushedTree(t, remote, defaultGitHubPagesBranch)
+	want := []string{".nojekyll", "index.html", "novels/a/index.html", "static/css/style.css"}
+	if got := sortedKeys(files); !slices.Equal(got, want) {
+		t.Fatalf("推送的文件 = %q, want %q", got, want)
+	}
+	if files["index.html"] != "<h1>首页</h1>" {
+		t.Errorf("index.html = %q", files["index.html"])
+	}
+
+	// 再次部署：修改与删除的文件都应反映到远程分支
+	if err := os.RemoveAll(filepath.Join(siteDir, "novels")); err != nil {
+		t.Fatal(err)
+	}
+	writeSite(t, siteDir, map[string]string{"index.html": "<h1>新首页</h1>"})
+
+	if err := deployer.Deploy(siteDir); err != nil {
+		t.Fatalf("再次部署失败: %v", err)
+	}
+
+	files = pushedTree(t, remote, defaultGitHubPagesBranch)
+	want = []string{".nojekyll", "index.html", "static/css/style.css"}
+	if got := sortedKeys(files); !slices.Equal(got, want) {
+		t.Fatalf("再次推送的文件 = %q, want %q", got, want)
+	}
+	if files["index.html"] != "<h1>新首页</h1>" {
+		t.Errorf("index.html = %q", files["index.html"])
+	}
+}
+
+func TestGitHubPagesDeployUnchangedSiteSkipsCommit(t *testing.T) {
+	remote := initBareRepo(t)
+	deployer := NewGitHubPagesDeployer(&GitHubPagesConfig{Repository: remote, Branch: "pages"})
+
+	siteDir := t.TempDir()
+	writeSite(t, siteDir, map[string]string{"index.html": "<h1>首页</h1>"})
+
+	if err := deployer.Deploy(siteDir); err != nil {
+		t.Fatalf("首次部署失败: %v", err)
+	}
+	first := branchHead(t, remote, "pages")
+
+	if err := deployer.Deploy(siteDir); err != nil {
+		t.Fatalf("再次部署失败: %v", err)
+	}
+	if second := branchHead(t, remote, "pages"); second != first {
+		t.Errorf("内容未变化时不应产生新提交: %s -> %s", first, second)
+	}
+}
+
+func branchHead(t *testing.T, remote, branch string) plumbing.Hash {
+	t.Helper()
+	repo, err := git.PlainOpen(remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		t.Fatalf("远程仓库没有 %s 分支: %v", branch, err)
+	}
+	return ref.Hash()
+}
@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// runHooks 依次执行部署钩子命令，任一命令以非零状态退出即返回错误
+func (dm *DeployManager) runHooks(stage string, hooks []string, siteDir string) error {
+	for i, hook := range hooks {
+		hook = strings.TrimSpace(hook)
+		if hook == "" {
+			continue
+		}
+
+		dm.logger.Info(fmt.Sprintf("执行%s钩子 %d/%d: %s", stage, i+1, len(hooks), hook))
+
+		cmd := hookCommand(hook)
+		cmd.Env = append(os.Environ(),
+			"CREEPER_SITE_DIR="+siteDir,
+			"CREEPER_DEPLOY_TYPE="+string(dm.config.Type),
+		)
+
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		err := cmd.Run()
+
+		if output := strings.TrimSpace(stdout.String()); output != "" {
+			dm.logger.Info(fmt.Sprintf("[%s] stdout:\n%s", hook, output))
+		}
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			dm.logger.Warn(fmt.Sprintf("[%s] stderr:\n%s", hook, output))
+		}
+
+		if err != nil {
+			return fmt.Errorf("%s钩子执行失败 (%s): %w", stage, hook, err)
+		}
+	}
+
+	return nil
+}
+
+// hookCommand 创建通过系统 shell 执行的钩子命令
+func hookCommand(hook string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", hook)
+	}
+	return exec.Command("sh", "-c", hook)
+}
@@ -61,8 +61,8 @@ func (di *DirectoryIterator) LoadFiles() error {
 			return err
 		}
 		
-		// 跳过根目录
-		if relPath == "." {
+		// 跳过根目录与本地构建文件
+		if relPath == "." || isLocalBuildFile(relPath) {
 			return nil
 		}
 		
@@ -29,6 +29,10 @@ type DeployConfig struct {
 	Vercel     *VercelConfig          `yaml:"vercel,omitempty"`
 	Netlify    *NetlifyConfig         `yaml:"netlify,omitempty"`
 	Options    map[string]interface{} `yaml:"options,omitempty"`
+
+	// 部署前后执行的 shell 命令
+	PreDeployHooks  []string `yaml:"pre_deploy_hooks,omitempty"`
+	PostDeployHooks []string `yaml:"post_deploy_hooks,omitempty"`
 }
 
 // GitHubPagesConfig GitHub Pages 配置
@@ -46,6 +50,8 @@ type VercelConfig struct {
 	TeamID       string `yaml:"team_id,omitempty"`
 	Framework    string `yaml:"framework"`
 	BuildCommand string `yaml:"build_command"`
+	// DeployHookURL 项目的 Deploy Hook 地址，部署时调用以触发构建
+	DeployHookURL string `yaml:"deploy_hook_url"`
 }
 
 // NetlifyConfig Netlify 配置
@@ -145,8 +151,8 @@ func (dm *DeployManager) Initialize() error {
 	return nil
 }
 
-// Deploy 执行部署
-func (dm *DeployManager) Deploy(siteDir string) error {
+// Deploy 执行部署，可传入进度回调接收上传进度（部署器需实现 ProgressReporter）
+func (dm *DeployManager) Deploy(siteDir string, progress ...DeployProgressCallback) error {
 	if dm.deployer == nil {
 		return fmt.Errorf("部署器未初始化")
 	}
@@ -157,6 +163,44 @@ func (dm *DeployManager) Deploy(siteDir string) error {
 	// 创建部署备忘录
 	memento := dm.originator.CreateMemento(string(dm.config.Type), siteDir)
 
+	var callback DeployProgressCallback
+	if len(progress) > 0 {
+		callback = progress[0]
+	}
+
+	return dm.deploy(siteDir, memento, callback)
+}
+
+// Rollback 重新部署 steps 次之前的成功部署所保存的站点快照，回滚本身也记入部署历史
+func (dm *DeployManager) Rollback(steps int) error {
+	if dm.deployer == nil {
+		return fmt.Errorf("部署器未初始化")
+	}
+
+	target, err := dm.caretaker.GetRollbackMemento(steps)
+	if err != nil {
+		return err
+	}
+
+	if err := dm.caretaker.VerifySnapshot(target); err != nil {
+		return fmt.Errorf("快照校验失败: %w", err)
+	}
+
+	dm.logger.Info("回滚到部署:", target.ID, "部署时间:", target.EndTime.Format("2006-01-02 15:04:05"))
+
+	memento := dm.originator.CreateMemento(string(dm.config.Type), target.SiteDir)
+	memento.Metadata["rollback_of"] = target.ID
+
+	return dm.deploy(target.RollbackPoint, memento, nil)
+}
+
+// deploy 部署站点目录并将结果记录到 memento
+func (dm *DeployManager) deploy(siteDir string, memento *DeploymentMemento, progress DeployProgressCallback) error {
+	// 部署器在多次部署间复用，每次部署都重新设置回调
+	if reporter, ok := dm.deployer.(ProgressReporter); ok {
+		reporter.SetProgressCallback(progress)
+	}
+
 	// 发送部署开始事件
 	dm.eventManager.Notify(NewDeploymentEventBuilder(EventDeploymentStarted).
 		WithData("site_dir", siteDir).
@@ -176,6 +220,16 @@ func (dm *DeployManager) Deploy(siteDir string) error {
 		return fmt.Errorf("站点目录验证失败: %w", err)
 	}
 
+	// 执行部署前钩子
+	if err := dm.runHooks("部署前", dm.config.PreDeployHooks, siteDir); err != nil {
+		dm.originator.SetError(memento, err)
+		dm.eventManager.Notify(NewDeploymentEventBuilder(EventDeploymentFailed).
+			WithData("site_dir", siteDir).
+			WithError(err).
+			Build())
+		return fmt.Errorf("部署失败: %w", err)
+	}
+
 	// 执行部署
 	if err := dm.deployer.Deploy(siteDir); err != nil {
 		dm.originator.SetError(memento, err)
@@ -189,6 +243,16 @@ func (dm *DeployManager) Deploy(siteDir string) error {
 		return fmt.Errorf("部署失败: %w", err)
 	}
 
+	// 执行部署后钩子
+	if err := dm.runHooks("部署后", dm.config.PostDeployHooks, siteDir); err != nil {
+		dm.originator.SetError(memento, err)
+		dm.eventManager.Notify(NewDeploymentEventBuilder(EventDeploymentFailed).
+			WithData("site_dir", siteDir).
+			WithError(err).
+			Build())
+		return fmt.Errorf("部署失败: %w", err)
+	}
+
 	// 获取部署 URL
 	deploymentURL := dm.deployer.GetDeploymentURL()
 
@@ -196,6 +260,11 @@ func (dm *DeployManager) Deploy(siteDir string) error {
 	fileCollection := NewFileCollection(dm.fileIterator)
 	stats := fileCollection.GetStats()
 
+	// 保存站点快照，供之后回滚
+	if err := dm.caretaker.Snapshot(memento, siteDir); err != nil {
+		dm.logger.Warn("保存站点快照失败，本次部署无法回滚:", err)
+	}
+
 	// 设置成功状态
 	dm.originator.SetSuccess(memento, deploymentURL, stats["files"].(int), stats["total_size"].(int64))
 
@@ -391,93 +460,3 @@ func CreateDefaultDeployConfig(deployType DeployType) *DeployConfig {
 
 	return config
 }
-
-// GitHubPagesDeployer GitHub Pages 部署器
-type GitHubPagesDeployer struct {
-	config *GitHubPagesConfig
-	logger *common.Logger
-}
-
-// NewGitHubPagesDeployer 创建 GitHub Pages 部署器
-func NewGitHubPagesDeployer(config *GitHubPagesConfig) *GitHubPagesDeployer {
-	return &GitHubPagesDeployer{
-		config: config,
-		logger: common.GetLogger(),
-	}
-}
-
-func (gpd *GitHubPagesDeployer) Deploy(siteDir string) error {
-	gpd.logger.Info("GitHub Pages 部署功能待实现")
-	return fmt.Errorf("GitHub Pages 部署功能暂未实现")
-}
-
-func (gpd *GitHubPagesDeployer) GetStatus() (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"type":   "github_pages",
-		"status": "not_implemented",
-	}, nil
-}
-
-func (gpd *GitHubPagesDeployer) GetDeploymentURL() string {
-	return fmt.Sprintf("https://%s.github.io/%s", gpd.config.Username, gpd.config.Repository)
-}
-
-// VercelDeployer Vercel 部署器
-type VercelDeployer struct {
-	config *VercelConfig
-	logger *common.Logger
-}
-
-// NewVercelDeployer 创建 Vercel 部署器
-func NewVercelDeployer(config *VercelConfig) *VercelDeployer {
-	return &VercelDeployer{
-		config: config,
-		logger: common.GetLogger(),
-	}
-}
-
-func (vd *VercelDeployer) Deploy(siteDir string) error {
-	vd.logger.Info("Vercel 部署功能待实现")
-	return fmt.Errorf("Vercel 部署功能暂未实现")
-}
-
-func (vd *VercelDeployer) GetStatus() (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"type":   "vercel",
-		"status": "not_implemented",
-	}, nil
-}
-
-func (vd *VercelDeployer) GetDeploymentURL() string {
-	return "https://vercel.com"
-}
-
-// NetlifyDeployer Netlify 部署器
-type NetlifyDeployer struct {
-	config *NetlifyConfig
-	logger *common.Logger
-}
-
-// NewNetlifyDeployer 创建 Netlify 部署器
-func NewNetlifyDeployer(config *NetlifyConfig) *NetlifyDeployer {
-	return &NetlifyDeployer{
-		config: config,
-		logger: common.GetLogger(),
-	}
-}
-
-func (nd *NetlifyDeployer) Deploy(siteDir string) error {
-	nd.logger.Info("Netlify 部署功能待实现")
-	return fmt.Errorf("Netlify 部署功能暂未实现")
-}
-
-func (nd *NetlifyDeployer) GetStatus() (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"type":   "netlify",
-		"status": "not_implemented",
-	}, nil
-}
-
-func (nd *NetlifyDeployer) GetDeploymentURL() string {
-	return "https://netlify.com"
-}
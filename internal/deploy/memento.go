@@ -25,6 +25,7 @@ type DeploymentMemento struct {
 	Metadata      map[string]interface{} `json:"metadata"`
 	Error         string                 `json:"error,omitempty"`
 	RollbackPoint string                 `json:"rollback_point,omitempty"`
+	IndexHash     string                 `json:"index_hash,omitempty"`
 }
 
 // DeploymentCaretaker 部署状态管理者
@@ -51,7 +52,9 @@ func (dc *DeploymentCaretaker) SaveMemento(memento *DeploymentMemento) error {
 	
 	// 限制历史记录数量
 	if len(dc.mementos) > dc.maxHistory {
+		dropped := dc.mementos[:len(dc.mementos)-dc.maxHistory]
 		dc.mementos = dc.mementos[len(dc.mementos)-dc.maxHistory:]
+		dc.pruneSnapshots(dropped)
 	}
 	
 	// 保存到文件
@@ -0,0 +1,311 @@
+package deploy
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"creeper/internal/common"
+)
+
+// netlifyAPIBase Netlify API 地址
+const netlifyAPIBase = "https://api.netlify.com/api/v1"
+
+// Netlify 部署轮询参数：上传完成后 Netlify 需要处理文件，状态变为 ready 或 error 时结束
+const (
+	netlifyPollInterval = 3 * time.Second
+	netlifyPollTimeout  = 10 * time.Minute
+)
+
+// netlifyDeploy Netlify 部署记录中用到的字段
+type netlifyDeploy struct {
+	ID           string `json:"id"`
+	State        string `json:"state"`
+	URL          string `json:"url"`
+	SSLURL       string `json:"ssl_url"`
+	DeployURL    string `json:"deploy_ssl_url"`
+	ErrorMessage string `json:"error_message"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// siteURL 站点访问地址，优先使用 HTTPS
+func (d *netlifyDeploy) siteURL() string {
+	if d.SSLURL != "" {
+		return d.SSLURL
+	}
+	return d.URL
+}
+
+// NetlifyDeployer Netlify 部署器
+// 将站点目录打包为 zip 上传到 Netlify Deploy API
+type NetlifyDeployer struct {
+	config *NetlifyConfig
+	logger *common.Logger
+	client *http.Client
+	url    string
+}
+
+// NewNetlifyDeployer 创建 Netlify 部署器
+func NewNetlifyDeployer(config *NetlifyConfig) *NetlifyDeployer {
+	return &NetlifyDeployer{
+		config: config,
+		logger: common.GetLogger(),
+		client: &http.Client{
+			// 上传整站压缩包耗时较长
+			Timeout: 5 * time.Minute,
+		},
+	}
+}
+
+// Deploy 部署到 Netlify
+func (nd *NetlifyDeployer) Deploy(siteDir string) error {
+	nd.logger.Info("开始部署到 Netlify")
+	nd.logger.Info("站点 ID:", nd.config.SiteID)
+	nd.logger.Info("站点目录:", siteDir)
+
+	// 1. 验证配置
+	if err := nd.validateConfig(); err != nil {
+		return fmt.Errorf("配置验证失败: %w", err)
+	}
+
+	// 2. 打包站点目录
+	archive, err := zipSiteDir(siteDir)
+	if err != nil {
+		return fmt.Errorf("打包站点目录失败: %w", err)
+	}
+	defer os.Remove(archive)
+
+	// 3. 上传
+	deploy, err := nd.uploadArchive(archive)
+	if err != nil {
+		return fmt.Errorf("上传站点失败: %w", err)
+	}
+	nd.logger.Info("部署已创建:", deploy.ID)
+
+	// 4. 等待 Netlify 处理完成
+	deploy, err = nd.waitForDeploy(deploy)
+	if err != nil {
+		return fmt.Errorf("等待部署完成失败: %w", err)
+	}
+
+	nd.url = deploy.siteURL()
+	nd.logger.Info("Netlify 部署完成:", nd.GetDeploymentURL())
+	return nil
+}
+
+// validateConfig 验证配置
+func (nd *NetlifyDeployer) validateConfig() error {
+	if nd.config.SiteID == "" {
+		return fmt.Errorf("站点 ID 不能为空")
+	}
+	if nd.config.Token == "" {
+		return fmt.Errorf("Token 不能为空")
+	}
+	return nil
+}
+
+// uploadArchive 通过 POST /sites/{site_id}/deploys 上传 zip 包
+func (nd *NetlifyDeployer) uploadArchive(archive string) (*netlifyDeploy, error) {
+	file, err := os.Open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/sites/%s/deploys", netlifyAPIBase, nd.config.SiteID)
+
+	req, err := http.NewRequest("POST", url, file)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = info.Size()
+
+	req.Header.Set("Authorization", "Bearer "+nd.config.Token)
+	req.Header.Set("Content-Type", "application/zip")
+
+	resp, err := nd.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("上传失败: %s, 响应: %s", resp.Status, string(body))
+	}
+
+	var deploy netlifyDeploy
+	if err := json.NewDecoder(resp.Body).Decode(&deploy); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	return &deploy, nil
+}
+
+// waitForDeploy 轮询部署状态直到 ready 或 error
+func (nd *NetlifyDeployer) waitForDeploy(deploy *netlifyDeploy) (*netlifyDeploy, error) {
+	deadline := time.Now().Add(netlifyPollTimeout)
+
+	for {
+		switch deploy.State {
+		case "ready":
+			return deploy, nil
+		case "error":
+			return nil, fmt.Errorf("部署失败: %s", deploy.ErrorMessage)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("部署 %s 在 %v 内未完成，当前状态: %s", deploy.ID, netlifyPollTimeout, deploy.State)
+		}
+
+		nd.logger.Debug("部署状态:", deploy.State)
+		time.Sleep(netlifyPollInterval)
+
+		next, err := nd.getDeploy(deploy.ID)
+		if err != nil {
+			return nil, err
+		}
+		deploy = next
+	}
+}
+
+// getDeploy 获取单个部署
+func (nd *NetlifyDeployer) getDeploy(deployID string) (*netlifyDeploy, error) {
+	url := fmt.Sprintf("%s/deploys/%s", netlifyAPIBase, deployID)
+
+	var deploy netlifyDeploy
+	if err := nd.getJSON(url, &deploy); err != nil {
+		return nil, err
+	}
+	return &deploy, nil
+}
+
+// getJSON 发送 GET 请求并解析 JSON 响应
+func (nd *NetlifyDeployer) getJSON(url string, result interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+nd.config.Token)
+
+	resp, err := nd.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("请求失败: %s, 响应: %s", resp.Status, string(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// GetStatus 获取最近一次部署的状态
+func (nd *NetlifyDeployer) GetStatus() (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/sites/%s/deploys?per_page=1", netlifyAPIBase, nd.config.SiteID)
+
+	var deploys []netlifyDeploy
+	if err := nd.getJSON(url, &deploys); err != nil {
+		return nil, fmt.Errorf("获取部署列表失败: %w", err)
+	}
+
+	if len(deploys) == 0 {
+		return map[string]interface{}{
+			"type":    "netlify",
+			"status":  "no_deployments",
+			"message": "没有找到部署记录",
+		}, nil
+	}
+
+	latest := deploys[0]
+	return map[string]interface{}{
+		"type":          "netlify",
+		"id":            latest.ID,
+		"state":         latest.State,
+		"url":           latest.siteURL(),
+		"deploy_url":    latest.DeployURL,
+		"error_message": latest.ErrorMessage,
+		"created_at":    latest.CreatedAt,
+	}, nil
+}
+
+// GetDeploymentURL 获取部署URL，部署完成前返回 Netlify 控制台地址
+func (nd *NetlifyDeployer) GetDeploymentURL() string {
+	if nd.url != "" {
+		return nd.url
+	}
+	return "https://app.netlify.com"
+}
+
+// zipSiteDir 将站点目录打包为临时 zip 文件，返回文件路径，调用方负责删除
+func zipSiteDir(siteDir string) (string, error) {
+	file, err := os.CreateTemp("", "creeper-site-*.zip")
+	if err != nil {
+		return "", err
+	}
+
+	writer := zip.NewWriter(file)
+	walkErr := filepath.Walk(siteDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(siteDir, path)
+		if err != nil {
+			return err
+		}
+		if isLocalBuildFile(relPath) {
+			return nil
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		header.Method = zip.Deflate
+
+		entry, err := writer.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		source, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer source.Close()
+
+		_, err = io.Copy(entry, source)
+		return err
+	})
+
+	if err := writer.Close(); err != nil && walkErr == nil {
+		walkErr = err
+	}
+	if err := file.Close(); err != nil && walkErr == nil {
+		walkErr = err
+	}
+	if walkErr != nil {
+		os.Remove(file.Name())
+		return "", walkErr
+	}
+
+	return file.Name(), nil
+}
@@ -70,7 +70,7 @@ func (dem *DeploymentEventManager) Notify(event *DeploymentEvent) {
 	dem.logger.Info("通知部署事件:", event.Type, "观察者数量:", len(dem.observers))
 	
 	for name, observer := range dem.observers {
-		go func(obs DeploymentObserver, evt *DeploymentEvent) {
+		go func(name string, obs DeploymentObserver, evt *DeploymentEvent) {
 			defer func() {
 				if r := recover(); r != nil {
 					dem.logger.Error("观察者处理事件时发生错误:", name, r)
@@ -78,7 +78,7 @@ func (dem *DeploymentEventManager) Notify(event *DeploymentEvent) {
 			}()
 			
 			obs.OnDeploymentEvent(evt)
-		}(observer, event)
+		}(name, observer, event)
 	}
 }
 
@@ -0,0 +1,25 @@
+package deploy
+
+// ProgressEvent 部署进度事件
+type ProgressEvent struct {
+	Percentage    int    `json:"percentage"`
+	Message       string `json:"message"`
+	FilesUploaded int    `json:"files_uploaded"`
+	TotalFiles    int    `json:"total_files"`
+}
+
+// DeployProgressCallback 接收部署进度的回调
+type DeployProgressCallback func(ProgressEvent)
+
+// ProgressReporter 支持进度回调的部署器
+type ProgressReporter interface {
+	SetProgressCallback(callback DeployProgressCallback)
+}
+
+// uploadPercentage 上传阶段的进度，映射到 [start, end] 区间
+func uploadPercentage(start, end, uploaded, total int) int {
+	if total <= 0 {
+		return end
+	}
+	return start + (end-start)*uploaded/total
+}
@@ -0,0 +1,125 @@
+package deploy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotIndexFile 用于校验快照完整性的文件
+const snapshotIndexFile = "index.html"
+
+// snapshotRoot 站点快照目录，与部署历史文件放在一起
+func (dc *DeploymentCaretaker) snapshotRoot() string {
+	return filepath.Join(filepath.Dir(dc.storagePath), "snapshots")
+}
+
+// isSnapshot 判断目录是否为已有的站点快照（回滚时直接部署快照目录）
+func (dc *DeploymentCaretaker) isSnapshot(dir string) bool {
+	root, err := filepath.Abs(dc.snapshotRoot())
+	if err != nil {
+		return false
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(abs, root+string(filepath.Separator))
+}
+
+// Snapshot 复制已部署的站点目录作为回滚点，并记录 index.html 的 SHA-256
+// siteDir 本身就是快照（回滚部署）时直接复用，不再复制
+func (dc *DeploymentCaretaker) Snapshot(memento *DeploymentMemento, siteDir string) error {
+	snapshotDir := siteDir
+	if !dc.isSnapshot(siteDir) {
+		snapshotDir = filepath.Join(dc.snapshotRoot(), memento.ID)
+		if err := os.RemoveAll(snapshotDir); err != nil {
+			return fmt.Errorf("清理快照目录失败: %w", err)
+		}
+		if err := copyDir(siteDir, snapshotDir); err != nil {
+			return fmt.Errorf("复制站点快照失败: %w", err)
+		}
+	}
+
+	hash, err := hashFile(filepath.Join(snapshotDir, snapshotIndexFile))
+	if err != nil {
+		return fmt.Errorf("计算 %s 哈希失败: %w", snapshotIndexFile, err)
+	}
+
+	memento.RollbackPoint = snapshotDir
+	memento.IndexHash = hash
+	return nil
+}
+
+// GetRollbackMemento 获取回滚目标：最近一次成功部署之前第 steps 次成功且带快照的部署
+// steps 为 1 时回到上一次部署
+func (dc *DeploymentCaretaker) GetRollbackMemento(steps int) (*DeploymentMemento, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("回滚步数必须大于 0")
+	}
+
+	var candidates []*DeploymentMemento
+	for i := len(dc.mementos) - 1; i >= 0; i-- {
+		memento := dc.mementos[i]
+		if memento.Status == "success" && memento.RollbackPoint != "" {
+			candidates = append(candidates, memento)
+		}
+	}
+
+	if steps >= len(candidates) {
+		return nil, fmt.Errorf("没有可回滚的部署：共有 %d 个带快照的成功部署，无法回滚 %d 步", len(candidates), steps)
+	}
+	return candidates[steps], nil
+}
+
+// VerifySnapshot 校验快照的 index.html 与部署时记录的哈希一致
+func (dc *DeploymentCaretaker) VerifySnapshot(memento *DeploymentMemento) error {
+	if memento.RollbackPoint == "" {
+		return fmt.Errorf("部署 %s 没有站点快照", memento.ID)
+	}
+
+	hash, err := hashFile(filepath.Join(memento.RollbackPoint, snapshotIndexFile))
+	if err != nil {
+		return fmt.Errorf("读取快照失败: %w", err)
+	}
+	if hash != memento.IndexHash {
+		return fmt.Errorf("快照 %s 已被修改：%s 哈希不匹配", memento.RollbackPoint, snapshotIndexFile)
+	}
+	return nil
+}
+
+// pruneSnapshots 删除不再被任何历史记录引用的快照
+func (dc *DeploymentCaretaker) pruneSnapshots(dropped []*DeploymentMemento) {
+	inUse := make(map[string]bool, len(dc.mementos))
+	for _, memento := range dc.mementos {
+		inUse[memento.RollbackPoint] = true
+	}
+
+	for _, memento := range dropped {
+		if memento.RollbackPoint == "" || inUse[memento.RollbackPoint] || !dc.isSnapshot(memento.RollbackPoint) {
+			continue
+		}
+		if err := os.RemoveAll(memento.RollbackPoint); err != nil {
+			dc.logger.Warn("删除过期快照失败:", err)
+		}
+	}
+}
+
+// hashFile 计算文件的 SHA-256
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
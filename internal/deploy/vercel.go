@@ -0,0 +1,255 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"time"
+
+	"creeper/internal/common"
+)
+
+// vercelAPIBase Vercel API 地址
+const vercelAPIBase = "https://api.vercel.com"
+
+// Vercel 部署轮询参数：Deploy Hook 触发的构建在 Vercel 上完成，状态变为 READY 或 ERROR 时结束
+const (
+	vercelPollInterval = 5 * time.Second
+	vercelPollTimeout  = 15 * time.Minute
+	// vercelDeploymentListLimit 每次查询的部署数量
+	vercelDeploymentListLimit = 10
+)
+
+// vercelDeployment Vercel 部署记录中用到的字段
+type vercelDeployment struct {
+	UID        string `json:"uid"`
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	ReadyState string `json:"readyState"`
+	Created    int64  `json:"created"`
+}
+
+// VercelDeployer Vercel 部署器
+// 通过 Deploy Hook 触发 Vercel 重新构建，并等待最新部署完成
+type VercelDeployer struct {
+	config *VercelConfig
+	logger *common.Logger
+	client *http.Client
+	url    string
+}
+
+// NewVercelDeployer 创建 Vercel 部署器
+func NewVercelDeployer(config *VercelConfig) *VercelDeployer {
+	return &VercelDeployer{
+		config: config,
+		logger: common.GetLogger(),
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Deploy 触发 Vercel 部署
+// 站点由 Vercel 根据项目配置重新构建，siteDir 仅用于检查本地构建是否存在
+// 不上传本地文件，因此增量构建清单等本地构建文件不会被发布
+func (vd *VercelDeployer) Deploy(siteDir string) error {
+	vd.logger.Info("开始部署到 Vercel")
+	vd.logger.Info("项目 ID:", vd.config.ProjectID)
+
+	// 1. 验证配置
+	if err := vd.validateConfig(); err != nil {
+		return fmt.Errorf("配置验证失败: %w", err)
+	}
+
+	if _, err := os.Stat(siteDir); err != nil {
+		return fmt.Errorf("站点目录验证失败: %w", err)
+	}
+
+	// 2. 记录触发前已存在的部署，避免把之前的 READY 部署误认为本次结果
+	existing, err := vd.listDeployments(vercelDeploymentListLimit)
+	if err != nil {
+		return fmt.Errorf("获取现有部署失败: %w", err)
+	}
+	known := make(map[string]bool, len(existing))
+	for _, d := range existing {
+		known[d.UID] = true
+	}
+
+	// 3. 触发 Deploy Hook
+	triggeredAt := time.Now()
+	if err := vd.triggerDeployHook(); err != nil {
+		return fmt.Errorf("触发 Deploy Hook 失败: %w", err)
+	}
+
+	// 4. 等待本次触发的部署完成
+	deployment, err := vd.waitForDeployment(triggeredAt, known)
+	if err != nil {
+		return fmt.Errorf("等待部署完成失败: %w", err)
+	}
+
+	vd.url = "https://" + deployment.URL
+	vd.logger.Info("Vercel 部署完成:", vd.GetDeploymentURL())
+	return nil
+}
+
+// validateConfig 验证配置
+func (vd *VercelDeployer) validateConfig() error {
+	if vd.config.DeployHookURL == "" {
+		return fmt.Errorf("Deploy Hook 地址不能为空")
+	}
+	if vd.config.Token == "" {
+		return fmt.Errorf("Token 不能为空")
+	}
+	return nil
+}
+
+// triggerDeployHook 以不带请求体的 POST 调用 Deploy Hook
+func (vd *VercelDeployer) triggerDeployHook() error {
+	req, err := http.NewRequest("POST", vd.config.DeployHookURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := vd.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("请求失败: %s, 响应: %s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// waitForDeployment 轮询部署列表，直到本次触发创建的部署变为 READY 或 ERROR
+// 本次部署须不在 known 中且创建时间不早于 triggeredAt，找到后只跟踪该部署
+func (vd *VercelDeployer) waitForDeployment(triggeredAt time.Time, known map[string]bool) (*vercelDeployment, error) {
+	deadline := time.Now().Add(vercelPollTimeout)
+	// created 为毫秒时间戳
+	since := triggeredAt.UnixMilli()
+	uid := ""
+
+	for {
+		deployments, err := vd.listDeployments(vercelDeploymentListLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		if current := findTriggeredDeployment(deployments, uid, since, known); current != nil {
+			uid = current.UID
+			switch current.ReadyState {
+			case "READY":
+				return current, nil
+			case "ERROR", "CANCELED":
+				return nil, fmt.Errorf("部署 %s 失败，状态: %s", current.UID, current.ReadyState)
+			}
+			vd.logger.Debug("部署状态:", current.ReadyState)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("部署在 %v 内未完成", vercelPollTimeout)
+		}
+		time.Sleep(vercelPollInterval)
+	}
+}
+
+// findTriggeredDeployment 在部署列表中查找本次触发的部署
+// uid 非空时只匹配该部署；否则取不在 known 中、创建时间不早于 since 的最早一条
+func findTriggeredDeployment(deployments []vercelDeployment, uid string, since int64, known map[string]bool) *vercelDeployment {
+	var found *vercelDeployment
+	for i := range deployments {
+		d := &deployments[i]
+		if uid != "" {
+			if d.UID == uid {
+				return d
+			}
+			continue
+		}
+		if known[d.UID] || d.Created < since {
+			continue
+		}
+		// 列表按创建时间倒序，取最早的新部署，即本次 Hook 触发的那一条
+		found = d
+	}
+	return found
+}
+
+// listDeployments 获取最近的部署，按创建时间倒序
+func (vd *VercelDeployer) listDeployments(limit int) ([]vercelDeployment, error) {
+	query := neturl.Values{}
+	query.Set("limit", fmt.Sprint(limit))
+	if vd.config.TeamID != "" {
+		query.Set("teamId", vd.config.TeamID)
+	}
+	if vd.config.ProjectID != "" {
+		query.Set("projectId", vd.config.ProjectID)
+	}
+
+	url := fmt.Sprintf("%s/v6/deployments?%s", vercelAPIBase, query.Encode())
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+vd.config.Token)
+
+	resp, err := vd.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("获取部署列表失败: %s, 响应: %s", resp.Status, string(body))
+	}
+
+	var result struct {
+		Deployments []vercelDeployment `json:"deployments"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	return result.Deployments, nil
+}
+
+// GetStatus 获取最新部署的状态
+func (vd *VercelDeployer) GetStatus() (map[string]interface{}, error) {
+	deployments, err := vd.listDeployments(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deployments) == 0 {
+		return map[string]interface{}{
+			"type":    "vercel",
+			"status":  "no_deployments",
+			"message": "没有找到部署记录",
+		}, nil
+	}
+
+	latest := deployments[0]
+	return map[string]interface{}{
+		"type":    "vercel",
+		"id":      latest.UID,
+		"status":  latest.ReadyState,
+		"url":     "https://" + latest.URL,
+		"created": time.UnixMilli(latest.Created).Format(time.RFC3339),
+	}, nil
+}
+
+// GetDeploymentURL 获取部署URL，部署完成前返回 Vercel 控制台地址
+func (vd *VercelDeployer) GetDeploymentURL() string {
+	if vd.url != "" {
+		return vd.url
+	}
+	return "https://vercel.com"
+}
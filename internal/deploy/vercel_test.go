@@ -0,0 +1,72 @@
+package deploy
+
+import "testing"
+
+func TestFindTriggeredDeployment(t *testing.T) {
+	const since = int64(1000)
+	known := map[string]bool{"old": true}
+
+	tests := []struct {
+		name        string
+		deployments []vercelDeployment
+		uid         string
+		want        string
+	}{
+		{
+			name:        "earlier ready deployment is ignored",
+			deployments: []vercelDeployment{{UID: "old", ReadyState: "READY", Created: 990}},
+			want:        "",
+		},
+		{
+			name:        "known deployment created after trigger is ignored",
+			deployments: []vercelDeployment{{UID: "old", ReadyState: "READY", Created: 1500}},
+			want:        "",
+		},
+		{
+			name: "unknown deployment created before trigger is ignored",
+			deployments: []vercelDeployment{
+				{UID: "other", ReadyState: "READY", Created: 999},
+			},
+			want: "",
+		},
+		{
+			name: "oldest new deployment is picked",
+			deployments: []vercelDeployment{
+				{UID: "newer", ReadyState: "BUILDING", Created: 1200},
+				{UID: "mine", ReadyState: "BUILDING", Created: 1100},
+				{UID: "old", ReadyState: "READY", Created: 900},
+			},
+			want: "mine",
+		},
+		{
+			name: "tracked deployment is followed by uid",
+			deployments: []vercelDeployment{
+				{UID: "newer", ReadyState: "READY", Created: 1200},
+				{UID: "mine", ReadyState: "BUILDING", Created: 1100},
+			},
+			uid:  "mine",
+			want: "mine",
+		},
+		{
+			name: "tracked deployment missing from list",
+			deployments: []vercelDeployment{
+				{UID: "newer", ReadyState: "READY", Created: 1200},
+			},
+			uid:  "mine",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findTriggeredDeployment(tt.deployments, tt.uid, since, known)
+			gotUID := ""
+			if got != nil {
+				gotUID = got.UID
+			}
+			if gotUID != tt.want {
+				t.Errorf("findTriggeredDeployment() = %q, want %q", gotUID, tt.want)
+			}
+		})
+	}
+}
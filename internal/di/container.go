@@ -1,8 +1,11 @@
 package di
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -15,25 +18,79 @@ const (
 	Scoped                          // 作用域
 )
 
+// ErrCircularDependency 服务之间存在循环依赖
+var ErrCircularDependency = errors.New("检测到循环依赖")
+
 // ServiceDescriptor 服务描述符
 type ServiceDescriptor struct {
 	ServiceType reflect.Type
 	Lifetime    ServiceLifetime
 	Factory     func(container *Container) (interface{}, error)
 	Instance    interface{}
+	// Dependencies 通过 ServiceBuilder.DependsOn 声明的依赖，Build 时据此检测循环依赖
+	Dependencies []reflect.Type
+
+	// creationMutex 保护单例实例的创建，与容器锁分离以允许工厂函数解析依赖
+	creationMutex sync.Mutex
 }
 
 // Container 依赖注入容器
 type Container struct {
 	services map[reflect.Type]*ServiceDescriptor
 	mutex    sync.RWMutex
+
+	// dependencies 工厂函数运行时实际解析到的直接依赖
+	dependencies map[reflect.Type]map[reflect.Type]struct{}
+	depMutex     sync.Mutex
+
+	// 以下字段仅用于传给工厂函数的解析视图：记录当前正在创建的服务及解析路径
+	parent *Container
+	owner  reflect.Type
+	path   []reflect.Type
+	// scope 在作用域内解析时所属的作用域，作用域服务经由它创建
+	scope *Scope
 }
 
 // NewContainer 创建新的容器
 func NewContainer() *Container {
 	return &Container{
-		services: make(map[reflect.Type]*ServiceDescriptor),
+		services:     make(map[reflect.Type]*ServiceDescriptor),
+		dependencies: make(map[reflect.Type]map[reflect.Type]struct{}),
+	}
+}
+
+// root 返回持有服务注册信息的根容器
+func (c *Container) root() *Container {
+	if c.parent != nil {
+		return c.parent
+	}
+	return c
+}
+
+// viewFor 返回创建 t 时传给工厂函数的解析视图，用于记录依赖并检测循环依赖
+func (c *Container) viewFor(t reflect.Type) *Container {
+	path := make([]reflect.Type, len(c.path), len(c.path)+1)
+	copy(path, c.path)
+
+	return &Container{
+		parent: c.root(),
+		owner:  t,
+		path:   append(path, t),
+		scope:  c.scope,
+	}
+}
+
+// recordDependency 记录 owner 对 dependency 的直接依赖
+func (c *Container) recordDependency(owner, dependency reflect.Type) {
+	c.depMutex.Lock()
+	defer c.depMutex.Unlock()
+
+	deps, ok := c.dependencies[owner]
+	if !ok {
+		deps = make(map[reflect.Type]struct{})
+		c.dependencies[owner] = deps
 	}
+	deps[dependency] = struct{}{}
 }
 
 // RegisterTransient 注册瞬态服务
@@ -46,8 +103,14 @@ func (c *Container) RegisterSingleton(serviceType interface{}, factory func(*Con
 	c.register(serviceType, factory, Singleton)
 }
 
+// RegisterScoped 注册作用域服务
+func (c *Container) RegisterScoped(serviceType interface{}, factory func(*Container) (interface{}, error)) {
+	c.register(serviceType, factory, Scoped)
+}
+
 // RegisterInstance 注册实例
 func (c *Container) RegisterInstance(serviceType interface{}, instance interface{}) {
+	c = c.root()
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	
@@ -65,6 +128,7 @@ func (c *Container) RegisterInstance(serviceType interface{}, instance interface
 
 // register 注册服务
 func (c *Container) register(serviceType interface{}, factory func(*Container) (interface{}, error), lifetime ServiceLifetime) {
+	c = c.root()
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	
@@ -80,16 +144,36 @@ func (c *Container) register(serviceType interface{}, factory func(*Container) (
 	}
 }
 
+// serviceTypeOf 服务的类型键，(*T)(nil) 与 T 的值对应同一个键
+func serviceTypeOf(serviceType interface{}) reflect.Type {
+	t := reflect.TypeOf(serviceType)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
 // Resolve 解析服务
 func (c *Container) Resolve(serviceType interface{}) (interface{}, error) {
 	t := reflect.TypeOf(serviceType)
 	if t.Kind() == reflect.Ptr {
 		t = t.Elem()
 	}
+
+	// 在工厂函数中解析时记录依赖，并在解析路径成环时报错而不是死锁
+	root := c.root()
+	if c.owner != nil {
+		root.recordDependency(c.owner, t)
+		for _, visiting := range c.path {
+			if visiting == t {
+				return nil, fmt.Errorf("%w: %s", ErrCircularDependency, formatPath(append(c.path, t)))
+			}
+		}
+	}
 	
-	c.mutex.RLock()
-	descriptor, exists := c.services[t]
-	c.mutex.RUnlock()
+	root.mutex.RLock()
+	descriptor, exists := root.services[t]
+	root.mutex.RUnlock()
 	
 	if !exists {
 		return nil, fmt.Errorf("服务未注册: %s", t.Name())
@@ -102,15 +186,18 @@ func (c *Container) Resolve(serviceType interface{}) (interface{}, error) {
 		}
 		
 		// 创建单例实例
-		c.mutex.Lock()
-		defer c.mutex.Unlock()
+		descriptor.creationMutex.Lock()
+		defer descriptor.creationMutex.Unlock()
 		
 		// 双重检查
 		if descriptor.Instance != nil {
 			return descriptor.Instance, nil
 		}
 		
-		instance, err := descriptor.Factory(c)
+		// 单例的生命周期长于作用域，不能依赖作用域服务
+		view := c.viewFor(t)
+		view.scope = nil
+		instance, err := descriptor.Factory(view)
 		if err != nil {
 			return nil, err
 		}
@@ -119,13 +206,28 @@ func (c *Container) Resolve(serviceType interface{}) (interface{}, error) {
 		return instance, nil
 		
 	case Transient:
-		return descriptor.Factory(c)
+		return descriptor.Factory(c.viewFor(t))
+
+	case Scoped:
+		if c.scope == nil {
+			return nil, fmt.Errorf("作用域服务必须通过 Scope 解析: %s", t.Name())
+		}
+		return c.scope.resolveScoped(c, t, descriptor)
 		
 	default:
-		return descriptor.Factory(c)
+		return descriptor.Factory(c.viewFor(t))
 	}
 }
 
+// formatPath 格式化解析路径，如 A -> B -> A
+func formatPath(path []reflect.Type) string {
+	names := make([]string, len(path))
+	for i, t := range path {
+		names[i] = t.Name()
+	}
+	return strings.Join(names, " -> ")
+}
+
 // MustResolve 必须解析服务（panic on error）
 func (c *Container) MustResolve(serviceType interface{}) interface{} {
 	service, err := c.Resolve(serviceType)
@@ -137,6 +239,7 @@ func (c *Container) MustResolve(serviceType interface{}) interface{} {
 
 // GetRegisteredServices 获取已注册的服务
 func (c *Container) GetRegisteredServices() []string {
+	c = c.root()
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 	
@@ -148,17 +251,71 @@ func (c *Container) GetRegisteredServices() []string {
 	return services
 }
 
+// GetDependencyGraph 获取服务依赖图：服务名 -> 直接依赖的服务名
+// 依赖来自 DependsOn 的声明以及已运行过的工厂函数中实际调用的 Resolve；不会为绘制依赖图而创建任何服务，
+// 没有声明且尚未解析过的服务不会出现依赖边
+func (c *Container) GetDependencyGraph() map[string][]string {
+	c = c.root()
+
+	edges := make(map[reflect.Type]map[reflect.Type]struct{})
+	addEdge := func(owner, dep reflect.Type) {
+		deps, ok := edges[owner]
+		if !ok {
+			deps = make(map[reflect.Type]struct{})
+			edges[owner] = deps
+		}
+		deps[dep] = struct{}{}
+	}
+
+	c.mutex.RLock()
+	graph := make(map[string][]string, len(c.services))
+	for t, descriptor := range c.services {
+		graph[t.Name()] = []string{}
+		for _, dep := range descriptor.Dependencies {
+			addEdge(t, dep)
+		}
+	}
+	c.mutex.RUnlock()
+
+	c.depMutex.Lock()
+	for owner, deps := range c.dependencies {
+		for dep := range deps {
+			addEdge(owner, dep)
+		}
+	}
+	c.depMutex.Unlock()
+
+	for owner, deps := range edges {
+		names := make([]string, 0, len(deps))
+		for dep := range deps {
+			names = append(names, dep.Name())
+		}
+		sort.Strings(names)
+		graph[owner.Name()] = names
+	}
+
+	return graph
+}
+
 // Clear 清空容器
 func (c *Container) Clear() {
+	c = c.root()
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	
 	c.services = make(map[reflect.Type]*ServiceDescriptor)
+
+	c.depMutex.Lock()
+	c.dependencies = make(map[reflect.Type]map[reflect.Type]struct{})
+	c.depMutex.Unlock()
 }
 
 // ServiceBuilder 服务构建器
 type ServiceBuilder struct {
 	container *Container
+	// last 最近添加的服务，DependsOn 为其声明依赖
+	last reflect.Type
+	err  error
 }
 
 // NewServiceBuilder 创建服务构建器
@@ -171,24 +328,115 @@ func NewServiceBuilder() *ServiceBuilder {
 // AddTransient 添加瞬态服务
 func (sb *ServiceBuilder) AddTransient(serviceType interface{}, factory func(*Container) (interface{}, error)) *ServiceBuilder {
 	sb.container.RegisterTransient(serviceType, factory)
+	sb.last = serviceTypeOf(serviceType)
 	return sb
 }
 
 // AddSingleton 添加单例服务
 func (sb *ServiceBuilder) AddSingleton(serviceType interface{}, factory func(*Container) (interface{}, error)) *ServiceBuilder {
 	sb.container.RegisterSingleton(serviceType, factory)
+	sb.last = serviceTypeOf(serviceType)
 	return sb
 }
 
 // AddInstance 添加实例
 func (sb *ServiceBuilder) AddInstance(serviceType interface{}, instance interface{}) *ServiceBuilder {
 	sb.container.RegisterInstance(serviceType, instance)
+	sb.last = serviceTypeOf(serviceType)
+	return sb
+}
+
+// AddScoped 添加作用域服务
+func (sb *ServiceBuilder) AddScoped(serviceType interface{}, factory func(*Container) (interface{}, error)) *ServiceBuilder {
+	sb.container.RegisterScoped(serviceType, factory)
+	sb.last = serviceTypeOf(serviceType)
 	return sb
 }
 
-// Build 构建容器
-func (sb *ServiceBuilder) Build() *Container {
-	return sb.container
+// DependsOn 声明最近添加的服务在工厂函数中会解析的服务，如
+//
+//	builder.AddSingleton((*generator.Generator)(nil), factory).DependsOn((*config.Config)(nil))
+//
+// Build 时检查声明的依赖均已注册且没有循环依赖
+func (sb *ServiceBuilder) DependsOn(serviceTypes ...interface{}) *ServiceBuilder {
+	if sb.last == nil {
+		sb.err = fmt.Errorf("DependsOn 必须在添加服务之后调用")
+		return sb
+	}
+
+	c := sb.container
+	c.mutex.Lock()
+	descriptor := c.services[sb.last]
+	for _, serviceType := range serviceTypes {
+		descriptor.Dependencies = append(descriptor.Dependencies, serviceTypeOf(serviceType))
+	}
+	c.mutex.Unlock()
+	return sb
+}
+
+// Build 构建容器，声明的依赖未注册或存在循环依赖时返回错误
+// Build 不运行工厂函数，只能看到 DependsOn 声明的依赖；工厂函数中未声明的 Resolve 形成的循环
+// 要到 Resolve 时才会以 ErrCircularDependency 报错，因此工厂函数解析的服务都应通过 DependsOn 声明
+func (sb *ServiceBuilder) Build() (*Container, error) {
+	if sb.err != nil {
+		return nil, sb.err
+	}
+	if err := sb.container.checkDependencies(); err != nil {
+		return nil, err
+	}
+	return sb.container, nil
+}
+
+// checkDependencies 按声明的依赖检查未注册的服务与循环依赖，并记录到依赖图
+func (c *Container) checkDependencies() error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	types := make([]reflect.Type, 0, len(c.services))
+	for t := range c.services {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].String() < types[j].String() })
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[reflect.Type]int, len(types))
+	var path []reflect.Type
+
+	var visit func(t reflect.Type) error
+	visit = func(t reflect.Type) error {
+		switch state[t] {
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrCircularDependency, formatPath(append(path, t)))
+		case done:
+			return nil
+		}
+
+		state[t] = visiting
+		path = append(path, t)
+		for _, dep := range c.services[t].Dependencies {
+			if _, ok := c.services[dep]; !ok {
+				return fmt.Errorf("服务 %s 依赖的 %s 未注册", t.Name(), dep.Name())
+			}
+			c.recordDependency(t, dep)
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[t] = done
+		return nil
+	}
+
+	for _, t := range types {
+		if err := visit(t); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ServiceLocator 服务定位器
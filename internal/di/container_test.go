@@ -0,0 +1,213 @@
+package di
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// 服务带有字段，避免零大小类型的不同实例指针相等
+type (
+	serviceA struct{ id int }
+	serviceB struct{ id int }
+	serviceC struct{ id int }
+)
+
+func TestResolveLifetimes(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(c *Container, factory func(*Container) (interface{}, error))
+		wantSame bool
+	}{
+		{
+			name: "singleton",
+			register: func(c *Container, factory func(*Container) (interface{}, error)) {
+				c.RegisterSingleton((*serviceA)(nil), factory)
+			},
+			wantSame: true,
+		},
+		{
+			name: "transient",
+			register: func(c *Container, factory func(*Container) (interface{}, error)) {
+				c.RegisterTransient((*serviceA)(nil), factory)
+			},
+			wantSame: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			c := NewContainer()
+			tt.register(c, func(*Container) (interface{}, error) {
+				calls++
+				return &serviceA{id: calls}, nil
+			})
+
+			first := c.MustResolve((*serviceA)(nil))
+			second := c.MustResolve((*serviceA)(nil))
+			if same := first == second; same != tt.wantSame {
+				t.Errorf("两次解析为同一实例 = %v, want %v", same, tt.wantSame)
+			}
+			wantCalls := 2
+			if tt.wantSame {
+				wantCalls = 1
+			}
+			if calls != wantCalls {
+				t.Errorf("工厂函数调用 %d 次, want %d", calls, wantCalls)
+			}
+		})
+	}
+}
+
+func TestResolveInterfaceKey(t *testing.T) {
+	c := NewContainer()
+	reader := strings.NewReader("creeper")
+	c.RegisterInstance((*io.Reader)(nil), reader)
+
+	got, err := c.Resolve((*io.Reader)(nil))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got != reader {
+		t.Errorf("Resolve() = %v, want %v", got, reader)
+	}
+
+	if _, err := c.Resolve((*io.Writer)(nil)); err == nil || !strings.Contains(err.Error(), "服务未注册: Writer") {
+		t.Errorf("Resolve(io.Writer) error = %v, want 服务未注册", err)
+	}
+}
+
+func TestCircularDependencies(t *testing.T) {
+	// resolving 返回在工厂函数中解析 deps 的工厂
+	resolving := func(deps ...interface{}) func(*Container) (interface{}, error) {
+		return func(c *Container) (interface{}, error) {
+			for _, dep := range deps {
+				if _, err := c.Resolve(dep); err != nil {
+					return nil, err
+				}
+			}
+			return struct{}{}, nil
+		}
+	}
+
+	tests := []struct {
+		name        string
+		build       func(sb *ServiceBuilder)
+		wantBuild   string
+		wantResolve string
+	}{
+		{
+			name: "declared cycle",
+			build: func(sb *ServiceBuilder) {
+				sb.AddSingleton((*serviceA)(nil), resolving()).DependsOn((*serviceB)(nil))
+				sb.AddSingleton((*serviceB)(nil), resolving()).DependsOn((*serviceA)(nil))
+			},
+			wantBuild: "serviceA -> serviceB -> serviceA",
+		},
+		{
+			name: "declared self dependency",
+			build: func(sb *ServiceBuilder) {
+				sb.AddTransient((*serviceA)(nil), resolving()).DependsOn((*serviceA)(nil))
+			},
+			wantBuild: "serviceA -> serviceA",
+		},
+		{
+			name: "declared three service cycle",
+			build: func(sb *ServiceBuilder) {
+				sb.AddSingleton((*serviceA)(nil), resolving()).DependsOn((*serviceB)(nil))
+				sb.AddSingleton((*serviceB)(nil), resolving()).DependsOn((*serviceC)(nil))
+				sb.AddSingleton((*serviceC)(nil), resolving()).DependsOn((*serviceA)(nil))
+			},
+			wantBuild: "serviceA -> serviceB -> serviceC -> serviceA",
+		},
+		{
+			// Build 只看到声明的依赖，工厂函数中的循环在 Resolve 时才会发现
+			name: "factory cycle",
+			build: func(sb *ServiceBuilder) {
+				sb.AddSingleton((*serviceA)(nil), resolving((*serviceB)(nil)))
+				sb.AddTransient((*serviceB)(nil), resolving((*serviceA)(nil)))
+			},
+			wantResolve: "serviceA -> serviceB -> serviceA",
+		},
+		{
+			name: "factory cycle behind declared edge",
+			build: func(sb *ServiceBuilder) {
+				sb.AddSingleton((*serviceA)(nil), resolving((*serviceB)(nil))).DependsOn((*serviceB)(nil))
+				sb.AddSingleton((*serviceB)(nil), resolving((*serviceC)(nil)))
+				sb.AddSingleton((*serviceC)(nil), resolving((*serviceA)(nil)))
+			},
+			wantResolve: "serviceA -> serviceB -> serviceC -> serviceA",
+		},
+		{
+			name: "no cycle",
+			build: func(sb *ServiceBuilder) {
+				sb.AddSingleton((*serviceA)(nil), resolving((*serviceB)(nil))).DependsOn((*serviceB)(nil))
+				sb.AddSingleton((*serviceB)(nil), resolving())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := NewServiceBuilder()
+			tt.build(sb)
+
+			c, err := sb.Build()
+			if tt.wantBuild != "" {
+				if !errors.Is(err, ErrCircularDependency) || !strings.Contains(err.Error(), tt.wantBuild) {
+					t.Fatalf("Build() error = %v, want %s 循环", err, tt.wantBuild)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+
+			_, err = c.Resolve((*serviceA)(nil))
+			if tt.wantResolve == "" {
+				if err != nil {
+					t.Errorf("Resolve() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrCircularDependency) || !strings.Contains(err.Error(), tt.wantResolve) {
+				t.Errorf("Resolve() error = %v, want %s 循环", err, tt.wantResolve)
+			}
+		})
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(sb *ServiceBuilder)
+		wantErr string
+	}{
+		{
+			name: "unregistered dependency",
+			build: func(sb *ServiceBuilder) {
+				sb.AddSingleton((*serviceA)(nil), nil).DependsOn((*serviceB)(nil))
+			},
+			wantErr: "服务 serviceA 依赖的 serviceB 未注册",
+		},
+		{
+			name: "DependsOn before adding a service",
+			build: func(sb *ServiceBuilder) {
+				sb.DependsOn((*serviceA)(nil))
+			},
+			wantErr: "DependsOn 必须在添加服务之后调用",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := NewServiceBuilder()
+			tt.build(sb)
+			if _, err := sb.Build(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetDependencyGraphDoesNotResolve(t *testing.T) {
+	called := false
+	factory := func(*Container) (interface{}, error) {
+		called = true
+		return &serviceA{}, nil
+	}
+
+	sb := NewServiceBuilder()
+	sb.AddSingleton((*serviceA)(nil), factory).DependsOn((*serviceB)(nil), (*serviceC)(nil))
+	sb.AddTransient((*serviceB)(nil), factory).DependsOn((*serviceC)(nil))
+	sb.AddScoped((*serviceC)(nil), factory)
+	c, err := sb.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	got := c.GetDependencyGraph()
+	want := map[string][]string{
+		"serviceA": {"serviceB", "serviceC"},
+		"serviceB": {"serviceC"},
+		"serviceC": {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetDependencyGraph() = %v, want %v", got, want)
+	}
+	if called {
+		t.Error("GetDependencyGraph() 调用了工厂函数")
+	}
+}
+
+func TestGetDependencyGraphIncludesResolvedEdges(t *testing.T) {
+	c := NewContainer()
+	c.RegisterSingleton((*serviceA)(nil), func(c *Container) (interface{}, error) {
+		if _, err := c.Resolve((*serviceB)(nil)); err != nil {
+			return nil, err
+		}
+		return &serviceA{}, nil
+	})
+	c.RegisterTransient((*serviceB)(nil), func(*Container) (interface{}, error) {
+		return &serviceB{}, nil
+	})
+
+	// 未解析过的工厂函数中的依赖不可见
+	if got := c.GetDependencyGraph()["serviceA"]; len(got) != 0 {
+		t.Errorf("解析前 serviceA 的依赖 = %v, want 空", got)
+	}
+
+	c.MustResolve((*serviceA)(nil))
+	if got := c.GetDependencyGraph()["serviceA"]; !reflect.DeepEqual(got, []string{"serviceB"}) {
+		t.Errorf("解析后 serviceA 的依赖 = %v, want [serviceB]", got)
+	}
+}
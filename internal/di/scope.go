@@ -0,0 +1,122 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// Scope 服务作用域，作用域服务在同一作用域内只创建一次
+type Scope struct {
+	container *Container
+	instances map[reflect.Type]*scopedInstance
+	resolved  []interface{}
+	closed    bool
+	mutex     sync.Mutex
+}
+
+// scopedInstance 作用域内的服务实例，工厂函数运行期间不持有作用域锁，以便解析其他作用域服务
+type scopedInstance struct {
+	once     sync.Once
+	instance interface{}
+	err      error
+}
+
+// CreateScope 创建新的作用域（例如每次生成或每个 HTTP 请求）
+func (c *Container) CreateScope() *Scope {
+	return &Scope{
+		container: c.root(),
+		instances: make(map[reflect.Type]*scopedInstance),
+	}
+}
+
+// Resolve 在作用域内解析服务
+// 工厂函数收到的容器视图同样属于该作用域，作用域服务与瞬态服务可以继续解析作用域服务
+func (s *Scope) Resolve(serviceType interface{}) (interface{}, error) {
+	view := &Container{parent: s.container, scope: s}
+	return view.Resolve(serviceType)
+}
+
+// resolveScoped 获取或创建作用域服务，view 为发起解析的容器视图
+func (s *Scope) resolveScoped(view *Container, t reflect.Type, descriptor *ServiceDescriptor) (interface{}, error) {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil, fmt.Errorf("作用域已关闭: %s", t.Name())
+	}
+	entry, ok := s.instances[t]
+	if !ok {
+		entry = &scopedInstance{}
+		s.instances[t] = entry
+	}
+	s.mutex.Unlock()
+
+	entry.once.Do(func() {
+		entry.instance, entry.err = descriptor.Factory(view.viewFor(t))
+		if entry.err != nil {
+			return
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if s.closed {
+			// 创建期间作用域已关闭，实例不再登记，直接关闭
+			if closer, ok := entry.instance.(io.Closer); ok {
+				closer.Close()
+			}
+			entry.instance, entry.err = nil, fmt.Errorf("作用域已关闭: %s", t.Name())
+			return
+		}
+		s.resolved = append(s.resolved, entry.instance)
+	})
+
+	if entry.err != nil {
+		// 创建失败时移除记录，下次解析重新调用工厂函数
+		s.mutex.Lock()
+		if s.instances[t] == entry {
+			delete(s.instances, t)
+		}
+		s.mutex.Unlock()
+		return nil, entry.err
+	}
+	return entry.instance, nil
+}
+
+// MustResolve 必须在作用域内解析服务（panic on error）
+func (s *Scope) MustResolve(serviceType interface{}) interface{} {
+	service, err := s.Resolve(serviceType)
+	if err != nil {
+		panic(fmt.Sprintf("无法解析服务: %v", err))
+	}
+	return service
+}
+
+// Close 关闭作用域，按创建顺序的逆序关闭实现了 io.Closer 的作用域服务
+func (s *Scope) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	var errs []error
+	for i := len(s.resolved) - 1; i >= 0; i-- {
+		if closer, ok := s.resolved[i].(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	s.instances = nil
+	s.resolved = nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("关闭作用域服务失败: %w", errors.Join(errs...))
+	}
+	return nil
+}
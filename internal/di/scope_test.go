@@ -0,0 +1,275 @@
+package di
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type (
+	scopedRepo    struct{ id int }
+	scopedService struct{ repo *scopedRepo }
+	scopedHandler struct{ service *scopedService }
+	singletonDep  struct{ repo interface{} }
+)
+
+// closeRecorder 记录关闭顺序，err 非空时关闭返回该错误
+type closeRecorder struct {
+	name  string
+	order *[]string
+	err   error
+}
+
+func (r *closeRecorder) Close() error {
+	*r.order = append(*r.order, r.name)
+	return r.err
+}
+
+// newScopedContainer 注册 scopedRepo -> scopedService 的作用域服务链与依赖作用域服务的瞬态服务
+func newScopedContainer(created *int) *Container {
+	c := NewContainer()
+	c.RegisterScoped((*scopedRepo)(nil), func(*Container) (interface{}, error) {
+		*created++
+		return &scopedRepo{id: *created}, nil
+	})
+	c.RegisterScoped((*scopedService)(nil), func(c *Container) (interface{}, error) {
+		repo, err := c.Resolve((*scopedRepo)(nil))
+		if err != nil {
+			return nil, err
+		}
+		return &scopedService{repo: repo.(*scopedRepo)}, nil
+	})
+	c.RegisterTransient((*scopedHandler)(nil), func(c *Container) (interface{}, error) {
+		service, err := c.Resolve((*scopedService)(nil))
+		if err != nil {
+			return nil, err
+		}
+		return &scopedHandler{service: service.(*scopedService)}, nil
+	})
+	return c
+}
+
+func TestScopeResolve(t *testing.T) {
+	created := 0
+	c := newScopedContainer(&created)
+
+	first := c.CreateScope()
+	second := c.CreateScope()
+
+	a1 := first.MustResolve((*scopedRepo)(nil))
+	a2 := first.MustResolve((*scopedRepo)(nil))
+	b := second.MustResolve((*scopedRepo)(nil))
+
+	if a1 != a2 {
+		t.Error("同一作用域内解析到不同实例")
+	}
+	if a1 == b {
+		t.Error("不同作用域解析到同一实例")
+	}
+	if created != 2 {
+		t.Errorf("工厂函数调用 %d 次, want 2", created)
+	}
+}
+
+func TestScopedDependsOnScoped(t *testing.T) {
+	created := 0
+	c := newScopedContainer(&created)
+	scope := c.CreateScope()
+
+	service := scope.MustResolve((*scopedService)(nil)).(*scopedService)
+	repo := scope.MustResolve((*scopedRepo)(nil)).(*scopedRepo)
+	if service.repo != repo {
+		t.Error("作用域服务注入的依赖与作用域内的实例不同")
+	}
+
+	// 瞬态服务每次创建新实例，但注入的作用域服务在作用域内共享
+	h1 := scope.MustResolve((*scopedHandler)(nil)).(*scopedHandler)
+	h2 := scope.MustResolve((*scopedHandler)(nil)).(*scopedHandler)
+	if h1 == h2 {
+		t.Error("瞬态服务返回了同一实例")
+	}
+	if h1.service != service || h2.service != service {
+		t.Error("瞬态服务注入的作用域服务与作用域内的实例不同")
+	}
+	if created != 1 {
+		t.Errorf("作用域服务创建 %d 次, want 1", created)
+	}
+}
+
+func TestScopeResolveErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(c *Container)
+		resolve func(c *Container) error
+		wantErr string
+		is      error
+	}{
+		{
+			name: "scoped from container",
+			setup: func(c *Container) {
+				c.RegisterScoped((*scopedRepo)(nil), func(*Container) (interface{}, error) { return &scopedRepo{}, nil })
+			},
+			resolve: func(c *Container) error {
+				_, err := c.Resolve((*scopedRepo)(nil))
+				return err
+			},
+			wantErr: "作用域服务必须通过 Scope 解析",
+		},
+		{
+			name: "singleton depending on scoped",
+			setup: func(c *Container) {
+				c.RegisterScoped((*scopedRepo)(nil), func(*Container) (interface{}, error) { return &scopedRepo{}, nil })
+				c.RegisterSingleton((*singletonDep)(nil), func(c *Container) (interface{}, error) {
+					repo, err := c.Resolve((*scopedRepo)(nil))
+					return &singletonDep{repo: repo}, err
+				})
+			},
+			resolve: func(c *Container) error {
+				_, err := c.CreateScope().Resolve((*singletonDep)(nil))
+				return err
+			},
+			wantErr: "作用域服务必须通过 Scope 解析",
+		},
+		{
+			name: "scoped cycle",
+			setup: func(c *Container) {
+				c.RegisterScoped((*scopedRepo)(nil), func(c *Container) (interface{}, error) {
+					_, err := c.Resolve((*scopedService)(nil))
+					return &scopedRepo{}, err
+				})
+				c.RegisterScoped((*scopedService)(nil), func(c *Container) (interface{}, error) {
+					_, err := c.Resolve((*scopedRepo)(nil))
+					return &scopedService{}, err
+				})
+			},
+			resolve: func(c *Container) error {
+				_, err := c.CreateScope().Resolve((*scopedRepo)(nil))
+				return err
+			},
+			is: ErrCircularDependency,
+		},
+		{
+			name: "closed scope",
+			setup: func(c *Container) {
+				c.RegisterScoped((*scopedRepo)(nil), func(*Container) (interface{}, error) { return &scopedRepo{}, nil })
+			},
+			resolve: func(c *Container) error {
+				scope := c.CreateScope()
+				scope.Close()
+				_, err := scope.Resolve((*scopedRepo)(nil))
+				return err
+			},
+			wantErr: "作用域已关闭",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewContainer()
+			tt.setup(c)
+			err := tt.resolve(c)
+			if err == nil {
+				t.Fatal("error = nil, want error")
+			}
+			if tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Errorf("error = %v, want errors.Is %v", err, tt.is)
+			}
+		})
+	}
+}
+
+func TestScopeFactoryErrorIsRetried(t *testing.T) {
+	calls := 0
+	c := NewContainer()
+	c.RegisterScoped((*scopedRepo)(nil), func(*Container) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("暂时失败")
+		}
+		return &scopedRepo{id: calls}, nil
+	})
+
+	scope := c.CreateScope()
+	if _, err := scope.Resolve((*scopedRepo)(nil)); err == nil {
+		t.Fatal("第一次解析 error = nil, want error")
+	}
+	repo, err := scope.Resolve((*scopedRepo)(nil))
+	if err != nil {
+		t.Fatalf("第二次解析 error = %v", err)
+	}
+	if repo.(*scopedRepo).id != 2 {
+		t.Errorf("id = %d, want 2", repo.(*scopedRepo).id)
+	}
+}
+
+func TestScopeClose(t *testing.T) {
+	errFirst := errors.New("first 关闭失败")
+	errThird := errors.New("third 关闭失败")
+
+	tests := []struct {
+		name      string
+		errs      map[string]error
+		wantOrder []string
+		wantErrs  []error
+	}{
+		{
+			name:      "reverse creation order",
+			wantOrder: []string{"third", "second", "first"},
+		},
+		{
+			name:      "errors are joined",
+			errs:      map[string]error{"first": errFirst, "third": errThird},
+			wantOrder: []string{"third", "second", "first"},
+			wantErrs:  []error{errFirst, errThird},
+		},
+	}
+
+	type first struct{}
+	type second struct{}
+	type third struct{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var order []string
+			c := NewContainer()
+			register := func(key interface{}, name string) {
+				c.RegisterScoped(key, func(*Container) (interface{}, error) {
+					return &closeRecorder{name: name, order: &order, err: tt.errs[name]}, nil
+				})
+			}
+			register((*first)(nil), "first")
+			register((*second)(nil), "second")
+			register((*third)(nil), "third")
+
+			scope := c.CreateScope()
+			for _, key := range []interface{}{(*first)(nil), (*second)(nil), (*third)(nil)} {
+				scope.MustResolve(key)
+			}
+
+			err := scope.Close()
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("关闭顺序 = %v, want %v", order, tt.wantOrder)
+			}
+			if len(tt.wantErrs) == 0 && err != nil {
+				t.Errorf("Close() error = %v", err)
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("Close() error = %v, want errors.Is %v", err, want)
+				}
+			}
+
+			// 重复关闭不再调用 Close
+			if err := scope.Close(); err != nil {
+				t.Errorf("第二次 Close() error = %v", err)
+			}
+			if len(order) != len(tt.wantOrder) {
+				t.Errorf("重复关闭后关闭次数 = %d, want %d", len(order), len(tt.wantOrder))
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package facade
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
 	"creeper/internal/common"
@@ -11,8 +14,13 @@ import (
 	"creeper/internal/parser"
 )
 
+// configCacheTTL 配置缓存的有效期，长时间运行的进程过期后重新读取配置文件
+const configCacheTTL = 10 * time.Minute
+
 // CreeperFacade Creeper 外观类
 type CreeperFacade struct {
+	// mu 保护配置、各组件与生成选项，配置监听在后台重新加载配置时替换这些字段
+	mu              sync.RWMutex
 	config          *config.Config
 	parser          *parser.Parser
 	generator       *generator.Generator
@@ -20,7 +28,22 @@ type CreeperFacade struct {
 	deployManager   *deploy.DeployManager
 	logger          *common.Logger
 	resourceManager *common.GlobalResourceManager
-	configCache     *common.ConfigCache
+	configCache     *common.Cache[string, *config.Config]
+	configPath      string
+
+	// 命令行设置的生成选项，重新加载配置后重新应用到新的生成器
+	verbose    bool
+	workers    int
+	exportEPUB bool
+	liveReload bool
+
+	// reloaded 当前组件的生命周期，重新加载配置时取消，停止绑定到旧生成器的监听
+	reloaded       context.Context
+	cancelReloaded context.CancelFunc
+
+	// serving 本地服务器是否在运行；siteHandler 为当前生成器的处理器，重新加载配置后切换
+	serving     bool
+	siteHandler http.Handler
 }
 
 // NewCreeperFacade 创建 Creeper 外观
@@ -28,7 +51,8 @@ func NewCreeperFacade(configPath string) (*CreeperFacade, error) {
 	facade := &CreeperFacade{
 		logger:          common.GetLogger(),
 		resourceManager: common.GetGlobalResourceManager(),
-		configCache:     common.GetConfigCache(),
+		configCache:     config.GetConfigCache(),
+		configPath:      configPath,
 	}
 
 	// 初始化配置
@@ -48,11 +72,9 @@ func NewCreeperFacade(configPath string) (*CreeperFacade, error) {
 func (cf *CreeperFacade) initializeConfig(configPath string) error {
 	// 尝试从缓存加载
 	if cached, exists := cf.configCache.Get(configPath); exists {
-		if config, ok := cached.(*config.Config); ok {
-			cf.config = config
-			cf.logger.Info("从缓存加载配置:", configPath)
-			return nil
-		}
+		cf.config = cached
+		cf.logger.Info("从缓存加载配置:", configPath)
+		return nil
 	}
 
 	// 加载配置文件
@@ -64,53 +86,99 @@ func (cf *CreeperFacade) initializeConfig(configPath string) error {
 
 	cf.config = cfg
 
-	// 缓存配置
-	cf.configCache.Set(configPath, cfg)
+	// 缓存配置，过期后重新读取配置文件
+	cf.configCache.SetWithTTL(configPath, cfg, configCacheTTL)
 
 	return nil
 }
 
-// initializeComponents 初始化组件
+// initializeComponents 根据当前配置创建组件并替换旧组件
+// 本地服务器运行时同时切换到新生成器的处理器，旧生成器的监听随旧生命周期结束
 func (cf *CreeperFacade) initializeComponents() {
+	cf.mu.RLock()
+	cfg := cf.config
+	cf.mu.RUnlock()
+
 	// 创建解析器
-	cf.parser = parser.New()
+	p, err := parser.NewFromConfig(cfg)
+	if err != nil {
+		cf.logger.Warn("Markdown 渲染器配置无效，使用 blackfriday:", err)
+	}
 
 	// 创建增强解析器
 	consoleObserver := parser.NewConsoleObserver(true)
-	cf.enhancedParser = parser.NewEnhancedParser().
+	enhancedParser := parser.NewEnhancedParser().
 		WithLogging(consoleObserver).
 		WithCaching().
 		WithValidation()
 
 	// 创建生成器
-	cf.generator = generator.New(cf.config)
+	gen := generator.New(cfg)
 
 	// 初始化部署管理器
-	if cf.config.Deploy != nil && cf.config.Deploy.Enabled {
-		if err := cf.initializeDeployManager(); err != nil {
+	var deployManager *deploy.DeployManager
+	if cfg.Deploy != nil && cfg.Deploy.Enabled {
+		if deployManager, err = cf.initializeDeployManager(cfg); err != nil {
 			cf.logger.Warn("部署管理器初始化失败:", err)
+			deployManager = nil
 		}
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cf.mu.Lock()
+	cancelPrevious := cf.cancelReloaded
+	cf.parser = p
+	cf.enhancedParser = enhancedParser
+	cf.generator = gen
+	cf.deployManager = deployManager
+	cf.reloaded, cf.cancelReloaded = ctx, cancel
+	cf.applyGeneratorOptions(gen)
+	serving := cf.serving
+	cf.mu.Unlock()
+
+	if cancelPrevious != nil {
+		cancelPrevious()
+	}
+	if serving {
+		cf.switchSiteHandler(gen, ctx)
+	}
+
 	// 设置资源管理器
-	cf.resourceManager.Set("config", cf.config)
-	cf.resourceManager.Set("parser", cf.parser)
-	cf.resourceManager.Set("generator", cf.generator)
-	if cf.deployManager != nil {
-		cf.resourceManager.Set("deployManager", cf.deployManager)
+	cf.resourceManager.Set("config", cfg)
+	cf.resourceManager.Set("parser", p)
+	cf.resourceManager.Set("generator", gen)
+	if deployManager != nil {
+		cf.resourceManager.Set("deployManager", deployManager)
 	}
 }
 
+// current 获取当前配置与生成器，重新加载配置后返回新的组件
+func (cf *CreeperFacade) current() (*config.Config, *generator.Generator) {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+	return cf.config, cf.generator
+}
+
+// currentDeployManager 获取当前部署管理器，未启用部署时为 nil
+func (cf *CreeperFacade) currentDeployManager() *deploy.DeployManager {
+	cf.mu.RLock()
+	defer cf.mu.RUnlock()
+	return cf.deployManager
+}
+
 // GenerateWebsite 生成网站（主要功能入口）
 func (cf *CreeperFacade) GenerateWebsite() error {
 	startTime := time.Now()
 
+	cfg, gen := cf.current()
+
 	cf.logger.Info("开始生成静态网站")
-	cf.logger.Info("输入目录:", cf.config.InputDir)
-	cf.logger.Info("输出目录:", cf.config.OutputDir)
+	cf.logger.Info("输入目录:", cfg.InputDir)
+	cf.logger.Info("输出目录:", cfg.OutputDir)
 
 	// 执行生成
-	if err := cf.generator.Generate(); err != nil {
+	if err := gen.Generate(); err != nil {
 		cf.logger.Error("网站生成失败:", err)
 		return fmt.Errorf("网站生成失败: %w", err)
 	}
@@ -121,11 +189,45 @@ func (cf *CreeperFacade) GenerateWebsite() error {
 	return nil
 }
 
+// GenerateCovers 仅重新生成小说封面
+func (cf *CreeperFacade) GenerateCovers() error {
+	cf.logger.Info("开始生成小说封面")
+
+	_, gen := cf.current()
+	if err := gen.GenerateCoversOnly(); err != nil {
+		cf.logger.Error("封面生成失败:", err)
+		return fmt.Errorf("封面生成失败: %w", err)
+	}
+
+	return nil
+}
+
+// CheckLinks 检查已生成站点中的站内链接
+func (cf *CreeperFacade) CheckLinks() ([]generator.BrokenLink, error) {
+	cfg, _ := cf.current()
+	cf.logger.Info("开始检查站内链接:", cfg.OutputDir)
+
+	broken, err := generator.CheckLinks(cfg.OutputDir, cfg.Site.BaseURL)
+	if err != nil {
+		cf.logger.Error("链接检查失败:", err)
+		return nil, fmt.Errorf("链接检查失败: %w", err)
+	}
+
+	return broken, nil
+}
+
 // ServeWebsite 启动服务器
+// 每个请求交给当前生成器的处理器，重新加载配置后新的输出目录、接口数据与热重载监听立即生效
 func (cf *CreeperFacade) ServeWebsite(port int) error {
 	cf.logger.Info("启动本地服务器，端口:", port)
 
-	if err := cf.generator.Serve(port); err != nil {
+	cf.mu.Lock()
+	cf.serving = true
+	gen, ctx := cf.generator, cf.reloaded
+	cf.mu.Unlock()
+	cf.switchSiteHandler(gen, ctx)
+
+	if err := http.ListenAndServe(fmt.Sprintf(":%d", port), http.HandlerFunc(cf.serveSite)); err != nil {
 		cf.logger.Error("服务器启动失败:", err)
 		return fmt.Errorf("服务器启动失败: %w", err)
 	}
@@ -133,12 +235,66 @@ func (cf *CreeperFacade) ServeWebsite(port int) error {
 	return nil
 }
 
+// switchSiteHandler 创建生成器的处理器，生成器仍是当前生成器时切换到该处理器
+func (cf *CreeperFacade) switchSiteHandler(gen *generator.Generator, ctx context.Context) {
+	handler := gen.Handler(ctx)
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	if cf.generator == gen {
+		cf.siteHandler = handler
+	}
+}
+
+// serveSite 使用当前生成器的处理器响应请求
+func (cf *CreeperFacade) serveSite(w http.ResponseWriter, r *http.Request) {
+	cf.mu.RLock()
+	handler := cf.siteHandler
+	cf.mu.RUnlock()
+
+	if handler == nil {
+		http.Error(w, "站点正在重新加载", http.StatusServiceUnavailable)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// WatchWebsite 监听输入目录与模板目录，变化时自动重建，直到 ctx 结束
+// 重新加载配置后改为监听新生成器的目录
+func (cf *CreeperFacade) WatchWebsite(ctx context.Context, options generator.WatchOptions) error {
+	for {
+		cf.mu.RLock()
+		gen, reloaded := cf.generator, cf.reloaded
+		cf.mu.RUnlock()
+
+		watchCtx, cancel := context.WithCancel(ctx)
+		stop := context.AfterFunc(reloaded, cancel)
+		err := gen.Watch(watchCtx, options)
+		stop()
+		cancel()
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		if reloaded.Err() == nil {
+			if err != nil {
+				cf.logger.Error("文件监听失败:", err)
+				return fmt.Errorf("文件监听失败: %w", err)
+			}
+			return nil
+		}
+	}
+}
+
 // ParseNovel 解析单个小说
 func (cf *CreeperFacade) ParseNovel(novelPath string) (*parser.Novel, error) {
 	cf.logger.Info("解析小说:", novelPath)
 
 	// 使用增强解析器
-	decorator := cf.enhancedParser.Build()
+	cf.mu.RLock()
+	enhancedParser := cf.enhancedParser
+	cf.mu.RUnlock()
+	decorator := enhancedParser.Build()
 	novel, err := decorator.ParseNovel(novelPath)
 
 	if err != nil {
@@ -151,6 +307,108 @@ func (cf *CreeperFacade) ParseNovel(novelPath string) (*parser.Novel, error) {
 	return novel, nil
 }
 
+// ParseNovelWithStats 解析单个小说并返回解析统计
+// 非 TXT 单文件小说没有统计信息，返回的 stats 为 nil
+func (cf *CreeperFacade) ParseNovelWithStats(novelPath string) (*parser.Novel, *parser.TxtParseStats, error) {
+	novel, err := cf.ParseNovel(novelPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats, _ := novel.ParseStats.(*parser.TxtParseStats)
+	return novel, stats, nil
+}
+
+// SetVerbose 设置是否输出详细信息（如每部小说的解析统计）
+func (cf *CreeperFacade) SetVerbose(verbose bool) {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	cf.verbose = verbose
+	cf.generator.SetVerbose(verbose)
+}
+
+// SetWorkers 设置并行解析与渲染的并发数，不大于 0 时使用配置或 CPU 核数
+func (cf *CreeperFacade) SetWorkers(workers int) {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	cf.workers = workers
+	cf.generator.SetWorkers(workers)
+}
+
+// SetExportEPUB 设置生成站点时是否同时导出每部小说的 EPUB
+func (cf *CreeperFacade) SetExportEPUB(export bool) {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	cf.exportEPUB = export
+	cf.generator.SetExportEPUB(export)
+}
+
+// SetLiveReload 设置本地服务器是否启用热重载
+func (cf *CreeperFacade) SetLiveReload(liveReload bool) {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	cf.liveReload = liveReload
+	cf.generator.SetLiveReload(liveReload)
+}
+
+// WatchConfig 监听配置文件，每次重新加载成功后调用 onChange，直到 ctx 结束
+// 加载失败（如 YAML 语法错误）时只记录警告，继续使用当前配置
+func (cf *CreeperFacade) WatchConfig(ctx context.Context, onChange func(*config.Config)) error {
+	watcher := config.NewConfigWatcher(cf.configPath)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+
+	cf.logger.Info("监听配置文件:", cf.configPath)
+
+	changes, errs := watcher.Changes(), watcher.Errors()
+	for changes != nil {
+		select {
+		case cfg, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			cf.logger.Info("配置文件已更新:", cf.configPath)
+			cf.configCache.Set(cf.configPath, cfg)
+			onChange(cfg)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			cf.logger.Warn(err)
+		}
+	}
+
+	if err := <-done; err != nil {
+		cf.logger.Error("配置文件监听失败:", err)
+		return fmt.Errorf("配置文件监听失败: %w", err)
+	}
+
+	return nil
+}
+
+// ReloadConfig 使用新配置重新初始化解析器、生成器与部署管理器，并保留命令行设置的生成选项
+func (cf *CreeperFacade) ReloadConfig(cfg *config.Config) {
+	cf.mu.Lock()
+	cf.config = cfg
+	cf.mu.Unlock()
+	cf.initializeComponents()
+
+	cf.logger.Info("已应用新配置")
+}
+
+// applyGeneratorOptions 将命令行设置的生成选项应用到重新创建的生成器，调用方需持有 cf.mu
+func (cf *CreeperFacade) applyGeneratorOptions(gen *generator.Generator) {
+	gen.SetVerbose(cf.verbose)
+	gen.SetWorkers(cf.workers)
+	gen.SetExportEPUB(cf.exportEPUB)
+	gen.SetLiveReload(cf.liveReload)
+}
+
 // GetNovelList 获取小说列表
 func (cf *CreeperFacade) GetNovelList() ([]*parser.Novel, error) {
 	cf.logger.Info("获取小说列表")
@@ -198,15 +456,19 @@ func (cf *CreeperFacade) UpdateConfig(updates map[string]interface{}) error {
 	}
 
 	// 应用更新
-	updatedConfig := builder.Build()
+	updatedConfig, err := builder.Build()
+	if err != nil {
+		return fmt.Errorf("更新配置失败: %w", err)
+	}
+	cf.mu.Lock()
 	cf.config = updatedConfig
+	cf.mu.Unlock()
 
 	// 更新缓存
 	cf.configCache.Set("current", updatedConfig)
 
 	// 重新初始化生成器
-	cf.generator = generator.New(cf.config)
-	cf.resourceManager.Set("generator", cf.generator)
+	cf.initializeComponents()
 
 	cf.logger.Info("配置更新完成")
 
@@ -215,11 +477,12 @@ func (cf *CreeperFacade) UpdateConfig(updates map[string]interface{}) error {
 
 // GetSystemStatus 获取系统状态
 func (cf *CreeperFacade) GetSystemStatus() map[string]interface{} {
+	cfg, _ := cf.current()
 	status := map[string]interface{}{
 		"config": map[string]interface{}{
-			"input_dir":  cf.config.InputDir,
-			"output_dir": cf.config.OutputDir,
-			"site_title": cf.config.Site.Title,
+			"input_dir":  cfg.InputDir,
+			"output_dir": cfg.OutputDir,
+			"site_title": cfg.Site.Title,
 		},
 		"resources": map[string]interface{}{
 			"count": cf.resourceManager.Count(),
@@ -237,19 +500,20 @@ func (cf *CreeperFacade) GetSystemStatus() map[string]interface{} {
 // ValidateSetup 验证系统设置
 func (cf *CreeperFacade) ValidateSetup() error {
 	cf.logger.Info("验证系统设置")
+	cfg, _ := cf.current()
 
 	// 验证输入目录
-	if cf.config.InputDir == "" {
+	if cfg.InputDir == "" {
 		return fmt.Errorf("输入目录未设置")
 	}
 
 	// 验证输出目录
-	if cf.config.OutputDir == "" {
+	if cfg.OutputDir == "" {
 		return fmt.Errorf("输出目录未设置")
 	}
 
 	// 验证主题配置
-	if cf.config.Theme.PrimaryColor == "" {
+	if cfg.Theme.PrimaryColor == "" {
 		return fmt.Errorf("主题主色调未设置")
 	}
 
@@ -263,8 +527,10 @@ func (cf *CreeperFacade) GetSupportedFormats() []string {
 	return []string{
 		"Markdown (.md)",
 		"Text (.txt)",
+		"Word (.docx)",
 		"Markdown Directory",
 		"Text Directory",
+		"Text Chapter Directories",
 		"Multi-Volume Structure",
 	}
 }
@@ -282,7 +548,7 @@ func (cf *CreeperFacade) CleanCache() {
 // GetVersion 获取版本信息
 func (cf *CreeperFacade) GetVersion() map[string]string {
 	return map[string]string{
-		"version":     "1.0.0",
+		"version":     generator.Version,
 		"build_date":  "2024-01-01",
 		"go_version":  "1.21+",
 		"description": "Creeper 静态小说站点生成器",
@@ -301,48 +567,60 @@ func (cf *CreeperFacade) Shutdown() error {
 		cf.logger.Warn("保存系统状态失败:", err)
 	}
 
+	// 关闭登记的资源
+	if err := cf.resourceManager.Shutdown(); err != nil {
+		cf.logger.Error("关闭资源失败:", err)
+		return fmt.Errorf("关闭资源失败: %w", err)
+	}
+
 	cf.logger.Info("系统关闭完成")
 
 	return nil
 }
 
-// initializeDeployManager 初始化部署管理器
-func (cf *CreeperFacade) initializeDeployManager() error {
+// initializeDeployManager 根据配置创建并初始化部署管理器
+func (cf *CreeperFacade) initializeDeployManager(cfg *config.Config) (*deploy.DeployManager, error) {
 	cf.logger.Info("初始化部署管理器")
 
 	// 加载部署配置
-	deployConfig, err := deploy.LoadDeployConfig(cf.config.Deploy.Config)
+	deployConfig, err := deploy.LoadDeployConfig(cfg.Deploy.Config)
 	if err != nil {
-		return fmt.Errorf("加载部署配置失败: %w", err)
+		return nil, fmt.Errorf("加载部署配置失败: %w", err)
+	}
+	// config.yaml 中的 deploy.type 优先于部署配置文件中的 type
+	if cfg.Deploy.Type != "" {
+		deployConfig.Type = deploy.DeployType(cfg.Deploy.Type)
 	}
 
 	// 创建部署管理器
-	cf.deployManager = deploy.NewDeployManager(deployConfig)
+	deployManager := deploy.NewDeployManager(deployConfig)
 
 	// 初始化部署管理器
-	if err := cf.deployManager.Initialize(); err != nil {
-		return fmt.Errorf("初始化部署管理器失败: %w", err)
+	if err := deployManager.Initialize(); err != nil {
+		return nil, fmt.Errorf("初始化部署管理器失败: %w", err)
 	}
 
 	cf.logger.Info("部署管理器初始化完成")
-	return nil
+	return deployManager, nil
 }
 
 // DeployWebsite 部署网站
 func (cf *CreeperFacade) DeployWebsite() error {
-	if cf.deployManager == nil {
+	deployManager := cf.currentDeployManager()
+	if deployManager == nil {
 		return fmt.Errorf("部署管理器未初始化，请检查部署配置")
 	}
+	cfg, _ := cf.current()
 
 	cf.logger.Info("开始部署网站")
 
 	// 执行部署
-	if err := cf.deployManager.Deploy(cf.config.OutputDir); err != nil {
+	if err := deployManager.Deploy(cfg.OutputDir); err != nil {
 		cf.logger.Error("网站部署失败:", err)
 		return fmt.Errorf("网站部署失败: %w", err)
 	}
 
-	deploymentURL := cf.deployManager.GetDeploymentURL()
+	deploymentURL := deployManager.GetDeploymentURL()
 	cf.logger.Info("网站部署完成，访问地址:", deploymentURL)
 
 	return nil
@@ -350,19 +628,21 @@ func (cf *CreeperFacade) DeployWebsite() error {
 
 // GetDeploymentStatus 获取部署状态
 func (cf *CreeperFacade) GetDeploymentStatus() (map[string]interface{}, error) {
-	if cf.deployManager == nil {
+	deployManager := cf.currentDeployManager()
+	if deployManager == nil {
 		return nil, fmt.Errorf("部署管理器未初始化")
 	}
 
-	return cf.deployManager.GetStatus()
+	return deployManager.GetStatus()
 }
 
 // GetDeploymentURL 获取部署 URL
 func (cf *CreeperFacade) GetDeploymentURL() string {
-	if cf.deployManager == nil {
+	deployManager := cf.currentDeployManager()
+	if deployManager == nil {
 		return ""
 	}
-	return cf.deployManager.GetDeploymentURL()
+	return deployManager.GetDeploymentURL()
 }
 
 // saveSystemState 保存系统状态
@@ -0,0 +1,108 @@
+package facade
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"creeper/internal/config"
+)
+
+// writeNovels 在 dir 中写入 count 部 Markdown 小说
+func writeNovels(t *testing.T, dir string, count int) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= count; i++ {
+		content := fmt.Sprintf("---\ntitle: 小说%d\n---\n# 第1章 开始\n正文\n", i)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("novel-%d.md", i)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// novelCount 请求 /api/novels 并返回小说数量
+func novelCount(t *testing.T, url string) int {
+	t.Helper()
+	resp, err := http.Get(url + "/api/novels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var novels []map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&novels); err != nil {
+		t.Fatal(err)
+	}
+	return len(novels)
+}
+
+func TestReloadConfigSwitchesServedGenerator(t *testing.T) {
+	root := t.TempDir()
+	first := config.Default()
+	first.InputDir = filepath.Join(root, "first")
+	first.OutputDir = filepath.Join(root, "first-dist")
+	first.Build.SkipCovers = true
+	writeNovels(t, first.InputDir, 1)
+
+	configPath := filepath.Join(root, "config.yaml")
+	if err := first.Save(configPath); err != nil {
+		t.Fatal(err)
+	}
+
+	cf, err := NewCreeperFacade(configPath)
+	if err != nil {
+		t.Fatalf("NewCreeperFacade() error = %v", err)
+	}
+
+	// 与 ServeWebsite 相同的处理器切换过程，不监听端口
+	cf.mu.Lock()
+	cf.serving = true
+	gen, ctx := cf.generator, cf.reloaded
+	cf.mu.Unlock()
+	cf.switchSiteHandler(gen, ctx)
+
+	server := httptest.NewServer(http.HandlerFunc(cf.serveSite))
+	defer server.Close()
+
+	if got := novelCount(t, server.URL); got != 1 {
+		t.Fatalf("重新加载前 /api/novels 返回 %d 部小说, want 1", got)
+	}
+
+	second := config.Default()
+	second.InputDir = filepath.Join(root, "second")
+	second.OutputDir = filepath.Join(root, "second-dist")
+	second.Build.SkipCovers = true
+	writeNovels(t, second.InputDir, 3)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// 重新加载期间的请求由旧处理器或新处理器响应
+		for i := 0; i < 20; i++ {
+			resp, err := http.Get(server.URL + "/api/novels")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}
+	}()
+	cf.ReloadConfig(second)
+	<-done
+
+	if got := novelCount(t, server.URL); got != 3 {
+		t.Errorf("重新加载后 /api/novels 返回 %d 部小说, want 3", got)
+	}
+	if ctx.Err() == nil {
+		t.Error("重新加载后旧生成器的生命周期未结束")
+	}
+	if cfg, _ := cf.current(); cfg != second {
+		t.Error("current() 未返回新配置")
+	}
+}
@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"creeper/internal/parser"
+)
+
+// apiNovel 小说接口数据
+type apiNovel struct {
+	Title        string   `json:"title"`
+	Author       string   `json:"author"`
+	Description  string   `json:"description"`
+	Category     string   `json:"category"`
+	Tags         []string `json:"tags"`
+	ChapterCount int      `json:"chapter_count"`
+	TotalWords   int      `json:"total_words"`
+	CoverURL     string   `json:"cover_url"`
+	URL          string   `json:"url"`
+}
+
+// apiChapter 章节接口数据
+type apiChapter struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	WordCount int    `json:"word_count"`
+	URL       string `json:"url"`
+}
+
+// registerAPIHandlers 注册开发服务器的 JSON 接口
+func (g *Generator) registerAPIHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/api/novels", g.handleNovelList)
+	mux.HandleFunc("/api/novels/", g.handleNovelDetail)
+	mux.HandleFunc("/health", g.handleHealth)
+	mux.HandleFunc("/ready", g.handleReady)
+}
+
+// handleNovelList 处理 GET /api/novels
+func (g *Generator) handleNovelList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	snapshot := g.novelSnapshot()
+	novels := make([]apiNovel, 0, len(snapshot))
+	for _, novel := range snapshot {
+		novels = append(novels, g.toAPINovel(novel))
+	}
+
+	g.writeJSON(w, novels)
+}
+
+// handleNovelDetail 处理 GET /api/novels/<title> 与 GET /api/novels/<title>/chapters
+func (g *Generator) handleNovelDetail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/novels/"), "/")
+	title, resource, _ := strings.Cut(path, "/")
+
+	novel := g.findNovel(title)
+	if novel == nil {
+		http.Error(w, fmt.Sprintf("小说不存在: %s", title), http.StatusNotFound)
+		return
+	}
+
+	switch resource {
+	case "":
+		g.writeJSON(w, g.toAPINovel(novel))
+	case "chapters":
+		chapters := make([]apiChapter, 0, len(novel.Chapters))
+		for _, chapter := range novel.Chapters {
+			chapters = append(chapters, apiChapter{
+				ID:        chapter.ID,
+				Title:     chapter.Title,
+				WordCount: chapter.WordCount,
+				URL:       fmt.Sprintf("%snovels/%s/chapter-%d.html", g.config.Site.BaseURL, g.sanitizeFileName(novel.Title), chapter.ID),
+			})
+		}
+		g.writeJSON(w, chapters)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// findNovel 按清理后的标题查找小说
+func (g *Generator) findNovel(title string) *parser.Novel {
+	for _, novel := range g.novelSnapshot() {
+		if g.sanitizeFileName(novel.Title) == title || novel.Title == title {
+			return novel
+		}
+	}
+	return nil
+}
+
+// toAPINovel 转换为接口数据
+func (g *Generator) toAPINovel(novel *parser.Novel) apiNovel {
+	totalWords := 0
+	for _, chapter := range novel.Chapters {
+		totalWords += chapter.WordCount
+	}
+
+	novelURL := g.novelURL(novel)
+
+	return apiNovel{
+		Title:        novel.Title,
+		Author:       novel.Author,
+		Description:  novel.Description,
+		Category:     novel.Category,
+		Tags:         novel.Tags,
+		ChapterCount: len(novel.Chapters),
+		TotalWords:   totalWords,
+		CoverURL:     novelURL + "cover.svg",
+		URL:          novelURL,
+	}
+}
+
+// writeJSON 输出 JSON 响应
+func (g *Generator) writeJSON(w http.ResponseWriter, data interface{}) {
+	g.writeJSONStatus(w, http.StatusOK, data)
+}
+
+// writeJSONStatus 以指定状态码输出 JSON 响应
+func (g *Generator) writeJSONStatus(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"creeper/internal/config"
+)
+
+// newTestGenerator 创建以临时目录为输入输出的生成器，novels 为文件名到内容的映射
+func newTestGenerator(t *testing.T, novels map[string]string) *Generator {
+	t.Helper()
+	root := t.TempDir()
+	inputDir := filepath.Join(root, "novels")
+	if err := os.MkdirAll(inputDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range novels {
+		if err := os.WriteFile(filepath.Join(inputDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := config.Default()
+	cfg.InputDir = inputDir
+	cfg.OutputDir = filepath.Join(root, "dist")
+	cfg.Build.SkipCovers = true
+
+	g := New(cfg)
+	g.SetWorkers(2)
+	return g
+}
+
+// markdownNovel 生成包含 chapters 个章节的 Markdown 小说
+func markdownNovel(title string, chapters int) string {
+	content := fmt.Sprintf("---\ntitle: %s\nauthor: 作者\n---\n", title)
+	for i := 1; i <= chapters; i++ {
+		content += fmt.Sprintf("# 第%d章 标题%d\n第 %d 章的正文。\n\n", i, i, i)
+	}
+	return content
+}
+
+func TestNovelListDuringRebuild(t *testing.T) {
+	novels := make(map[string]string)
+	for i := 1; i <= 5; i++ {
+		novels[fmt.Sprintf("novel-%d.md", i)] = markdownNovel(fmt.Sprintf("小说%d", i), 3)
+	}
+	g := newTestGenerator(t, novels)
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	mux := http.NewServeMux()
+	g.registerAPIHandlers(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			if err := g.Generate(); err != nil {
+				t.Errorf("Generate() error = %v", err)
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				for _, path := range []string{"/api/novels", "/api/novels/小说1/chapters"} {
+					resp, err := http.Get(server.URL + path)
+					if err != nil {
+						t.Errorf("GET %s error = %v", path, err)
+						return
+					}
+					var body []map[string]interface{}
+					err = json.NewDecoder(resp.Body).Decode(&body)
+					resp.Body.Close()
+					if err != nil {
+						t.Errorf("decode %s error = %v", path, err)
+						return
+					}
+					// 重建期间列表整体替换，不应读到部分结果
+					if path == "/api/novels" && len(body) != 5 {
+						t.Errorf("GET %s returned %d novels, want 5", path, len(body))
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -2,8 +2,11 @@ package generator
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 // generateAssets 生成静态资源
@@ -27,12 +30,71 @@ func (g *Generator) generateAssets() error {
 		return fmt.Errorf("生成增强JavaScript失败: %v", err)
 	}
 
+	if err := g.generateColorSchemeJS(); err != nil {
+		return fmt.Errorf("生成配色方案脚本失败: %v", err)
+	}
+
+	if err := g.generateSkeletonCSS(); err != nil {
+		return fmt.Errorf("生成骨架屏CSS失败: %v", err)
+	}
+
+	if err := g.generateServiceWorker(); err != nil {
+		return fmt.Errorf("生成 Service Worker 失败: %v", err)
+	}
+
+	if err := g.generateDefaultCover(); err != nil {
+		return fmt.Errorf("生成默认封面失败: %v", err)
+	}
+
 	return nil
 }
 
+// spacingScale 基础间距刻度（rem）
+var spacingScale = []struct {
+	name  string
+	value float64
+}{
+	{"xs", 0.5},
+	{"sm", 1},
+	{"md", 1.5},
+	{"lg", 2},
+	{"xl", 3},
+}
+
+// spacingVariables 生成按 Theme.SpaceScale 缩放后的间距变量
+func (g *Generator) spacingVariables() string {
+	scale := g.config.Theme.SpaceScale
+	if scale <= 0 {
+		scale = 1.0
+	}
+
+	var sb strings.Builder
+	for _, space := range spacingScale {
+		value := math.Round(space.value*scale*1000) / 1000
+		sb.WriteString(fmt.Sprintf("    --space-%s: %srem;\n", space.name, strconv.FormatFloat(value, 'f', -1, 64)))
+	}
+	return sb.String()
+}
+
+// cssLayerOrder 样式表共用的级联层顺序声明
+// 每个样式表都以此开头，保证单独引入时层的顺序一致
+const cssLayerOrder = "@layer base, theme, components, utilities;"
+
 // generateCSS 生成CSS文件
 func (g *Generator) generateCSS() error {
-	css := fmt.Sprintf(`/* Creeper 小说站点样式 */
+	css := fmt.Sprintf(`/* Creeper 小说站点样式
+ *
+ * 使用 CSS 级联层管理优先级：base < theme < components < utilities，
+ * 后声明的层优先，与选择器权重无关。覆盖单部小说的主题时，
+ * 在 @layer theme { :root { ... } } 中重新定义变量即可，无需 !important。
+ *
+ * 最低浏览器要求（@layer 支持）：Chrome/Edge 99、Firefox 97、Safari 15.4，
+ * 更早的浏览器会忽略层内的全部样式。
+ */
+%s
+
+/* 主题层：颜色、字体、间距等 CSS 变量 */
+@layer theme {
 :root {
     --primary-color: %s;
     --secondary-color: %s;
@@ -44,8 +106,11 @@ func (g *Generator) generateCSS() error {
     --border-color: #e1e5e9;
     --shadow: 0 2px 4px rgba(0,0,0,0.1);
     --shadow-hover: 0 4px 8px rgba(0,0,0,0.15);
+%s}
 }
 
+/* 基础层：重置浏览器默认样式 */
+@layer base {
 * {
     margin: 0;
     padding: 0;
@@ -59,7 +124,10 @@ body {
     color: var(--text-color);
     background-color: var(--background-color);
 }
+}
 
+/* 组件层：页面与组件样式 */
+@layer components {
 .container {
     max-width: 1200px;
     margin: 0 auto;
@@ -70,7 +138,7 @@ body {
 .header {
     background: var(--primary-color);
     color: white;
-    padding: 1rem 0;
+    padding: var(--space-sm) 0;
     box-shadow: var(--shadow);
 }
 
@@ -93,7 +161,7 @@ body {
 .nav {
     display: flex;
     align-items: center;
-    gap: 2rem;
+    gap: var(--space-lg);
 }
 
 .nav-link {
@@ -112,7 +180,7 @@ body {
 }
 
 #search-input {
-    padding: 0.5rem 1rem;
+    padding: var(--space-xs) var(--space-sm);
     border: none;
     border-radius: 20px;
     width: 250px;
@@ -121,7 +189,7 @@ body {
 
 .search-results {
     position: absolute;
-    top: 100%;
+    top: 100%%;
     left: 0;
     right: 0;
     background: white;
@@ -159,31 +227,90 @@ body {
     margin-top: 0.25rem;
 }
 
+/* 更新日志样式 */
+.changelog-list {
+    background: white;
+    border: 1px solid var(--border-color);
+    border-radius: 8px;
+}
+
+.changelog-item {
+    display: flex;
+    align-items: center;
+    gap: var(--space-sm);
+    padding: 0.75rem var(--space-sm);
+    border-bottom: 1px solid var(--border-color);
+}
+
+.changelog-item:last-child {
+    border-bottom: none;
+}
+
+.changelog-date {
+    font-size: 0.85rem;
+    color: #666;
+}
+
+.new-badge {
+    padding: 0.1rem var(--space-xs);
+    border-radius: 10px;
+    background: #e74c3c;
+    color: white;
+    font-size: 0.75rem;
+    font-weight: bold;
+}
+
+/* 搜索页样式 */
+.search-form {
+    display: flex;
+    gap: var(--space-xs);
+    margin-top: var(--space-sm);
+}
+
+.search-form input {
+    flex: 1;
+    padding: var(--space-xs) var(--space-sm);
+    border: 1px solid var(--border-color);
+    border-radius: 20px;
+    font-size: 0.9rem;
+}
+
+.search-page-results {
+    list-style: none;
+    background: white;
+    border: 1px solid var(--border-color);
+    border-radius: 8px;
+}
+
+.search-page-results .search-result-item {
+    cursor: default;
+}
+
 /* 主要内容区域 */
 .main {
     min-height: calc(100vh - 200px);
-    padding: 2rem 0;
+    padding: var(--space-lg) 0;
 }
 
 /* 首页样式 */
 .hero {
     text-align: center;
-    padding: 3rem 0;
+    padding: var(--space-xl) 0;
     background: linear-gradient(135deg, var(--primary-color), var(--secondary-color));
     color: white;
     border-radius: 8px;
-    margin-bottom: 3rem;
+    margin-bottom: var(--space-xl);
 }
 
 .hero h2 {
     font-size: 2rem;
-    margin-bottom: 1rem;
+    margin-bottom: var(--space-sm);
 }
 
 .novels-grid {
     display: grid;
     grid-template-columns: repeat(auto-fill, minmax(300px, 1fr));
-    gap: 2rem;
+    gap: var(--space-lg);
 }
 
 .novel-card {
@@ -201,18 +328,18 @@ body {
 }
 
 .novel-cover img {
-    width: 100%;
+    width: 100%%;
     height: 200px;
     object-fit: cover;
 }
 
 .novel-info {
-    padding: 1.5rem;
+    padding: var(--space-md);
 }
 
 .novel-title {
     font-size: 1.25rem;
-    margin-bottom: 0.5rem;
+    margin-bottom: var(--space-xs);
 }
 
 .novel-title a {
@@ -227,40 +354,144 @@ body {
 .novel-author {
     color: #666;
     font-size: 0.9rem;
-    margin-bottom: 0.5rem;
+    margin-bottom: var(--space-xs);
 }
 
 .novel-description {
     color: #555;
     font-size: 0.9rem;
     line-height: 1.5;
-    margin-bottom: 1rem;
-    display: -webkit-box;
-    -webkit-line-clamp: 3;
-    -webkit-box-orient: vertical;
-    overflow: hidden;
+    margin-bottom: var(--space-sm);
 }
 
 .novel-stats {
     display: flex;
-    gap: 1rem;
+    gap: var(--space-sm);
     font-size: 0.85rem;
     color: #666;
 }
 
+.novel-genres {
+    display: flex;
+    flex-wrap: wrap;
+    gap: 0.25rem;
+    margin-top: var(--space-xs);
+}
+
+.genre-badge {
+    display: inline-block;
+    padding: 0.1rem var(--space-xs);
+    border: 1px solid var(--secondary-color);
+    border-radius: 10px;
+    font-size: 0.75rem;
+    color: var(--secondary-color);
+    text-decoration: none;
+}
+
+.genre-badge:hover {
+    background: var(--secondary-color);
+    color: white;
+}
+
+.novel-tags,
+.tag-cloud {
+    display: flex;
+    flex-wrap: wrap;
+    gap: 0.25rem;
+    margin-top: var(--space-xs);
+}
+
+.tag-cloud {
+    gap: var(--space-sm);
+}
+
+.tag-badge {
+    display: inline-block;
+    padding: 0.1rem var(--space-xs);
+    border-radius: 10px;
+    background: #f0f0f0;
+    font-size: 0.75rem;
+    color: #666;
+    text-decoration: none;
+}
+
+.tag-badge:hover {
+    background: var(--primary-color);
+    color: white;
+}
+
+.tag-count {
+    margin-left: 0.25rem;
+    opacity: 0.7;
+}
+
+.difficulty-badge {
+    display: inline-block;
+    padding: 0.1rem var(--space-xs);
+    border-radius: 10px;
+    font-size: 0.75rem;
+    color: white;
+}
+
+.difficulty-beginner {
+    background: #27ae60;
+}
+
+.difficulty-intermediate {
+    background: #f39c12;
+}
+
+.difficulty-advanced {
+    background: #c0392b;
+}
+
+.completion-estimate {
+    color: var(--secondary-color);
+}
+
+.confidence-badge {
+    display: inline-block;
+    margin-left: var(--space-xs);
+    padding: 0.1rem var(--space-xs);
+    border-radius: 10px;
+    font-size: 0.75rem;
+    color: white;
+}
+
+.confidence-high {
+    background: #27ae60;
+}
+
+.confidence-medium {
+    background: #f39c12;
+}
+
+.confidence-low {
+    background: #95a5a6;
+}
+
+.novel-word-bar {
+    margin-top: var(--space-xs);
+}
+
+.word-count-bar {
+    display: block;
+    max-width: 100%%;
+}
+
 /* 小说详情页样式 */
 .novel-header {
     background: white;
     border: 1px solid var(--border-color);
     border-radius: 8px;
-    padding: 2rem;
-    margin-bottom: 2rem;
+    padding: var(--space-lg);
+    margin-bottom: var(--space-lg);
     box-shadow: var(--shadow);
 }
 
 .novel-meta {
     display: flex;
-    gap: 2rem;
+    gap: var(--space-lg);
     align-items: flex-start;
 }
 
@@ -277,30 +508,30 @@ body {
 
 .novel-details .novel-title {
     font-size: 2rem;
-    margin-bottom: 1rem;
+    margin-bottom: var(--space-sm);
     color: var(--primary-color);
 }
 
 .novel-details .novel-author {
     font-size: 1rem;
-    margin-bottom: 1rem;
+    margin-bottom: var(--space-sm);
 }
 
 .novel-details .novel-description {
     font-size: 1rem;
     line-height: 1.6;
-    margin-bottom: 1.5rem;
+    margin-bottom: var(--space-md);
     color: #555;
 }
 
 .novel-details .novel-stats {
     font-size: 0.9rem;
-    margin-bottom: 2rem;
+    margin-bottom: var(--space-lg);
 }
 
 .novel-actions {
     display: flex;
-    gap: 1rem;
+    gap: var(--space-sm);
 }
 
 /* 章节列表样式 */
@@ -308,19 +539,19 @@ body {
     background: white;
     border: 1px solid var(--border-color);
     border-radius: 8px;
-    padding: 2rem;
+    padding: var(--space-lg);
     box-shadow: var(--shadow);
 }
 
 .chapters-list h2 {
-    margin-bottom: 1.5rem;
+    margin-bottom: var(--space-md);
     color: var(--primary-color);
 }
 
 .chapters-grid {
     display: grid;
     grid-template-columns: repeat(auto-fill, minmax(250px, 1fr));
-    gap: 0.5rem;
+    gap: var(--space-xs);
 }
 
 .chapter-item {
@@ -338,7 +569,7 @@ body {
     display: flex;
     justify-content: space-between;
     align-items: center;
-    padding: 0.75rem 1rem;
+    padding: 0.75rem var(--space-sm);
     text-decoration: none;
     color: var(--text-color);
 }
@@ -353,18 +584,22 @@ body {
     color: #666;
 }
 
+.chapters-list + .chapters-list {
+    margin-top: var(--space-lg);
+}
+
 /* 章节阅读页样式 */
 .chapter-header {
     background: white;
     border: 1px solid var(--border-color);
     border-radius: 8px;
-    padding: 2rem;
-    margin-bottom: 2rem;
+    padding: var(--space-lg);
+    margin-bottom: var(--space-lg);
     box-shadow: var(--shadow);
 }
 
 .breadcrumb {
-    margin-bottom: 1rem;
+    margin-bottom: var(--space-sm);
     font-size: 0.9rem;
     color: #666;
 }
@@ -379,27 +614,40 @@ body {
 }
 
 .separator {
-    margin: 0 0.5rem;
+    margin: 0 var(--space-xs);
 }
 
 .chapter-title {
     font-size: 1.8rem;
-    margin-bottom: 1.5rem;
+    margin-bottom: var(--space-md);
     color: var(--primary-color);
 }
 
 .chapter-nav {
     display: flex;
-    gap: 1rem;
+    gap: var(--space-sm);
+    justify-content: center;
+}
+
+.pagination {
+    display: flex;
+    gap: var(--space-sm);
     justify-content: center;
+    align-items: center;
+    margin: var(--space-lg) 0;
+}
+
+.pagination-info {
+    color: var(--text-color);
+    opacity: 0.7;
 }
 
 .chapter-content {
     background: white;
     border: 1px solid var(--border-color);
     border-radius: 8px;
-    padding: 3rem;
-    margin-bottom: 2rem;
+    padding: var(--space-xl);
+    margin-bottom: var(--space-lg);
     box-shadow: var(--shadow);
     line-height: 2;
     font-size: 1.1rem;
@@ -407,15 +655,15 @@ body {
 
 /* 分类页面样式 */
 .page-header {
-    margin-bottom: 2rem;
-    padding-bottom: 1rem;
+    margin-bottom: var(--space-lg);
+    padding-bottom: var(--space-sm);
     border-bottom: 2px solid var(--border-color);
 }
 
 .page-header h1 {
     font-size: 2rem;
     color: var(--primary-color);
-    margin-bottom: 0.5rem;
+    margin-bottom: var(--space-xs);
 }
 
 .page-header p {
@@ -426,19 +674,19 @@ body {
 .categories-grid {
     display: grid;
     grid-template-columns: repeat(auto-fill, minmax(300px, 1fr));
-    gap: 1.5rem;
-    margin-top: 2rem;
+    gap: var(--space-md);
+    margin-top: var(--space-lg);
 }
 
 .category-card {
     background: white;
     border-radius: 8px;
-    padding: 1.5rem;
+    padding: var(--space-md);
     box-shadow: var(--shadow);
     transition: transform 0.2s ease, box-shadow 0.2s ease;
     display: flex;
     align-items: center;
-    gap: 1rem;
+    gap: var(--space-sm);
 }
 
 .category-card:hover {
@@ -454,7 +702,7 @@ body {
     align-items: center;
     justify-content: center;
     background: #f8f9fa;
-    border-radius: 50%;
+    border-radius: 50%%;
 }
 
 .category-info {
@@ -463,7 +711,7 @@ body {
 
 .category-name {
     font-size: 1.2rem;
-    margin-bottom: 0.5rem;
+    margin-bottom: var(--space-xs);
 }
 
 .category-name a {
@@ -477,7 +725,7 @@ body {
 
 .category-description {
     color: #666;
-    margin-bottom: 0.5rem;
+    margin-bottom: var(--space-xs);
     font-size: 0.9rem;
 }
 
@@ -489,8 +737,8 @@ body {
 .category-header {
     display: flex;
     align-items: center;
-    gap: 1.5rem;
-    margin-bottom: 2rem;
+    gap: var(--space-md);
+    margin-bottom: var(--space-lg);
 }
 
 .category-header .category-icon {
@@ -500,26 +748,56 @@ body {
 }
 
 .category-header .category-info h1 {
-    margin-bottom: 0.5rem;
+    margin-bottom: var(--space-xs);
+}
+
+.sub-categories {
+    list-style: none;
+    display: flex;
+    flex-wrap: wrap;
+    gap: var(--space-xs);
+    margin-top: var(--space-sm);
+}
+
+.sub-categories a {
+    display: inline-flex;
+    align-items: center;
+    gap: var(--space-xs);
+    padding: 0.2rem 0.75rem;
+    border-radius: 999px;
+    background: #f1f3f5;
+    color: var(--primary-color);
+    font-size: 0.85rem;
+    text-decoration: none;
+}
+
+.sub-categories a:hover {
+    background: var(--secondary-color);
+    color: white;
+}
+
+.sub-category-count {
+    font-size: 0.75rem;
+    opacity: 0.7;
 }
 
 /* 作者页面样式 */
 .authors-grid {
     display: grid;
     grid-template-columns: repeat(auto-fill, minmax(300px, 1fr));
-    gap: 1.5rem;
-    margin-top: 2rem;
+    gap: var(--space-md);
+    margin-top: var(--space-lg);
 }
 
 .author-card {
     background: white;
     border-radius: 8px;
-    padding: 1.5rem;
+    padding: var(--space-md);
     box-shadow: var(--shadow);
     transition: transform 0.2s ease, box-shadow 0.2s ease;
     display: flex;
     align-items: center;
-    gap: 1rem;
+    gap: var(--space-sm);
 }
 
 .author-card:hover {
@@ -536,7 +814,7 @@ body {
     justify-content: center;
     background: var(--primary-color);
     color: white;
-    border-radius: 50%;
+    border-radius: 50%%;
 }
 
 .author-info {
@@ -545,7 +823,7 @@ body {
 
 .author-name {
     font-size: 1.2rem;
-    margin-bottom: 0.5rem;
+    margin-bottom: var(--space-xs);
 }
 
 .author-name a {
@@ -560,22 +838,22 @@ body {
 .author-stats {
     display: flex;
     flex-wrap: wrap;
-    gap: 0.5rem;
+    gap: var(--space-xs);
     font-size: 0.8rem;
     color: #666;
 }
 
 .author-stats span {
     background: #f8f9fa;
-    padding: 0.2rem 0.5rem;
+    padding: 0.2rem var(--space-xs);
     border-radius: 12px;
 }
 
 .author-header {
     display: flex;
     align-items: center;
-    gap: 1.5rem;
-    margin-bottom: 2rem;
+    gap: var(--space-md);
+    margin-bottom: var(--space-lg);
 }
 
 .author-header .author-avatar {
@@ -585,22 +863,107 @@ body {
 }
 
 .author-header .author-info h1 {
-    margin-bottom: 0.5rem;
+    margin-bottom: var(--space-xs);
+}
+
+/* 小说阅读统计 */
+.novel-stats-summary {
+    display: grid;
+    grid-template-columns: repeat(auto-fit, minmax(180px, 1fr));
+    gap: var(--space-md);
+    margin-bottom: var(--space-lg);
+}
+
+.novel-stats-summary .stat-item {
+    display: flex;
+    flex-direction: column;
+    gap: 0.2rem;
+    background: white;
+    border: 1px solid var(--border-color);
+    border-radius: 8px;
+    padding: var(--space-md);
+}
+
+.novel-stats-summary .stat-label,
+.novel-stats-summary .stat-note {
+    font-size: 0.8rem;
+    color: #666;
+}
+
+.novel-stats-summary .stat-value {
+    font-size: 1.1rem;
+    font-weight: bold;
+}
+
+.novel-stats-chart {
+    margin-bottom: var(--space-lg);
+}
+
+.novel-stats-chart .chart-scroll {
+    overflow-x: auto;
+}
+
+/* 作者资料 */
+.author-profile {
+    display: grid;
+    grid-template-columns: repeat(auto-fit, minmax(280px, 1fr));
+    gap: var(--space-md);
+    margin-bottom: var(--space-lg);
+}
+
+.author-profile-section {
+    background: white;
+    border: 1px solid var(--border-color);
+    border-radius: 8px;
+    padding: var(--space-md);
+    box-shadow: var(--shadow);
+}
+
+.author-profile-section h2 {
+    font-size: 1.1rem;
+    margin-bottom: var(--space-sm);
+}
+
+.category-chart {
+    max-width: 100%%;
+    color: var(--text-color);
+}
+
+.author-top-chapters,
+.author-timeline {
+    padding-left: var(--space-md);
+}
+
+.author-top-chapters li,
+.author-timeline li {
+    margin-bottom: var(--space-xs);
+}
+
+.author-chapter-meta {
+    display: block;
+    color: #666;
+    font-size: 0.8rem;
+}
+
+.author-timeline time {
+    color: #666;
+    font-size: 0.85rem;
+    margin-right: var(--space-xs);
 }
 
 /* 小说分类标签 */
 .novel-category {
     background: var(--secondary-color);
     color: white;
-    padding: 0.2rem 0.5rem;
+    padding: 0.2rem var(--space-xs);
     border-radius: 12px;
     font-size: 0.8rem;
     display: inline-block;
-    margin-left: 0.5rem;
+    margin-left: var(--space-xs);
 }
 
 .chapter-content p {
-    margin-bottom: 1.5rem;
+    margin-bottom: var(--space-md);
     text-indent: 2em;
 }
 
@@ -610,30 +973,128 @@ body {
 .chapter-content h4,
 .chapter-content h5,
 .chapter-content h6 {
-    margin: 2rem 0 1rem 0;
+    margin: var(--space-lg) 0 var(--space-sm) 0;
     color: var(--primary-color);
     text-indent: 0;
 }
 
+.author-note {
+    background: rgba(255, 193, 7, 0.08);
+    border-left: 4px solid var(--secondary-color);
+    border-radius: 4px;
+    padding: var(--space-sm) var(--space-md);
+    margin-bottom: var(--space-lg);
+    font-size: 0.95rem;
+    opacity: 0.9;
+}
+
+.author-note-title {
+    font-size: 0.95rem;
+    margin-bottom: var(--space-xs);
+    color: var(--secondary-color);
+}
+
+.author-note-content {
+    white-space: pre-line;
+}
+
+.illustration {
+    margin: var(--space-lg) auto;
+    text-align: center;
+}
+
+.illustration img {
+    display: block;
+    max-width: 100%%;
+    height: auto;
+    margin: 0 auto var(--space-xs);
+    border-radius: 4px;
+}
+
+.illustration figcaption {
+    font-size: 0.85rem;
+    color: #888;
+}
+
+.illustration:not(:has(img)) {
+    padding: var(--space-md);
+    border: 1px dashed var(--border-color);
+    border-radius: 4px;
+}
+
 .chapter-footer {
     background: white;
     border: 1px solid var(--border-color);
     border-radius: 8px;
-    padding: 2rem;
+    padding: var(--space-lg);
     box-shadow: var(--shadow);
     text-align: center;
 }
 
 .chapter-info {
-    margin-bottom: 1.5rem;
+    margin-bottom: var(--space-md);
     color: #666;
     font-size: 0.9rem;
 }
 
+/* 离线阅读队列样式 */
+.offline-queue {
+    list-style: none;
+}
+
+.offline-item {
+    display: flex;
+    align-items: center;
+    gap: var(--space-sm);
+    background: white;
+    border: 1px solid var(--border-color);
+    border-radius: 8px;
+    padding: var(--space-sm) var(--space-md);
+    margin-bottom: var(--space-xs);
+    box-shadow: var(--shadow);
+}
+
+.offline-info {
+    flex: 1;
+    min-width: 0;
+}
+
+.offline-title {
+    color: var(--primary-color);
+    font-weight: 500;
+    text-decoration: none;
+}
+
+.offline-meta {
+    color: #666;
+    font-size: 0.85rem;
+}
+
+.offline-status {
+    padding: 0.1rem var(--space-xs);
+    border-radius: 10px;
+    font-size: 0.75rem;
+    color: white;
+    background: var(--secondary-color);
+}
+
+.offline-read .offline-status {
+    background: #95a5a6;
+}
+
+.offline-read .offline-title {
+    color: #666;
+}
+
+.offline-actions {
+    display: flex;
+    gap: var(--space-xs);
+}
+
 /* 按钮样式 */
 .btn {
     display: inline-block;
-    padding: 0.75rem 1.5rem;
+    padding: 0.75rem var(--space-md);
     border: none;
     border-radius: 4px;
     text-decoration: none;
@@ -668,8 +1129,8 @@ body {
     background: var(--primary-color);
     color: white;
     text-align: center;
-    padding: 2rem 0;
-    margin-top: 3rem;
+    padding: var(--space-lg) 0;
+    margin-top: var(--space-xl);
 }
 
 /* 响应式设计 */
@@ -680,12 +1141,12 @@ body {
     
     .header .container {
         flex-direction: column;
-        gap: 1rem;
+        gap: var(--space-sm);
     }
     
     .nav {
         flex-direction: column;
-        gap: 1rem;
+        gap: var(--space-sm);
     }
     
     #search-input {
@@ -711,13 +1172,13 @@ body {
     }
     
     .chapter-content {
-        padding: 2rem 1.5rem;
+        padding: var(--space-lg) var(--space-md);
         font-size: 1rem;
     }
     
     .chapter-nav {
         flex-wrap: wrap;
-        gap: 0.5rem;
+        gap: var(--space-xs);
     }
     
     .btn {
@@ -733,7 +1194,7 @@ body {
 
 @media (max-width: 480px) {
     .hero {
-        padding: 2rem 0;
+        padding: var(--space-lg) 0;
     }
     
     .hero h2 {
@@ -745,14 +1206,23 @@ body {
     .chapter-header,
     .chapter-content,
     .chapter-footer {
-        padding: 1.5rem;
+        padding: var(--space-md);
     }
     
     .chapter-content {
-        padding: 2rem 1rem;
+        padding: var(--space-lg) var(--space-sm);
     }
 }
+}
+
+/* 工具层：优先级最高的辅助样式 */
+@layer utilities {
+[hidden] {
+    display: none;
+}
+}
 `,
+		cssLayerOrder,
 		g.config.Theme.PrimaryColor,
 		g.config.Theme.SecondaryColor,
 		g.config.Theme.BackgroundColor,
@@ -760,28 +1230,134 @@ body {
 		g.config.Theme.FontFamily,
 		g.config.Theme.FontSize,
 		g.config.Theme.LineHeight,
+		g.spacingVariables(),
 	)
 
 	cssPath := filepath.Join(g.config.OutputDir, "static", "css", "style.css")
 	return os.WriteFile(cssPath, []byte(css), 0644)
 }
 
+// generateSkeletonCSS 生成骨架屏样式，在内容加载前显示占位卡片
+func (g *Generator) generateSkeletonCSS() error {
+	css := `/* Creeper 骨架屏样式 */
+` + cssLayerOrder + `
+
+@layer components {
+@keyframes skeleton-shimmer {
+    0% {
+        background-position: -468px 0;
+    }
+    100% {
+        background-position: 468px 0;
+    }
+}
+
+.skeleton-card {
+    border: 1px solid var(--border-color, #e1e5e9);
+    border-radius: 8px;
+    padding: 1.5rem;
+    background: white;
+    pointer-events: none;
+}
+
+.skeleton-line,
+.skeleton-cover {
+    background: linear-gradient(to right, #eeeeee 8%, #dddddd 18%, #eeeeee 33%);
+    background-size: 936px 100%;
+    animation: skeleton-shimmer 1.2s linear infinite;
+    border-radius: 4px;
+}
+
+.skeleton-cover {
+    height: 180px;
+    margin-bottom: 1rem;
+}
+
+.skeleton-line {
+    height: 0.9rem;
+    margin-bottom: 0.75rem;
+}
+
+.skeleton-line.skeleton-title {
+    width: 60%;
+    height: 1.2rem;
+}
+
+.skeleton-line.skeleton-short {
+    width: 40%;
+}
+
+[data-theme="dark"] .skeleton-card {
+    background: #2d2d2d;
+    border-color: #404040;
+}
+
+[data-theme="dark"] .skeleton-line,
+[data-theme="dark"] .skeleton-cover {
+    background: linear-gradient(to right, #333333 8%, #3d3d3d 18%, #333333 33%);
+    background-size: 936px 100%;
+}
+
+@media (prefers-reduced-motion: reduce) {
+    .skeleton-line,
+    .skeleton-cover {
+        animation: none;
+    }
+}
+}
+`
+
+	cssPath := filepath.Join(g.config.OutputDir, "static", "css", "loading-skeleton.css")
+	return os.WriteFile(cssPath, []byte(css), 0644)
+}
+
 // generateJS 生成JavaScript文件
 func (g *Generator) generateJS() error {
 	js := `// Creeper 小说站点脚本
 (function() {
     'use strict';
     
-    let searchData = [];
+    let searchIndex = null;
+    let searchStore = {};
     let searchTimeout;
     
+    // 立即插入骨架屏占位
+    showSkeletons();
+    
     // 初始化
     document.addEventListener('DOMContentLoaded', function() {
+        hideSkeletons();
         initSearch();
         initKeyboardNavigation();
         initReadingProgress();
     });
     
+    // 插入小说卡片骨架屏
+    function showSkeletons() {
+        document.querySelectorAll('.novels-grid').forEach(grid => {
+            const count = Math.min(grid.children.length || 3, 6);
+            grid.setAttribute('aria-busy', 'true');
+            for (let i = 0; i < count; i++) {
+                const card = document.createElement('div');
+                card.className = 'skeleton-card';
+                card.setAttribute('aria-hidden', 'true');
+                card.innerHTML = '<div class="skeleton-cover"></div>' +
+                    '<div class="skeleton-line skeleton-title"></div>' +
+                    '<div class="skeleton-line"></div>' +
+                    '<div class="skeleton-line skeleton-short"></div>';
+                grid.insertBefore(card, grid.firstChild);
+            }
+        });
+    }
+    
+    // 移除骨架屏
+    function hideSkeletons() {
+        document.querySelectorAll('.skeleton-card').forEach(card => card.remove());
+        document.querySelectorAll('.novels-grid[aria-busy]').forEach(grid => {
+            grid.removeAttribute('aria-busy');
+        });
+    }
+    
     // 初始化搜索功能
     function initSearch() {
         const searchInput = document.getElementById('search-input');
@@ -789,11 +1365,14 @@ func (g *Generator) generateJS() error {
         
         if (!searchInput || !searchResults) return;
         
-        // 加载搜索数据
+        // 加载预构建的 lunr 索引与结果数据
         fetch('/static/js/search-data.json')
             .then(response => response.json())
             .then(data => {
-                searchData = data;
+                searchStore = data.store || {};
+                if (window.lunr && data.index) {
+                    searchIndex = lunr.Index.load(data.index);
+                }
             })
             .catch(error => {
                 console.warn('搜索数据加载失败:', error);
@@ -863,14 +1442,49 @@ func (g *Generator) generateJS() error {
         });
     }
     
+    // 按生成索引时的规则切分关键词：汉字按相邻两字切分，其他字母与数字按连续片段切分
+    // 单个汉字与字母片段按前缀匹配，便于边输入边搜索
+    function searchTokens(text) {
+        const tokens = [];
+        const parts = text.toLowerCase().match(/\p{Script=Han}+|(?:(?!\p{Script=Han})[\p{L}\p{N}])+/gu) || [];
+        parts.forEach(part => {
+            const chars = Array.from(part);
+            if (chars.length === 1 || !/\p{Script=Han}/u.test(chars[0])) {
+                tokens.push({ term: part, prefix: true });
+                return;
+            }
+            for (let i = 0; i + 1 < chars.length; i++) {
+                tokens.push({ term: chars[i] + chars[i + 1], prefix: false });
+            }
+        });
+        return tokens;
+    }
+    
     // 执行搜索
     function performSearch(query) {
-        const results = searchData.filter(item => {
-            const searchText = (item.title + ' ' + (item.author || '') + ' ' + (item.novel || '')).toLowerCase();
-            return searchText.includes(query.toLowerCase());
-        }).slice(0, 10);
+        let results;
+        
+        if (searchIndex) {
+            const tokens = searchTokens(query);
+            results = tokens.length === 0 ? [] : searchIndex.query(q => {
+                tokens.forEach(token => {
+                    q.term(token.term, {
+                        usePipeline: false,
+                        presence: lunr.Query.presence.REQUIRED,
+                        wildcard: token.prefix ? lunr.Query.wildcard.TRAILING : lunr.Query.wildcard.NONE
+                    });
+                });
+            }).map(result => searchStore[result.ref]).filter(Boolean);
+        } else {
+            // lunr.js 未能加载（如离线阅读）时按包含关系匹配
+            const keyword = query.toLowerCase();
+            results = Object.values(searchStore).filter(item => {
+                const searchText = [item.title, item.author, item.novel, item.content].join(' ').toLowerCase();
+                return searchText.includes(keyword);
+            });
+        }
         
-        displaySearchResults(results);
+        displaySearchResults(results.slice(0, 10));
     }
     
     // 显示搜索结果
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	"creeper/internal/parser"
+)
+
+// authorTopChapterCount 作者页面展示的字数最多的章节数
+const authorTopChapterCount = 3
+
+// authorCategoryStat 作者在单个分类下的作品统计
+type authorCategoryStat struct {
+	Name  string
+	Count int
+	Words int
+	Color string
+}
+
+// getAuthorStats 计算作者的跨分类统计：分类分布、字数最多的章节、平均更新间隔与作品时间线
+func (g *Generator) getAuthorStats(author string, novels []*parser.Novel) map[string]interface{} {
+	categories := g.authorCategoryBreakdown(novels)
+
+	return map[string]interface{}{
+		"Categories":        categories,
+		"CategoryChart":     g.categoryBarChart(categories),
+		"TopChapters":       g.authorTopChapters(novels),
+		"AverageUpdateDays": averageChapterInterval(novels),
+		"Timeline":          novelTimeline(novels),
+	}
+}
+
+// authorCategoryBreakdown 按分类统计作品数与字数，作品多的分类在前
+func (g *Generator) authorCategoryBreakdown(novels []*parser.Novel) []authorCategoryStat {
+	index := make(map[string]int)
+	var stats []authorCategoryStat
+
+	for _, novel := range novels {
+		category := novel.Category
+		if category == "" {
+			category = "未分类"
+		}
+
+		i, exists := index[category]
+		if !exists {
+			i = len(stats)
+			index[category] = i
+			stats = append(stats, authorCategoryStat{Name: category, Color: g.getCategoryColor(category)})
+		}
+		stats[i].Count++
+		stats[i].Words += g.calculateTotalWords([]*parser.Novel{novel})
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// categoryBarChart 生成分类分布的 SVG 横向条形图
+func (g *Generator) categoryBarChart(categories []authorCategoryStat) template.HTML {
+	const (
+		width      = 320
+		labelWidth = 80
+		rowHeight  = 22
+		barHeight  = 14
+	)
+
+	maxCount := 0
+	for _, category := range categories {
+		if category.Count > maxCount {
+			maxCount = category.Count
+		}
+	}
+	if maxCount == 0 {
+		return ""
+	}
+
+	height := rowHeight * len(categories)
+	barSpace := float64(width - labelWidth - 30)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg class="category-chart" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="分类分布">`,
+		width, height, width, height))
+	for i, category := range categories {
+		color := category.Color
+		if color == "" {
+			color = g.config.Theme.PrimaryColor
+		}
+		y := i * rowHeight
+		barWidth := barSpace * float64(category.Count) / float64(maxCount)
+		sb.WriteString(fmt.Sprintf(`<text x="0" y="%d" font-size="12" fill="currentColor">%s</text>`,
+			y+barHeight-2, template.HTMLEscapeString(category.Name)))
+		sb.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%.1f" height="%d" rx="3" fill="%s"/>`,
+			labelWidth, y, barWidth, barHeight, template.HTMLEscapeString(color)))
+		sb.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" font-size="12" fill="currentColor">%d</text>`,
+			float64(labelWidth)+barWidth+6, y+barHeight-2, category.Count))
+	}
+	sb.WriteString(`</svg>`)
+
+	return template.HTML(sb.String())
+}
+
+// authorTopChapters 返回所有作品中字数最多的章节
+func (g *Generator) authorTopChapters(novels []*parser.Novel) []map[string]interface{} {
+	var chapters []chapterRef
+	for _, novel := range novels {
+		for _, chapter := range novel.Chapters {
+			chapters = append(chapters, chapterRef{novel, chapter})
+		}
+	}
+	sort.SliceStable(chapters, func(i, j int) bool {
+		return chapters[i].chapter.WordCount > chapters[j].chapter.WordCount
+	})
+	if len(chapters) > authorTopChapterCount {
+		chapters = chapters[:authorTopChapterCount]
+	}
+
+	result := make([]map[string]interface{}, 0, len(chapters))
+	for _, ref := range chapters {
+		result = append(result, map[string]interface{}{
+			"Novel":     ref.novel.Title,
+			"Title":     ref.chapter.Title,
+			"WordCount": ref.chapter.WordCount,
+			"URL":       fmt.Sprintf("novels/%s/chapter-%d.html", g.sanitizeFileName(ref.novel.Title), ref.chapter.ID),
+		})
+	}
+	return result
+}
+
+// averageChapterInterval 计算所有作品章节之间的平均更新间隔（天），无法计算时返回 0
+func averageChapterInterval(novels []*parser.Novel) float64 {
+	var times []time.Time
+	for _, novel := range novels {
+		for _, chapter := range novel.Chapters {
+			if !chapter.CreatedAt.IsZero() {
+				times = append(times, chapter.CreatedAt)
+			}
+		}
+	}
+	if len(times) < 2 {
+		return 0
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	span := times[len(times)-1].Sub(times[0])
+	if span < time.Hour {
+		// 部分格式以解析时间作为章节时间，无法反映真实的更新频率
+		return 0
+	}
+	return span.Hours() / 24 / float64(len(times)-1)
+}
+
+// novelTimeline 按创建时间排列作品
+func novelTimeline(novels []*parser.Novel) []*parser.Novel {
+	timeline := append([]*parser.Novel(nil), novels...)
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].CreatedAt.Before(timeline[j].CreatedAt)
+	})
+	return timeline
+}
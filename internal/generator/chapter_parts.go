@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"creeper/internal/parser"
+)
+
+// chapterTagRegex 匹配 HTML 标签，用于寻找段落边界
+var chapterTagRegex = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)[^>]*>`)
+
+// chapterContainerTags 可以包含段落的块级元素，只在这些元素之外拆分
+var chapterContainerTags = map[string]bool{
+	"div": true, "blockquote": true, "ul": true, "ol": true, "table": true,
+	"pre": true, "section": true, "figure": true, "aside": true,
+}
+
+// chapterBlockTags 结束后可以作为拆分点的块级元素
+var chapterBlockTags = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"div": true, "blockquote": true, "ul": true, "ol": true, "table": true,
+	"pre": true, "section": true, "figure": true, "aside": true,
+}
+
+// chapterPartName 章节第 part 页的文件名：第一页为 chapter-N.html，其余为 chapter-N-part-M.html
+func chapterPartName(chapterID, part int) string {
+	if part <= 1 {
+		return fmt.Sprintf("chapter-%d.html", chapterID)
+	}
+	return fmt.Sprintf("chapter-%d-part-%d.html", chapterID, part)
+}
+
+// splitChapterParts 在段落边界处拆分章节 HTML，每页的正文字数不少于 maxWords（最后一页除外）
+// 拆分后只有一页时返回 nil
+func splitChapterParts(htmlContent string, maxWords int) []string {
+	if maxWords <= 0 {
+		return nil
+	}
+
+	var (
+		parts []string
+		start int // 当前页在 htmlContent 中的起点
+		last  int // 上一个标签的结束位置
+		words int // 当前页的正文字数
+		depth int // 所在容器元素的嵌套层数
+	)
+
+	for _, loc := range chapterTagRegex.FindAllStringSubmatchIndex(htmlContent, -1) {
+		words += utf8.RuneCountInString(strings.TrimSpace(htmlContent[last:loc[0]]))
+		last = loc[1]
+
+		closing := loc[3] > loc[2]
+		name := strings.ToLower(htmlContent[loc[4]:loc[5]])
+		if chapterContainerTags[name] && !strings.HasSuffix(htmlContent[loc[0]:loc[1]], "/>") {
+			if closing {
+				depth--
+			} else {
+				depth++
+			}
+		}
+
+		if closing && depth == 0 && chapterBlockTags[name] && words >= maxWords {
+			parts = append(parts, strings.TrimSpace(htmlContent[start:loc[1]]))
+			start, words = loc[1], 0
+		}
+	}
+
+	if rest := strings.TrimSpace(htmlContent[start:]); rest != "" {
+		parts = append(parts, rest)
+	}
+	if len(parts) <= 1 {
+		return nil
+	}
+	return parts
+}
+
+// chapterParts 按 build.max_chapter_words_per_page 拆分章节，不需要拆分时返回 nil
+// 插图只增加不含正文的标签，嵌入插图前后的拆分结果相同
+func (g *Generator) chapterParts(chapter *parser.Chapter) []string {
+	if limit := g.config.Build.MaxChapterWordsPerPage; limit > 0 && chapter.WordCount > limit {
+		return splitChapterParts(chapter.HTMLContent, limit)
+	}
+	return nil
+}
+
+// generateChapterPages 生成章节页面，超过 build.max_chapter_words_per_page 的章节拆分为多页
+// 第一页直接写入 chapter-N.html，保持目录、上一章/下一章链接不变
+func (g *Generator) generateChapterPages(novel *parser.Novel, chapter *parser.Chapter, novelDir string) error {
+	chapter.Parts = g.chapterParts(chapter)
+
+	if len(chapter.Parts) == 0 {
+		chapterData := map[string]interface{}{
+			"Config":  g.config,
+			"Novel":   novel,
+			"Chapter": chapter,
+			"Title":   fmt.Sprintf("%s - %s", chapter.Title, novel.Title),
+		}
+		return g.renderTemplateToFile("chapter", filepath.Join(novelDir, chapterPartName(chapter.ID, 1)), chapterData)
+	}
+
+	totalParts := len(chapter.Parts)
+	for i, content := range chapter.Parts {
+		part := i + 1
+		chapterData := map[string]interface{}{
+			"Config":      g.config,
+			"Novel":       novel,
+			"Chapter":     chapter,
+			"Title":       fmt.Sprintf("%s (%d/%d) - %s", chapter.Title, part, totalParts, novel.Title),
+			"Part":        part,
+			"TotalParts":  totalParts,
+			"PartContent": content,
+		}
+		if part > 1 {
+			chapterData["PrevPartURL"] = chapterPartName(chapter.ID, part-1)
+		}
+		if part < totalParts {
+			chapterData["NextPartURL"] = chapterPartName(chapter.ID, part+1)
+		}
+
+		if err := g.renderTemplateToFile("chapter", filepath.Join(novelDir, chapterPartName(chapter.ID, part)), chapterData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitChapterParts(t *testing.T) {
+	long := "<p>" + strings.Repeat("长", 10) + "</p>"
+
+	tests := []struct {
+		name     string
+		html     string
+		maxWords int
+		want     []string
+	}{
+		{"未设置上限", "<p>一二三</p><p>四五六</p>", 0, nil},
+		{"未超过上限", "<p>一二三</p><p>四五六</p>", 10, nil},
+		{"正好等于上限", "<p>一二三</p><p>四五六</p>", 6, nil},
+		{"在段落边界拆分", "<p>一二三</p><p>四五六</p>", 3, []string{"<p>一二三</p>", "<p>四五六</p>"}},
+		{"单个段落超过上限不拆开", long, 3, nil},
+		{"超长段落单独成页", long + "<p>短</p>", 3, []string{long, "<p>短</p>"}},
+		{
+			"不在容器元素内部拆分",
+			"<blockquote><p>一二三</p><p>四五六</p></blockquote><p>七</p>", 3,
+			[]string{"<blockquote><p>一二三</p><p>四五六</p></blockquote>", "<p>七</p>"},
+		},
+		{
+			"行内元素不是拆分点",
+			"<p>一<em>二</em>三</p>\n<p>四</p>", 2,
+			[]string{"<p>一<em>二</em>三</p>", "<p>四</p>"},
+		},
+		{
+			"最后一页可以不足上限",
+			"<h2>小节</h2><p>一二三</p><p>四</p>", 4,
+			[]string{"<h2>小节</h2><p>一二三</p>", "<p>四</p>"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitChapterParts(tt.html, tt.maxWords); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitChapterParts(%q, %d) = %q, want %q", tt.html, tt.maxWords, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSitemapIncludesChapterParts(t *testing.T) {
+	content := "---\ntitle: 长篇\n---\n# 第1章 开端\n"
+	for i := 0; i < 4; i++ {
+		content += strings.Repeat("字", 50) + "\n\n"
+	}
+	content += "# 第2章 短章\n短短的一章。\n"
+
+	g := newTestGenerator(t, map[string]string{"a.md": content})
+	g.config.Build.MaxChapterWordsPerPage = 100
+
+	// 第二次构建跳过未变化的小说，分页链接仍应出现在站点地图中
+	for build := 1; build <= 2; build++ {
+		if err := g.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(g.config.OutputDir, "sitemap.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sitemap := string(data)
+		for _, page := range []string{"chapter-1.html", "chapter-1-part-2.html", "chapter-2.html"} {
+			if !strings.Contains(sitemap, page) {
+				t.Errorf("第 %d 次构建的站点地图缺少 %s", build, page)
+			}
+			if _, err := os.Stat(filepath.Join(g.config.OutputDir, "novels", g.sanitizeFileName("长篇"), page)); err != nil {
+				t.Errorf("第 %d 次构建没有生成 %s", build, page)
+			}
+		}
+		for _, page := range []string{"chapter-1-part-3.html", "chapter-2-part-2.html"} {
+			if strings.Contains(sitemap, page) {
+				t.Errorf("第 %d 次构建的站点地图包含不存在的页面 %s", build, page)
+			}
+		}
+	}
+}
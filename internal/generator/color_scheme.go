@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+)
+
+// colorSchemeSwitcherJS 配色方案切换脚本
+// 体积很小，直接内联在 <head> 中执行，在首次绘制前设置 data-theme，避免页面闪烁
+const colorSchemeSwitcherJS = `(function() {
+    'use strict';
+    var KEY = 'creeper-reading-settings';
+    var THEMES = ['light', 'dark', 'sepia', 'green'];
+    var root = document.documentElement;
+
+    function read() {
+        try {
+            return JSON.parse(localStorage.getItem(KEY)) || {};
+        } catch (e) {
+            return {};
+        }
+    }
+
+    function get() {
+        var theme = read().theme;
+        return THEMES.indexOf(theme) >= 0 ? theme : 'light';
+    }
+
+    function set(theme) {
+        if (THEMES.indexOf(theme) < 0) {
+            return;
+        }
+        root.setAttribute('data-theme', theme);
+        var settings = read();
+        settings.theme = theme;
+        try {
+            localStorage.setItem(KEY, JSON.stringify(settings));
+        } catch (e) {}
+    }
+
+    function cycle() {
+        var next = THEMES[(THEMES.indexOf(get()) + 1) % THEMES.length];
+        set(next);
+        return next;
+    }
+
+    root.setAttribute('data-theme', get());
+    window.CreeperColorScheme = {themes: THEMES, get: get, set: set, cycle: cycle};
+})();
+`
+
+// colorSchemeScript 返回可内联到模板中的配色方案脚本
+func (g *Generator) colorSchemeScript() template.JS {
+	return template.JS(colorSchemeSwitcherJS)
+}
+
+// generateColorSchemeJS 生成独立的配色方案切换脚本
+func (g *Generator) generateColorSchemeJS() error {
+	jsPath := filepath.Join(g.config.OutputDir, "static", "js", "color-scheme-switcher.js")
+	return os.WriteFile(jsPath, []byte(colorSchemeSwitcherJS), 0644)
+}
@@ -0,0 +1,26 @@
+package generator
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// staticContentTypes 本地服务器按扩展名固定的 Content-Type
+// http.FileServer 依赖系统的 MIME 表，部分系统会把 .svg 识别为 text/plain，浏览器因此无法显示封面
+var staticContentTypes = map[string]string{
+	".svg":   "image/svg+xml",
+	".json":  "application/json",
+	".woff2": "font/woff2",
+}
+
+// withContentTypes 为 staticContentTypes 中的扩展名预先设置 Content-Type，http.FileServer 不会覆盖已设置的值
+func withContentTypes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ext := strings.ToLower(path.Ext(r.URL.Path))
+		if contentType, ok := staticContentTypes[ext]; ok {
+			w.Header().Set("Content-Type", contentType)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
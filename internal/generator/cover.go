@@ -2,30 +2,75 @@ package generator
 
 import (
 	"fmt"
+	"html/template"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
+	"creeper/internal/cover"
 	"creeper/internal/parser"
 )
 
+// GenerateCoversOnly 仅为所有小说重新生成封面，不生成 HTML 页面
+func (g *Generator) GenerateCoversOnly() error {
+	if err := g.parseNovels(); err != nil {
+		return fmt.Errorf("解析小说失败: %v", err)
+	}
+
+	var failed []string
+	for _, novel := range g.novels {
+		if err := g.generateNovelCover(novel); err != nil {
+			fmt.Printf("警告：生成小说 %s 的封面失败: %v\n", novel.Title, err)
+			failed = append(failed, novel.Title)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d 部小说的封面生成失败: %s", len(failed), strings.Join(failed, ", "))
+	}
+
+	fmt.Printf("成功生成 %d 部小说的封面\n", len(g.novels))
+	return nil
+}
+
+// categoryToTheme 小说分类对应的封面主题，未列出的分类使用 default 主题
+var categoryToTheme = map[string]string{
+	"玄幻": "fantasy",
+	"奇幻": "fantasy",
+	"魔幻": "fantasy",
+	"科幻": "scifi",
+	"武侠": "wuxia",
+	"仙侠": "wuxia",
+	"历史": "classical",
+	"古典": "classical",
+	"古言": "classical",
+	"都市": "modern",
+	"现代": "modern",
+	"言情": "modern",
+}
+
+// novelCoverWidth、novelCoverHeight 小说封面尺寸，与标题框的坐标对应
+const (
+	novelCoverWidth  = 300
+	novelCoverHeight = 400
+)
+
 // generateNovelCover 为小说生成带标题的封面
+// 指定了封面文件时在其上添加标题，否则按分类选择主题生成封面
 func (g *Generator) generateNovelCover(novel *parser.Novel) error {
-	// 如果没有指定封面，使用默认封面
-	coverPath := novel.Cover
-	if coverPath == "" {
-		coverPath = "static/images/default-cover.svg"
+	if novel.Cover == "" {
+		return g.GenerateNovelCover(novel)
 	}
 
 	// 读取原始封面文件
-	originalCoverPath := filepath.Join(g.config.InputDir, "..", coverPath)
+	originalCoverPath := filepath.Join(g.config.InputDir, "..", novel.Cover)
 	if _, err := os.Stat(originalCoverPath); os.IsNotExist(err) {
 		// 如果封面文件不存在，尝试从项目根目录查找
-		originalCoverPath = coverPath
+		originalCoverPath = novel.Cover
 		if _, err := os.Stat(originalCoverPath); os.IsNotExist(err) {
-			// 如果仍然不存在，跳过封面生成
-			return nil
+			// 如果仍然不存在，按分类生成封面
+			return g.GenerateNovelCover(novel)
 		}
 	}
 
@@ -35,8 +80,71 @@ func (g *Generator) generateNovelCover(novel *parser.Novel) error {
 	}
 
 	// 为小说生成带标题的封面
-	modifiedSVG := g.addTitleToCover(string(svgContent), novel.Title, novel.Author)
+	modifiedSVG := g.addTitleToCover(string(svgContent), novel.Title, g.getCoverSubtitle(novel), novel.Author)
+	return g.writeNovelCover(novel, modifiedSVG)
+}
+
+// GenerateNovelCover 按小说分类选择主题生成封面，写入 novels/<标题>/cover.svg
+func (g *Generator) GenerateNovelCover(novel *parser.Novel) error {
+	coverGenerator := cover.NewCoverGenerator()
+	theme, _ := coverGenerator.Theme(coverThemeName(coverGenerator, novel.Category))
+
+	subtitle := g.getCoverSubtitle(novel)
 
+	// 作者显示在标题框中，主题背景不再单独绘制作者名
+	svgContent, err := coverGenerator.GenerateSVG(novel.Title, subtitle, "", theme, novelCoverWidth, novelCoverHeight)
+	if err != nil {
+		return fmt.Errorf("生成封面失败: %v", err)
+	}
+
+	modifiedSVG := g.addStyledTitleToCover(svgContent, coverTitleStyle(theme.Name), novel.Title, subtitle, novel.Author)
+	return g.writeNovelCover(novel, modifiedSVG)
+}
+
+// generateDefaultCover 生成 static/images/default-cover.svg，页面中小说封面加载失败时使用
+func (g *Generator) generateDefaultCover() error {
+	coverGenerator := cover.NewCoverGenerator()
+	theme, _ := coverGenerator.Theme("default")
+
+	svgContent, err := coverGenerator.GenerateSVG("Novel", "Creeper", "", theme, novelCoverWidth, novelCoverHeight)
+	if err != nil {
+		return err
+	}
+
+	coverPath := filepath.Join(g.config.OutputDir, "static", "images", "default-cover.svg")
+	if err := os.MkdirAll(filepath.Dir(coverPath), 0755); err != nil {
+		return fmt.Errorf("创建封面目录失败: %v", err)
+	}
+
+	return os.WriteFile(coverPath, []byte(g.addStyledTitleToCover(svgContent, "default", "Novel", "Creeper", "")), 0644)
+}
+
+// coverThemeName 获取分类对应的封面主题名，分类本身是主题名时直接使用
+func coverThemeName(coverGenerator *cover.CoverGenerator, category string) string {
+	category = strings.TrimSpace(category)
+	if name, ok := categoryToTheme[category]; ok {
+		return name
+	}
+	if _, ok := coverGenerator.Theme(strings.ToLower(category)); ok {
+		return strings.ToLower(category)
+	}
+	return "default"
+}
+
+// coverTitleStyle 封面主题对应的标题样式，武侠主题使用浅色背景的古典样式
+func coverTitleStyle(themeName string) string {
+	switch themeName {
+	case "fantasy", "scifi", "classical", "modern":
+		return themeName
+	case "wuxia":
+		return "classical"
+	default:
+		return "default"
+	}
+}
+
+// writeNovelCover 写入小说封面
+func (g *Generator) writeNovelCover(novel *parser.Novel, svgContent string) error {
 	// 生成输出路径
 	novelDir := filepath.Join(g.config.OutputDir, "novels", g.sanitizeFileName(novel.Title))
 	coverOutputPath := filepath.Join(novelDir, "cover.svg")
@@ -47,16 +155,46 @@ func (g *Generator) generateNovelCover(novel *parser.Novel) error {
 	}
 
 	// 写入修改后的封面
-	return os.WriteFile(coverOutputPath, []byte(modifiedSVG), 0644)
+	return os.WriteFile(coverOutputPath, []byte(svgContent), 0644)
+}
+
+// getCoverSubtitle 获取封面副标题
+func (g *Generator) getCoverSubtitle(novel *parser.Novel) string {
+	if novel.Subtitle != "" {
+		return novel.Subtitle
+	}
+
+	if g.config.Cover.UseDescriptionAsSubtitle {
+		return firstSentence(novel.Description, 30)
+	}
+
+	return ""
+}
+
+// firstSentence 提取文本的第一句，最多 maxRunes 个字符
+func firstSentence(text string, maxRunes int) string {
+	text = strings.TrimSpace(text)
+	if end := strings.IndexAny(text, "。！？!?\n"); end >= 0 {
+		text = text[:end]
+	}
+
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) > maxRunes {
+		runes = runes[:maxRunes]
+	}
+	return string(runes)
 }
 
 // addTitleToCover 在封面上添加标题
-func (g *Generator) addTitleToCover(svgContent, title, author string) string {
+func (g *Generator) addTitleToCover(svgContent, title, subtitle, author string) string {
 	// 检测封面风格
-	style := g.detectCoverStyle(svgContent)
+	return g.addStyledTitleToCover(svgContent, g.detectCoverStyle(svgContent), title, subtitle, author)
+}
 
+// addStyledTitleToCover 按指定风格在封面上添加标题
+func (g *Generator) addStyledTitleToCover(svgContent, style, title, subtitle, author string) string {
 	// 根据不同风格选择合适的标题样式
-	titleElement := g.generateTitleElement(title, author, style)
+	titleElement := g.generateTitleElement(title, author, style) + g.generateSubtitleText(subtitle, style)
 
 	// 在 </svg> 标签前插入标题元素
 	svgEndRegex := regexp.MustCompile(`</svg>\s*$`)
@@ -87,6 +225,8 @@ func (g *Generator) generateTitleElement(title, author, style string) string {
 	if len([]rune(title)) > 12 {
 		displayTitle = string([]rune(title)[:12]) + "..."
 	}
+	// 截断后再转义，避免切断实体；标题含 & 或 < 时不转义会使 SVG 无法解析
+	displayTitle = template.HTMLEscapeString(displayTitle)
 
 	switch style {
 	case "fantasy":
@@ -171,6 +311,27 @@ func (g *Generator) generateModernTitle(title, author string) string {
   </g>`, title, g.generateAuthorText(author, "150", "365", "#ffffff", "Arial, sans-serif", "11"))
 }
 
+// generateSubtitleText 生成副标题文本，位于标题框上方
+func (g *Generator) generateSubtitleText(subtitle, style string) string {
+	if subtitle == "" {
+		return ""
+	}
+
+	color, fontFamily := "#ffffff", "Arial, sans-serif"
+	switch style {
+	case "fantasy", "classical":
+		fontFamily = "serif"
+	case "scifi":
+		color, fontFamily = "#00ffff", "monospace"
+	}
+
+	return fmt.Sprintf(`
+  <!-- 动态副标题 -->
+  <text id="dynamic-subtitle" x="150" y="308" text-anchor="middle" fill="%s" font-family="%s" font-size="11" opacity="0.85">
+    %s
+  </text>`, color, fontFamily, template.HTMLEscapeString(subtitle))
+}
+
 // generateAuthorText 生成作者文本
 func (g *Generator) generateAuthorText(author, x, y, color, fontFamily, fontSize string) string {
 	if author == "" {
@@ -186,5 +347,5 @@ func (g *Generator) generateAuthorText(author, x, y, color, fontFamily, fontSize
 	return fmt.Sprintf(`
     <text x="%s" y="%s" text-anchor="middle" fill="%s" font-family="%s" font-size="%s" opacity="0.8">
       %s
-    </text>`, x, y, color, fontFamily, fontSize, displayAuthor)
+    </text>`, x, y, color, fontFamily, fontSize, template.HTMLEscapeString(displayAuthor))
 }
@@ -0,0 +1,376 @@
+package generator
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"creeper/internal/parser"
+)
+
+// epubCSS EPUB 内置的简单样式
+const epubCSS = `body { font-family: serif; line-height: 1.8; margin: 0 5%; }
+h1 { font-size: 1.4em; text-align: center; margin: 1.5em 0 1em; }
+p { text-indent: 2em; margin: 0 0 0.6em; }
+img { max-width: 100%; }
+.author-note { margin-top: 2em; padding-top: 0.5em; border-top: 1px solid #ccc; font-size: 0.9em; }
+`
+
+const epubContainerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+var (
+	// epubVoidTagRegex HTML 空元素，XHTML 中需要自闭合
+	epubVoidTagRegex = regexp.MustCompile(`<(br|hr|img|input|meta|link|col|area|source|wbr)((?:\s[^<>]*?)?)\s*/?>`)
+	// epubEntityRegex 命名字符实体，XHTML 只认 XML 预定义的五个
+	epubEntityRegex = regexp.MustCompile(`&[a-zA-Z][a-zA-Z0-9]*;`)
+	// epubImageRegex 章节中的图片地址
+	epubImageRegex = regexp.MustCompile(`<img([^>]*?)\ssrc="([^"]+)"`)
+)
+
+// opfPackage content.opf
+type opfPackage struct {
+	XMLName          xml.Name    `xml:"package"`
+	Xmlns            string      `xml:"xmlns,attr"`
+	Version          string      `xml:"version,attr"`
+	UniqueIdentifier string      `xml:"unique-identifier,attr"`
+	Lang             string      `xml:"xml:lang,attr"`
+	Metadata         opfMetadata `xml:"metadata"`
+	Manifest         []opfItem   `xml:"manifest>item"`
+	Spine            opfSpine    `xml:"spine"`
+}
+
+// opfMetadata 书籍元数据（Dublin Core）
+type opfMetadata struct {
+	XmlnsDC     string    `xml:"xmlns:dc,attr"`
+	Identifier  opfID     `xml:"dc:identifier"`
+	Title       string    `xml:"dc:title"`
+	Language    string    `xml:"dc:language"`
+	Creator     string    `xml:"dc:creator,omitempty"`
+	Description string    `xml:"dc:description,omitempty"`
+	Subjects    []string  `xml:"dc:subject"`
+	Meta        []opfMeta `xml:"meta"`
+}
+
+// opfID 书籍唯一标识
+type opfID struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:",chardata"`
+}
+
+// opfMeta EPUB 3 的 meta 元素
+type opfMeta struct {
+	Property string `xml:"property,attr,omitempty"`
+	Name     string `xml:"name,attr,omitempty"`
+	Content  string `xml:"content,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+// opfItem 清单中的文件
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr,omitempty"`
+}
+
+// opfSpine 阅读顺序
+type opfSpine struct {
+	Toc      string       `xml:"toc,attr"`
+	ItemRefs []opfItemRef `xml:"itemref"`
+}
+
+// opfItemRef 阅读顺序中的文件
+type opfItemRef struct {
+	IDRef string `xml:"idref,attr"`
+}
+
+// ncxDocument toc.ncx，供只支持 EPUB 2 的阅读器使用
+type ncxDocument struct {
+	XMLName   xml.Name   `xml:"ncx"`
+	Xmlns     string     `xml:"xmlns,attr"`
+	Version   string     `xml:"version,attr"`
+	Head      []ncxMeta  `xml:"head>meta"`
+	DocTitle  string     `xml:"docTitle>text"`
+	NavPoints []ncxPoint `xml:"navMap>navPoint"`
+}
+
+// ncxMeta toc.ncx 的元数据
+type ncxMeta struct {
+	Name    string `xml:"name,attr"`
+	Content string `xml:"content,attr"`
+}
+
+// ncxPoint 目录项
+type ncxPoint struct {
+	ID        string `xml:"id,attr"`
+	PlayOrder int    `xml:"playOrder,attr"`
+	Label     string `xml:"navLabel>text"`
+	Content   struct {
+		Src string `xml:"src,attr"`
+	} `xml:"content"`
+}
+
+// epubFile 写入 EPUB 的文件
+type epubFile struct {
+	name string
+	data []byte
+}
+
+// ExportEPUB 将小说导出为 EPUB 3.0 文件
+// 章节按 ID 排序写入阅读顺序；已复制到输出目录的插图与封面会一并打包
+func (g *Generator) ExportEPUB(novel *parser.Novel, outputPath string) error {
+	slug := g.sanitizeFileName(novel.Title)
+	identifier := "urn:creeper:novel:" + slug
+
+	chapters := make([]*parser.Chapter, len(novel.Chapters))
+	copy(chapters, novel.Chapters)
+	sort.SliceStable(chapters, func(i, j int) bool { return chapters[i].ID < chapters[j].ID })
+
+	pkg := opfPackage{
+		Xmlns:            "http://www.idpf.org/2007/opf",
+		Version:          "3.0",
+		UniqueIdentifier: "book-id",
+		Lang:             "zh-CN",
+		Metadata: opfMetadata{
+			XmlnsDC:     "http://purl.org/dc/elements/1.1/",
+			Identifier:  opfID{ID: "book-id", Value: identifier},
+			Title:       novel.Title,
+			Language:    "zh-CN",
+			Creator:     novel.Author,
+			Description: novel.Description,
+			Meta: []opfMeta{{
+				Property: "dcterms:modified",
+				Value:    epubModified(novel).UTC().Format("2006-01-02T15:04:05Z"),
+			}},
+		},
+		Manifest: []opfItem{
+			{ID: "nav", Href: "nav.xhtml", MediaType: "application/xhtml+xml", Properties: "nav"},
+			{ID: "ncx", Href: "toc.ncx", MediaType: "application/x-dtbncx+xml"},
+			{ID: "css", Href: "style.css", MediaType: "text/css"},
+		},
+		Spine: opfSpine{Toc: "ncx"},
+	}
+	if novel.Category != "" {
+		pkg.Metadata.Subjects = append(pkg.Metadata.Subjects, novel.Category)
+	}
+	pkg.Metadata.Subjects = append(pkg.Metadata.Subjects, novel.Genre...)
+
+	ncx := ncxDocument{
+		Xmlns:    "http://www.daisy.org/z3986/2005/ncx/",
+		Version:  "2005-1",
+		Head:     []ncxMeta{{Name: "dtb:uid", Content: identifier}, {Name: "dtb:depth", Content: "1"}},
+		DocTitle: novel.Title,
+	}
+
+	var files []epubFile
+	images := make(map[string]bool)
+
+	// 封面
+	coverPath := filepath.Join(g.config.OutputDir, "novels", slug, "cover.svg")
+	if data, err := os.ReadFile(coverPath); err == nil {
+		files = append(files, epubFile{"OEBPS/images/cover.svg", data})
+		pkg.Manifest = append(pkg.Manifest, opfItem{ID: "cover", Href: "images/cover.svg", MediaType: "image/svg+xml", Properties: "cover-image"})
+		pkg.Metadata.Meta = append(pkg.Metadata.Meta, opfMeta{Name: "cover", Content: "cover"})
+	}
+
+	var navItems strings.Builder
+	for i, chapter := range chapters {
+		id := fmt.Sprintf("chapter-%d", chapter.ID)
+		href := id + ".xhtml"
+
+		content, chapterImages := g.epubChapterContent(chapter.HTMLContent)
+		for _, image := range chapterImages {
+			if images[image] {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(g.config.OutputDir, illustrationDir, image))
+			if err != nil {
+				continue
+			}
+			images[image] = true
+			files = append(files, epubFile{"OEBPS/images/" + image, data})
+			pkg.Manifest = append(pkg.Manifest, opfItem{
+				ID:        fmt.Sprintf("image-%d", len(images)),
+				Href:      "images/" + image,
+				MediaType: epubImageType(image),
+			})
+		}
+
+		var body strings.Builder
+		body.WriteString(`<section epub:type="chapter">` + "\n")
+		body.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(chapter.Title)))
+		body.WriteString(content)
+		if chapter.AuthorNote != "" {
+			body.WriteString(fmt.Sprintf("\n<aside class=\"author-note\"><p>作者有话说：%s</p></aside>", html.EscapeString(chapter.AuthorNote)))
+		}
+		body.WriteString("\n</section>")
+
+		files = append(files, epubFile{"OEBPS/" + href, []byte(epubXHTML(chapter.Title, body.String()))})
+		pkg.Manifest = append(pkg.Manifest, opfItem{ID: id, Href: href, MediaType: "application/xhtml+xml"})
+		pkg.Spine.ItemRefs = append(pkg.Spine.ItemRefs, opfItemRef{IDRef: id})
+
+		point := ncxPoint{ID: id, PlayOrder: i + 1, Label: chapter.Title}
+		point.Content.Src = href
+		ncx.NavPoints = append(ncx.NavPoints, point)
+
+		navItems.WriteString(fmt.Sprintf("    <li><a href=\"%s\">%s</a></li>\n", href, html.EscapeString(chapter.Title)))
+	}
+
+	nav := fmt.Sprintf("<nav epub:type=\"toc\" id=\"toc\">\n<h1>目录</h1>\n<ol>\n%s</ol>\n</nav>", navItems.String())
+
+	opfData, err := xml.MarshalIndent(pkg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("生成 content.opf 失败: %v", err)
+	}
+	ncxData, err := xml.MarshalIndent(ncx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("生成 toc.ncx 失败: %v", err)
+	}
+
+	files = append([]epubFile{
+		{"META-INF/container.xml", []byte(epubContainerXML)},
+		{"OEBPS/content.opf", append([]byte(xml.Header), opfData...)},
+		{"OEBPS/toc.ncx", append([]byte(xml.Header), ncxData...)},
+		{"OEBPS/nav.xhtml", []byte(epubXHTML("目录", nav))},
+		{"OEBPS/style.css", []byte(epubCSS)},
+	}, files...)
+
+	return writeEPUB(outputPath, files)
+}
+
+// exportEPUBs 将所有小说导出到 novels/<标题>/<标题>.epub，OPDS 目录会为已导出的小说添加下载链接
+func (g *Generator) exportEPUBs() error {
+	for _, novel := range g.novels {
+		slug := g.sanitizeFileName(novel.Title)
+		outputPath := filepath.Join(g.config.OutputDir, "novels", slug, slug+".epub")
+		if err := g.ExportEPUB(novel, outputPath); err != nil {
+			return fmt.Errorf("导出小说 %s 的 EPUB 失败: %v", novel.Title, err)
+		}
+	}
+	fmt.Printf("📚 已导出 %d 部小说的 EPUB\n", len(g.novels))
+	return nil
+}
+
+// SetExportEPUB 设置生成站点时是否同时导出 EPUB
+func (g *Generator) SetExportEPUB(export bool) {
+	g.exportEPUB = export
+}
+
+// epubChapterContent 将章节 HTML 转换为 XHTML，返回转换后的内容与引用的插图文件名
+func (g *Generator) epubChapterContent(content string) (string, []string) {
+	content = epubEntityRegex.ReplaceAllStringFunc(content, func(entity string) string {
+		switch entity {
+		case "&amp;", "&lt;", "&gt;", "&quot;", "&apos;":
+			return entity
+		}
+		if text := html.UnescapeString(entity); text != entity {
+			return html.EscapeString(text)
+		}
+		return "&amp;" + entity[1:]
+	})
+
+	// 站内插图改为 EPUB 内的相对路径
+	var images []string
+	prefix := g.config.Site.BaseURL + filepath.ToSlash(illustrationDir) + "/"
+	content = epubImageRegex.ReplaceAllStringFunc(content, func(tag string) string {
+		match := epubImageRegex.FindStringSubmatch(tag)
+		if !strings.HasPrefix(match[2], prefix) {
+			return tag
+		}
+		image := strings.TrimPrefix(match[2], prefix)
+		images = append(images, image)
+		return fmt.Sprintf(`<img%s src="images/%s"`, match[1], image)
+	})
+
+	content = epubVoidTagRegex.ReplaceAllString(content, "<$1$2 />")
+	return content, images
+}
+
+// epubXHTML 生成 XHTML 文档
+func epubXHTML(title, body string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="zh-CN" lang="zh-CN">
+<head>
+<meta charset="UTF-8" />
+<title>%s</title>
+<link rel="stylesheet" type="text/css" href="style.css" />
+</head>
+<body>
+%s
+</body>
+</html>
+`, html.EscapeString(title), body)
+}
+
+// epubModified EPUB 的修改时间，取小说更新时间，未知时取当前时间
+func epubModified(novel *parser.Novel) time.Time {
+	if !novel.UpdatedAt.IsZero() {
+		return novel.UpdatedAt
+	}
+	return time.Now()
+}
+
+// epubImageType 根据扩展名返回图片的媒体类型
+func epubImageType(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	case ".webp":
+		return "image/webp"
+	case ".svg":
+		return "image/svg+xml"
+	default:
+		return "image/jpeg"
+	}
+}
+
+// writeEPUB 写入 EPUB 压缩包，mimetype 必须是第一个文件且不压缩
+func writeEPUB(outputPath string, files []epubFile) error {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("创建目录失败: %v", err)
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("创建文件 %s 失败: %v", outputPath, err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := mimetype.Write([]byte("application/epub+zip")); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		w, err := archive.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(f.data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
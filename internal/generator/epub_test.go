@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"creeper/internal/parser"
+)
+
+func TestExportEPUB(t *testing.T) {
+	g := newTestGenerator(t, nil)
+	novel := &parser.Novel{
+		Title:  "小说甲",
+		Author: "作者",
+		Chapters: []*parser.Chapter{
+			{ID: 2, Title: "第2章 <下>", HTMLContent: "<p>第二章&nbsp;正文<br>换行</p>"},
+			{ID: 1, Title: "第1章 上", HTMLContent: "<p>第一章 &amp; 正文</p><hr>", AuthorNote: "感谢 A & B"},
+		},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "novel.epub")
+	if err := g.ExportEPUB(novel, outputPath); err != nil {
+		t.Fatalf("ExportEPUB() error = %v", err)
+	}
+
+	archive, err := zip.OpenReader(outputPath)
+	if err != nil {
+		t.Fatalf("打开 EPUB 失败: %v", err)
+	}
+	defer archive.Close()
+
+	// mimetype 必须是第一个且不压缩，阅读器靠它识别文件类型
+	first := archive.File[0]
+	if first.Name != "mimetype" || first.Method != zip.Store {
+		t.Errorf("第一个条目 = %s（Method %d），want mimetype（zip.Store）", first.Name, first.Method)
+	}
+	if got := readZipFile(t, first); got != "application/epub+zip" {
+		t.Errorf("mimetype = %q, want application/epub+zip", got)
+	}
+
+	files := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	xhtmlCount := 0
+	for name, f := range files {
+		if !strings.HasSuffix(name, ".xhtml") {
+			continue
+		}
+		xhtmlCount++
+		decoder := xml.NewDecoder(strings.NewReader(readZipFile(t, f)))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("%s 不是合法的 XML: %v", name, err)
+				break
+			}
+		}
+	}
+
+	if xhtmlCount != 3 {
+		t.Errorf("XHTML 文件数 = %d, want 3（目录与两个章节）", xhtmlCount)
+	}
+
+	var pkg struct {
+		Manifest []struct {
+			ID   string `xml:"id,attr"`
+			Href string `xml:"href,attr"`
+		} `xml:"manifest>item"`
+		Spine []struct {
+			IDRef string `xml:"idref,attr"`
+		} `xml:"spine>itemref"`
+	}
+	opf, exists := files["OEBPS/content.opf"]
+	if !exists {
+		t.Fatal("EPUB 缺少 OEBPS/content.opf")
+	}
+	if err := xml.Unmarshal([]byte(readZipFile(t, opf)), &pkg); err != nil {
+		t.Fatalf("解析 content.opf 失败: %v", err)
+	}
+
+	manifest := make(map[string]string)
+	for _, item := range pkg.Manifest {
+		manifest[item.ID] = item.Href
+		if _, exists := files["OEBPS/"+item.Href]; !exists {
+			t.Errorf("清单中的 %s 不在 EPUB 中", item.Href)
+		}
+	}
+
+	var spine []string
+	for _, ref := range pkg.Spine {
+		spine = append(spine, ref.IDRef)
+		if _, exists := manifest[ref.IDRef]; !exists {
+			t.Errorf("阅读顺序中的 %s 不在清单中", ref.IDRef)
+		}
+	}
+	if want := []string{"chapter-1", "chapter-2"}; !reflect.DeepEqual(spine, want) {
+		t.Errorf("阅读顺序 = %q, want %q", spine, want)
+	}
+}
+
+// readZipFile 读取压缩包中的文件内容
+func readZipFile(t *testing.T, f *zip.File) string {
+	t.Helper()
+	r, err := f.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
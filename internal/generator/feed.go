@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"creeper/internal/parser"
+)
+
+// defaultFeedMaxItems 未配置 feed.max_items 时每个订阅源的条目数
+const defaultFeedMaxItems = 20
+
+// atomFeed Atom 1.0 订阅源
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  *opdsAuthor `xml:"author,omitempty"`
+	Links   []opdsLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry 订阅源条目，每个条目对应一个章节
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Author  *opdsAuthor `xml:"author,omitempty"`
+	Summary string      `xml:"summary,omitempty"`
+	Links   []opdsLink  `xml:"link"`
+}
+
+// chapterRef 小说与其章节
+type chapterRef struct {
+	novel   *parser.Novel
+	chapter *parser.Chapter
+}
+
+// updated 条目的更新时间：章节发布时间未知时取小说的更新时间，都未知时取生成时间
+// Atom 要求 updated 为有效时间，不能输出 0001-01-01
+func (ref chapterRef) updated() time.Time {
+	if !ref.chapter.CreatedAt.IsZero() {
+		return ref.chapter.CreatedAt
+	}
+	if !ref.novel.UpdatedAt.IsZero() {
+		return ref.novel.UpdatedAt
+	}
+	return time.Now()
+}
+
+// generateFeed 生成站点根目录的 feed.xml（所有小说的最新章节）与每部小说的 novels/<标题>/feed.xml
+func (g *Generator) generateFeed() error {
+	if !g.config.Feed.Enabled {
+		// 关闭订阅源后删除上次生成的文件；小说目录中的订阅源随配置变化整体重建时清除
+		os.Remove(filepath.Join(g.config.OutputDir, "feed.xml"))
+		return nil
+	}
+
+	var all []chapterRef
+	for _, novel := range g.novels {
+		var chapters []chapterRef
+		for _, chapter := range novel.Chapters {
+			chapters = append(chapters, chapterRef{novel, chapter})
+		}
+		all = append(all, chapters...)
+
+		title := fmt.Sprintf("%s - %s", novel.Title, g.feedTitle())
+		feed := g.buildAtomFeed("urn:creeper:novel:"+g.sanitizeFileName(novel.Title), title,
+			g.novelURL(novel)+"feed.xml", g.novelURL(novel), chapters, false)
+		if novel.Author != "" {
+			feed.Author = &opdsAuthor{Name: novel.Author}
+		}
+
+		outputPath := filepath.Join(g.config.OutputDir, "novels", g.sanitizeFileName(novel.Title), "feed.xml")
+		if err := writeAtomFeed(outputPath, feed); err != nil {
+			return fmt.Errorf("生成小说 %s 的订阅源失败: %v", novel.Title, err)
+		}
+	}
+
+	baseURL := g.config.Site.BaseURL
+	feed := g.buildAtomFeed("urn:creeper:feed:"+g.config.Site.Title, g.feedTitle(), baseURL+"feed.xml", baseURL, all, true)
+	if g.config.Site.Author != "" {
+		feed.Author = &opdsAuthor{Name: g.config.Site.Author}
+	}
+
+	return writeAtomFeed(filepath.Join(g.config.OutputDir, "feed.xml"), feed)
+}
+
+// feedTitle 订阅源标题，未配置时使用站点标题
+func (g *Generator) feedTitle() string {
+	if g.config.Feed.Title != "" {
+		return g.config.Feed.Title
+	}
+	return g.config.Site.Title
+}
+
+// feedURL 站点订阅源地址，未启用订阅源时返回空字符串
+func (g *Generator) feedURL() string {
+	if !g.config.Feed.Enabled {
+		return ""
+	}
+	return g.config.Site.BaseURL + "feed.xml"
+}
+
+// novelFeedURL 小说订阅源地址，未启用订阅源时返回空字符串
+func (g *Generator) novelFeedURL(novel *parser.Novel) string {
+	if !g.config.Feed.Enabled {
+		return ""
+	}
+	return g.novelURL(novel) + "feed.xml"
+}
+
+// buildAtomFeed 按章节发布时间倒序生成订阅源，最多保留 feed.max_items 个条目
+// withNovel 为 true 时条目标题包含小说名，用于汇总多部小说的站点订阅源
+func (g *Generator) buildAtomFeed(id, title, selfURL, alternateURL string, chapters []chapterRef, withNovel bool) atomFeed {
+	sort.SliceStable(chapters, func(i, j int) bool {
+		return chapters[i].chapter.CreatedAt.After(chapters[j].chapter.CreatedAt)
+	})
+
+	maxItems := g.config.Feed.MaxItems
+	if maxItems <= 0 {
+		maxItems = defaultFeedMaxItems
+	}
+	if len(chapters) > maxItems {
+		chapters = chapters[:maxItems]
+	}
+
+	// 订阅源的更新时间取最新章节的发布时间，没有章节时取生成时间
+	updated := time.Now()
+	if len(chapters) > 0 {
+		updated = chapters[0].updated()
+	}
+
+	feed := atomFeed{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		ID:      id,
+		Title:   title,
+		Updated: updated.Format(time.RFC3339),
+		Links: []opdsLink{
+			{Rel: "self", Href: selfURL, Type: "application/atom+xml"},
+			{Rel: "alternate", Href: alternateURL, Type: "text/html"},
+		},
+	}
+
+	for _, ref := range chapters {
+		entryTitle := ref.chapter.Title
+		if withNovel {
+			entryTitle = fmt.Sprintf("%s：%s", ref.novel.Title, ref.chapter.Title)
+		}
+
+		entry := atomEntry{
+			Title:   entryTitle,
+			ID:      fmt.Sprintf("urn:creeper:chapter:%s:%d", g.sanitizeFileName(ref.novel.Title), ref.chapter.ID),
+			Updated: ref.updated().Format(time.RFC3339),
+			Summary: chapterSummary(ref.chapter),
+			Links: []opdsLink{{
+				Rel:  "alternate",
+				Href: fmt.Sprintf("%schapter-%d.html", g.novelURL(ref.novel), ref.chapter.ID),
+				Type: "text/html",
+			}},
+		}
+		if ref.novel.Author != "" {
+			entry.Author = &opdsAuthor{Name: ref.novel.Author}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+// chapterSummary 章节摘要：去掉 Markdown 标题行后截取正文开头
+func chapterSummary(chapter *parser.Chapter) string {
+	return chapterExcerpt(chapter, descriptionExcerptLength)
+}
+
+// chapterExcerpt 去掉 Markdown 标题行后截取正文开头最多 n 个字符
+func chapterExcerpt(chapter *parser.Chapter, n int) string {
+	var lines []string
+	for _, line := range strings.Split(chapter.Content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return truncate(strings.Join(lines, " "), n)
+}
+
+// writeAtomFeed 将订阅源写入文件
+func writeAtomFeed(path string, feed atomFeed) error {
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), data...), 0644)
+}
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"creeper/internal/parser"
+)
+
+func TestBuildAtomFeedUpdated(t *testing.T) {
+	published := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	novelUpdated := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		createdAt   time.Time
+		novelUpdate time.Time
+		want        string // 空表示取生成时间
+	}{
+		{"使用章节发布时间", published, novelUpdated, published.Format(time.RFC3339)},
+		{"章节时间未知时使用小说更新时间", time.Time{}, novelUpdated, novelUpdated.Format(time.RFC3339)},
+		{"都未知时使用生成时间", time.Time{}, time.Time{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGenerator(t, nil)
+			novel := &parser.Novel{Title: "小说甲", UpdatedAt: tt.novelUpdate}
+			chapter := &parser.Chapter{ID: 1, Title: "第1章", CreatedAt: tt.createdAt}
+
+			before := time.Now().Add(-time.Second)
+			feed := g.buildAtomFeed("urn:test", "测试", "feed.xml", "/", []chapterRef{{novel, chapter}}, false)
+			if len(feed.Entries) != 1 {
+				t.Fatalf("len(Entries) = %d, want 1", len(feed.Entries))
+			}
+
+			for _, got := range []string{feed.Updated, feed.Entries[0].Updated} {
+				if tt.want != "" {
+					if got != tt.want {
+						t.Errorf("Updated = %q, want %q", got, tt.want)
+					}
+					continue
+				}
+				parsed, err := time.Parse(time.RFC3339, got)
+				if err != nil || parsed.Before(before) {
+					t.Errorf("Updated = %q, want 生成时间", got)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package generator
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"html/template"
 	"net/http"
@@ -9,7 +9,10 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"creeper/internal/common"
 	"creeper/internal/config"
 	"creeper/internal/parser"
 )
@@ -20,20 +23,112 @@ type Generator struct {
 	parser   *parser.Parser
 	novels   []*parser.Novel
 	templates map[string]*template.Template
+
+	// novelsMu 保护 novels 的替换，开发服务器的接口在重建期间读取小说列表
+	novelsMu sync.RWMutex
+
+	// buildMu 串行化生成与重新渲染，热重载、监听与配置监听可能同时触发重建
+	buildMu sync.Mutex
+
+	// templatesMu 保护 templates，并行渲染页面时监听模式可能同时重新加载模板
+	templatesMu sync.RWMutex
+
+	// workers 并行解析与渲染的并发数，为 0 时使用配置中的 build.workers
+	workers int
+
+	// illustrationMu 串行化插图复制，多部小说可能引用同名插图
+	illustrationMu sync.Mutex
+
+	// exportEPUB 生成站点时同时导出每部小说的 EPUB
+	exportEPUB bool
+
+	// liveReload 本地服务器监听输入目录，重建后通过 SSE 通知浏览器刷新
+	liveReload bool
+
+	// flyweights 模板中按需生成的 CSS 片段
+	flyweights *common.FlyweightManager
+
+	// verbose 输出详细信息，如每部小说的解析统计
+	verbose bool
+
+	// novelIndex 按分类、作者与标签组织的小说索引，解析小说后重新建立
+	novelIndexMu sync.Mutex
+	novelIndex   *NovelIndex
+
+	// warnings 严格模式下本次生成中被跳过的错误
+	warningsMu sync.Mutex
+	warnings   []error
+
+	// 最近一次生成的状态，供开发服务器健康检查使用
+	stateMu   sync.RWMutex
+	lastError error
+	startedAt time.Time
 }
 
 // New 创建新的生成器
 func New(cfg *config.Config) *Generator {
+	p, err := parser.NewFromConfig(cfg)
+	if err != nil {
+		fmt.Printf("⚠️  %v，使用 blackfriday\n", err)
+	}
+
+	flyweights := common.NewFlyweightManager()
+	flyweights.SetPalette(common.CSSPalette{
+		PrimaryColor:    cfg.Theme.PrimaryColor,
+		SecondaryColor:  cfg.Theme.SecondaryColor,
+		BackgroundColor: cfg.Theme.BackgroundColor,
+		TextColor:       cfg.Theme.TextColor,
+	})
+
 	return &Generator{
-		config:    cfg,
-		parser:    parser.New(),
-		novels:    make([]*parser.Novel, 0),
-		templates: make(map[string]*template.Template),
+		config:     cfg,
+		parser:     p,
+		novels:     make([]*parser.Novel, 0),
+		templates:  make(map[string]*template.Template),
+		flyweights: flyweights,
+	}
+}
+
+// SetVerbose 设置是否输出详细信息
+func (g *Generator) SetVerbose(verbose bool) {
+	g.verbose = verbose
+}
+
+// SetWorkers 设置并行解析与渲染的并发数，不大于 0 时使用配置或 CPU 核数
+func (g *Generator) SetWorkers(workers int) {
+	g.workers = workers
+}
+
+// workerPool 按当前并发配置创建任务池
+func (g *Generator) workerPool() *WorkerPool {
+	workers := g.workers
+	if workers <= 0 {
+		workers = g.config.Build.Workers
 	}
+	return NewWorkerPool(workers)
 }
 
 // Generate 生成静态站点
+// 启用 build.strict_mode 时，生成过程中被跳过的错误在全部步骤完成后以 *PartialBuildError 返回
 func (g *Generator) Generate() error {
+	g.buildMu.Lock()
+	defer g.buildMu.Unlock()
+
+	g.takeWarnings() // 丢弃上次生成残留的警告
+	err := g.generate()
+	if err == nil {
+		err = g.takeWarnings()
+	}
+
+	g.stateMu.Lock()
+	g.lastError = err
+	g.stateMu.Unlock()
+
+	return err
+}
+
+// generate 依次执行各生成步骤
+func (g *Generator) generate() error {
 	// 1. 解析所有小说
 	if err := g.parseNovels(); err != nil {
 		return fmt.Errorf("解析小说失败: %v", err)
@@ -59,33 +154,68 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("生成首页失败: %v", err)
 	}
 
-	// 6. 生成小说页面
-	for _, novel := range g.novels {
-		// 生成带标题的封面
-		if err := g.generateNovelCover(novel); err != nil {
-			fmt.Printf("警告：生成小说 %s 的封面失败: %v\n", novel.Title, err)
-		}
-		
-		if err := g.generateNovel(novel); err != nil {
-			return fmt.Errorf("生成小说 %s 失败: %v", novel.Title, err)
+	// 6. 生成小说页面，源文件未变化的小说沿用上次的输出
+	if err := g.generateNovels(); err != nil {
+		return err
+	}
+
+	// 7. 导出 EPUB，需在生成 OPDS 目录之前完成
+	if g.exportEPUB {
+		if err := g.exportEPUBs(); err != nil {
+			return err
 		}
 	}
 
-	// 7. 生成搜索数据
+	// 8. 生成搜索数据
 	if err := g.generateSearchData(); err != nil {
 		return fmt.Errorf("生成搜索数据失败: %v", err)
 	}
 
-	// 8. 生成分类页面
+	// 9. 生成搜索页面
+	if err := g.generateSearchPage(); err != nil {
+		return fmt.Errorf("生成搜索页面失败: %v", err)
+	}
+
+	// 10. 生成离线阅读页面
+	if err := g.generateOfflineQueuePage(); err != nil {
+		return fmt.Errorf("生成离线阅读页面失败: %v", err)
+	}
+
+	// 11. 生成 OPDS 目录
+	if err := g.generateOPDSCatalog(); err != nil {
+		return fmt.Errorf("生成 OPDS 目录失败: %v", err)
+	}
+
+	// 12. 生成 Atom 订阅源
+	if err := g.generateFeed(); err != nil {
+		return fmt.Errorf("生成订阅源失败: %v", err)
+	}
+
+	// 13. 生成站点地图
+	if err := g.generateSitemap(); err != nil {
+		return fmt.Errorf("生成站点地图失败: %v", err)
+	}
+
+	// 14. 生成分类页面
 	if err := g.generateCategoryPages(); err != nil {
 		return fmt.Errorf("生成分类页面失败: %v", err)
 	}
 
-	// 9. 生成作者页面
+	// 15. 生成作者页面
 	if err := g.generateAuthorPages(); err != nil {
 		return fmt.Errorf("生成作者页面失败: %v", err)
 	}
 
+	// 16. 生成题材页面
+	if err := g.generateGenrePages(); err != nil {
+		return fmt.Errorf("生成题材页面失败: %v", err)
+	}
+
+	// 17. 生成标签页面
+	if err := g.generateTagPages(); err != nil {
+		return fmt.Errorf("生成标签页面失败: %v", err)
+	}
+
 	return nil
 }
 
@@ -98,58 +228,92 @@ func (g *Generator) parseNovels() error {
 		return fmt.Errorf("输入目录不存在: %s", inputDir)
 	}
 
+	// 重新生成时丢弃上次的解析结果
+	g.resetNovelIndex()
+
 	// 遍历输入目录
 	entries, err := os.ReadDir(inputDir)
 	if err != nil {
 		return fmt.Errorf("读取输入目录失败: %v", err)
 	}
 
+	var paths []string
 	for _, entry := range entries {
-		path := filepath.Join(inputDir, entry.Name())
-
 		// 跳过隐藏文件和目录
 		if strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
 
-		var novel *parser.Novel
-		if entry.IsDir() {
-			// 目录模式
-			novel, err = g.parser.ParseNovel(path)
-		} else if strings.HasSuffix(strings.ToLower(entry.Name()), ".md") {
-			// 单文件模式
-			novel, err = g.parser.ParseNovel(path)
-		} else {
-			continue
+		// 目录模式与单文件模式（Markdown、TXT 与 DOCX）
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || ext == ".md" || ext == ".txt" || ext == ".docx" {
+			paths = append(paths, filepath.Join(inputDir, entry.Name()))
 		}
+	}
 
+	// 并行解析，每个任务只写入自己的结果位置
+	results := make([]*parser.Novel, len(paths))
+	g.workerPool().Run(len(paths), func(i int) error {
+		novel, err := g.parser.ParseNovel(paths[i])
 		if err != nil {
-			fmt.Printf("警告：解析 %s 失败: %v\n", path, err)
+			g.warn(fmt.Errorf("解析 %s 失败: %v", paths[i], err))
+			return err
+		}
+		results[i] = novel
+		return nil
+	})
+
+	// 在新的切片中整理结果，完成后再整体替换，接口不会读到填充一半的列表
+	novels := make([]*parser.Novel, 0, len(results))
+	for _, novel := range results {
+		if novel == nil {
 			continue
 		}
 
+		if g.verbose {
+			g.printParseStats(novel)
+		}
+
 		if len(novel.Chapters) > 0 {
-			g.novels = append(g.novels, novel)
+			// 检查重复的章节标题，发布前完成去重，生成页面时不再修改章节
+			for _, title := range detectDuplicateTitles(novel) {
+				fmt.Printf("警告：小说 %s 中存在重复的章节标题 %q，已为后续章节添加序号\n", novel.Title, title)
+			}
+			disambiguateChapterTitles(novel)
+			novels = append(novels, novel)
 		}
 	}
 
 	// 按标题排序
-	sort.Slice(g.novels, func(i, j int) bool {
-		return g.novels[i].Title < g.novels[j].Title
+	sort.Slice(novels, func(i, j int) bool {
+		return novels[i].Title < novels[j].Title
 	})
 
-	fmt.Printf("成功解析 %d 部小说\n", len(g.novels))
+	g.novelsMu.Lock()
+	g.novels = novels
+	g.novelsMu.Unlock()
+
+	fmt.Printf("成功解析 %d 部小说\n", len(novels))
 	return nil
 }
 
+// novelSnapshot 获取当前小说列表，供与重建并发的接口读取
+// 重建时整体替换列表而不修改原切片，返回的切片可以在不加锁的情况下遍历
+func (g *Generator) novelSnapshot() []*parser.Novel {
+	g.novelsMu.RLock()
+	defer g.novelsMu.RUnlock()
+	return g.novels
+}
+
 // createOutputDir 创建输出目录
+// 为支持增量构建，保留已有的小说页面；每次都会完整重新生成的分类、作者、题材、标签页面目录先清空，
+// 避免残留已删除分类的页面
 func (g *Generator) createOutputDir() error {
 	outputDir := g.config.OutputDir
 
-	// 如果目录存在，先删除
-	if _, err := os.Stat(outputDir); err == nil {
-		if err := os.RemoveAll(outputDir); err != nil {
-			return fmt.Errorf("删除旧输出目录失败: %v", err)
+	for _, dir := range []string{"categories", "authors", "genres", "tags"} {
+		if err := os.RemoveAll(filepath.Join(outputDir, dir)); err != nil {
+			return fmt.Errorf("清理旧输出目录 %s 失败: %v", dir, err)
 		}
 	}
 
@@ -174,13 +338,165 @@ func (g *Generator) createOutputDir() error {
 
 // generateIndex 生成首页
 func (g *Generator) generateIndex() error {
-	data := map[string]interface{}{
-		"Config": g.config,
-		"Novels": g.novels,
-		"Title":  g.config.Site.Title,
+	jsonLD, err := g.buildSiteJSONLD()
+	if err != nil {
+		return err
+	}
+
+	totalPages := g.indexPageCount()
+	perPage := g.indexItemsPerPage()
+
+	for page := 1; page <= totalPages; page++ {
+		novels := g.novels
+		if totalPages > 1 {
+			start := (page - 1) * perPage
+			end := start + perPage
+			if end > len(novels) {
+				end = len(novels)
+			}
+			novels = novels[start:end]
+		}
+
+		data := map[string]interface{}{
+			"Config":      g.config,
+			"Novels":      novels,
+			"TotalNovels": len(g.novels),
+			"Title":       g.config.Site.Title,
+			"JSONLD":      jsonLD,
+			"OPDS":        g.config.Site.BaseURL + "opds.xml",
+			"Feed":        g.feedURL(),
+			"CurrentPage": page,
+			"TotalPages":  totalPages,
+		}
+		if page > 1 {
+			data["PrevURL"] = g.config.Site.BaseURL + indexPageName(page-1)
+		}
+		if page < totalPages {
+			data["NextURL"] = g.config.Site.BaseURL + indexPageName(page+1)
+		}
+
+		if err := g.renderTemplate("index", indexPageName(page), data); err != nil {
+			return err
+		}
+	}
+
+	return g.removeStaleIndexPages(totalPages)
+}
+
+// generateNovels 生成所有小说页面
+// 对比构建清单中记录的内容哈希，跳过未变化的小说，并删除已移除小说的输出
+func (g *Generator) generateNovels() error {
+	previous := g.loadBuildManifest(g.buildFingerprint())
+	manifest := newBuildManifest(previous.Fingerprint)
+
+	// 同名小说共用输出目录，分到同一组内顺序生成；不同组并行生成
+	var groups [][]int
+	groupIndex := make(map[string]int)
+	for i, novel := range g.novels {
+		slug := g.sanitizeFileName(novel.Title)
+		if j, exists := groupIndex[slug]; exists {
+			groups[j] = append(groups[j], i)
+			continue
+		}
+		groupIndex[slug] = len(groups)
+		groups = append(groups, []int{i})
+	}
+
+	// 结果按小说序号写入，生成结束后再汇总到清单
+	entries := make([]*manifestEntry, len(g.novels))
+	skipped := make([]bool, len(g.novels))
+	// generateGroup 生成共用同一输出目录的一组小说
+	// 同组小说只要有一部变化就整组重新生成：目录只清理一次，避免后生成的小说删除先生成的输出
+	generateGroup := func(group []int) error {
+		novelDir := filepath.Join(g.config.OutputDir, "novels", g.sanitizeFileName(g.novels[group[0]].Title))
+
+		hashes := make([]string, len(group))
+		hashErrs := make([]error, len(group))
+		upToDate := true
+		for k, i := range group {
+			hashes[k], hashErrs[k] = g.novelSourceHash(g.novels[i])
+			if hashErrs[k] != nil || !previous.isUpToDate(g.novels[i].Path, hashes[k], g.config.OutputDir) {
+				upToDate = false
+			}
+		}
+
+		if upToDate {
+			for _, i := range group {
+				novel := g.novels[i]
+				// 更新日志中的“新”标记与当前时间有关，每次都重新生成
+				if err := g.generateNovelChangelog(novel); err != nil {
+					return fmt.Errorf("生成小说 %s 的更新日志失败: %v", novel.Title, err)
+				}
+				entry := previous.Novels[novel.Path]
+				entries[i] = &entry
+				skipped[i] = true
+			}
+			return nil
+		}
+
+		// 清除旧输出，避免残留已删除的章节页面
+		if err := os.RemoveAll(novelDir); err != nil {
+			return fmt.Errorf("清理小说 %s 的旧输出失败: %v", g.novels[group[0]].Title, err)
+		}
+
+		for _, i := range group {
+			novel := g.novels[i]
+
+			// 生成带标题的封面
+			if !g.config.Build.SkipCovers {
+				if err := g.generateNovelCover(novel); err != nil {
+					g.warn(fmt.Errorf("生成小说 %s 的封面失败: %v", novel.Title, err))
+				}
+			}
+
+			if err := g.generateNovel(novel); err != nil {
+				return fmt.Errorf("生成小说 %s 失败: %v", novel.Title, err)
+			}
+		}
+
+		// 同组小说的输出混在同一目录中，每部小说都记录整个目录，任一文件缺失时整组重新生成
+		outputs := g.listOutputs(novelDir)
+		for k, i := range group {
+			if hashErrs[k] == nil {
+				entries[i] = &manifestEntry{Hash: hashes[k], Outputs: outputs}
+			}
+		}
+		return nil
+	}
+
+	errs := g.workerPool().Run(len(groups), func(j int) error {
+		return generateGroup(groups[j])
+	})
+	if err := firstError(errs); err != nil {
+		return err
+	}
+
+	skippedCount := 0
+	for i, novel := range g.novels {
+		if entries[i] != nil {
+			manifest.Novels[novel.Path] = *entries[i]
+		}
+		if skipped[i] {
+			skippedCount++
+		}
+	}
+
+	keep := make(map[string]bool, len(groupIndex))
+	for slug := range groupIndex {
+		keep[slug] = true
+	}
+	if err := g.removeStaleNovelDirs(keep); err != nil {
+		return err
+	}
+
+	if skippedCount > 0 {
+		fmt.Printf("⏭️  %d 部小说未变化，跳过生成\n", skippedCount)
 	}
 
-	return g.renderTemplate("index", "index.html", data)
+	if err := g.saveBuildManifest(manifest); err != nil {
+		g.warn(fmt.Errorf("保存构建清单失败: %v", err))
+	}
+	return nil
 }
 
 // generateNovel 生成小说页面
@@ -191,10 +507,21 @@ func (g *Generator) generateNovel(novel *parser.Novel) error {
 	}
 
 	// 生成小说目录页
+	jsonLD, err := g.buildBookJSONLD(novel)
+	if err != nil {
+		return err
+	}
+
 	data := map[string]interface{}{
 		"Config": g.config,
 		"Novel":  novel,
 		"Title":  novel.Title,
+		"JSONLD": jsonLD,
+		"Feed":   g.novelFeedURL(novel),
+	}
+	if volumes := displayVolumes(novel); volumes != nil {
+		data["Volumes"] = volumes
+		data["UngroupedChapters"] = ungroupedChapters(novel)
 	}
 
 	indexPath := filepath.Join(novelDir, "index.html")
@@ -204,24 +531,118 @@ func (g *Generator) generateNovel(novel *parser.Novel) error {
 
 	// 生成每个章节页面
 	for _, chapter := range novel.Chapters {
-		chapterData := map[string]interface{}{
-			"Config":  g.config,
-			"Novel":   novel,
-			"Chapter": chapter,
-			"Title":   fmt.Sprintf("%s - %s", chapter.Title, novel.Title),
+		if err := g.embedIllustrations(chapter); err != nil {
+			return err
 		}
 
-		chapterPath := filepath.Join(novelDir, fmt.Sprintf("chapter-%d.html", chapter.ID))
-		if err := g.renderTemplateToFile("chapter", chapterPath, chapterData); err != nil {
+		if err := g.generateChapterPages(novel, chapter, novelDir); err != nil {
 			return fmt.Errorf("生成章节 %d 失败: %v", chapter.ID, err)
 		}
 	}
 
+	// 生成分卷目录页
+	if err := g.generateVolumePages(novel, novelDir); err != nil {
+		return err
+	}
+
+	// 生成更新日志页
+	if err := g.generateNovelChangelog(novel); err != nil {
+		return fmt.Errorf("生成更新日志失败: %v", err)
+	}
+
+	// 生成阅读统计页
+	if err := g.generateNovelStats(novel); err != nil {
+		return fmt.Errorf("生成阅读统计页失败: %v", err)
+	}
+
 	return nil
 }
 
-// generateSearchData 生成搜索数据
-func (g *Generator) generateSearchData() error {
+// detectDuplicateTitles 返回小说中出现多次的章节标题（按首次出现顺序）
+func detectDuplicateTitles(novel *parser.Novel) []string {
+	counts := make(map[string]int)
+	var duplicates []string
+	for _, chapter := range novel.Chapters {
+		counts[chapter.Title]++
+		if counts[chapter.Title] == 2 {
+			duplicates = append(duplicates, chapter.Title)
+		}
+	}
+	return duplicates
+}
+
+// disambiguateChapterTitles 为重复标题的后续章节追加 (2)、(3) 等后缀
+func disambiguateChapterTitles(novel *parser.Novel) {
+	seen := make(map[string]int)
+	for _, chapter := range novel.Chapters {
+		seen[chapter.Title]++
+		if n := seen[chapter.Title]; n > 1 {
+			chapter.Title = fmt.Sprintf("%s (%d)", chapter.Title, n)
+		}
+	}
+}
+
+// generateNovelChangelog 生成小说更新日志页，按章节发布时间倒序排列
+func (g *Generator) generateNovelChangelog(novel *parser.Novel) error {
+	newChapterDays := g.config.Build.NewChapterDays
+	if newChapterDays <= 0 {
+		newChapterDays = 7
+	}
+	threshold := time.Now().AddDate(0, 0, -newChapterDays)
+
+	chapters := make([]*parser.Chapter, len(novel.Chapters))
+	copy(chapters, novel.Chapters)
+	sort.SliceStable(chapters, func(i, j int) bool {
+		return chapters[i].CreatedAt.After(chapters[j].CreatedAt)
+	})
+
+	entries := make([]map[string]interface{}, 0, len(chapters))
+	for _, chapter := range chapters {
+		entries = append(entries, map[string]interface{}{
+			"Chapter": chapter,
+			"IsNew":   chapter.CreatedAt.After(threshold),
+		})
+	}
+
+	data := map[string]interface{}{
+		"Config":         g.config,
+		"Novel":          novel,
+		"Entries":        entries,
+		"NewChapterDays": newChapterDays,
+		"Title":          fmt.Sprintf("更新日志 - %s", novel.Title),
+	}
+
+	changelogPath := filepath.Join(g.config.OutputDir, "novels", g.sanitizeFileName(novel.Title), "changelog.html")
+	return g.renderTemplateToFile("changelog", changelogPath, data)
+}
+
+// printParseStats 输出小说的解析统计
+func (g *Generator) printParseStats(novel *parser.Novel) {
+	fmt.Printf("📊 %s: 共 %d 章\n", novel.Title, len(novel.Chapters))
+	if stats, ok := novel.ParseStats.(*parser.TxtParseStats); ok {
+		fmt.Print(stats.Table())
+	}
+}
+
+// generateSearchPage 生成搜索页面，页面列出全部小说与章节作为索引
+// 按 ?q= 筛选由页面脚本完成，未启用 JavaScript 时只显示完整列表
+func (g *Generator) generateSearchPage() error {
+	if !g.config.Search.Enabled {
+		os.Remove(filepath.Join(g.config.OutputDir, "search.html"))
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"Config":  g.config,
+		"Entries": g.buildSearchEntries(false),
+		"Title":   fmt.Sprintf("搜索 - %s", g.config.Site.Title),
+	}
+
+	return g.renderTemplate("search", "search.html", data)
+}
+
+// buildSearchEntries 构建搜索条目，withContent 为 true 时包含小说简介与章节正文开头
+func (g *Generator) buildSearchEntries(withContent bool) []map[string]interface{} {
 	searchData := make([]map[string]interface{}, 0)
 
 	for _, novel := range g.novels {
@@ -232,6 +653,9 @@ func (g *Generator) generateSearchData() error {
 			"description": novel.Description,
 			"url":         fmt.Sprintf("novels/%s/", g.sanitizeFileName(novel.Title)),
 		}
+		if withContent {
+			novelData["content"] = novel.Description
+		}
 		searchData = append(searchData, novelData)
 
 		for _, chapter := range novel.Chapters {
@@ -242,28 +666,27 @@ func (g *Generator) generateSearchData() error {
 				"author": novel.Author,
 				"url":    fmt.Sprintf("novels/%s/chapter-%d.html", g.sanitizeFileName(novel.Title), chapter.ID),
 			}
+			if withContent {
+				chapterData["content"] = chapterExcerpt(chapter, g.searchContentLength())
+			}
 			searchData = append(searchData, chapterData)
 		}
 	}
 
-	data, err := json.MarshalIndent(searchData, "", "  ")
-	if err != nil {
-		return fmt.Errorf("序列化搜索数据失败: %v", err)
-	}
-
-	searchPath := filepath.Join(g.config.OutputDir, "static", "js", "search-data.json")
-	return os.WriteFile(searchPath, data, 0644)
+	return searchData
 }
 
-// renderTemplate 渲染模板到默认位置
+// renderTemplate 渲染模板到输出目录下的 fileName，fileName 为相对输出目录的路径
 func (g *Generator) renderTemplate(templateName, fileName string, data interface{}) error {
 	outputPath := filepath.Join(g.config.OutputDir, fileName)
 	return g.renderTemplateToFile(templateName, outputPath, data)
 }
 
-// renderTemplateToFile 渲染模板到指定文件
+// renderTemplateToFile 渲染模板到指定文件，outputPath 已包含输出目录
 func (g *Generator) renderTemplateToFile(templateName, outputPath string, data interface{}) error {
+	g.templatesMu.RLock()
 	tmpl, exists := g.templates[templateName]
+	g.templatesMu.RUnlock()
 	if !exists {
 		return fmt.Errorf("模板 %s 不存在", templateName)
 	}
@@ -294,37 +717,79 @@ func (g *Generator) sanitizeFileName(name string) string {
 
 // Serve 启动本地服务器
 func (g *Generator) Serve(port int) error {
-	handler := http.FileServer(http.Dir(g.config.OutputDir))
-	http.Handle("/", handler)
+	handler := g.Handler(context.Background())
 
 	fmt.Printf("服务器运行在: http://localhost:%d\n", port)
 	fmt.Printf("按 Ctrl+C 停止服务器\n")
 
-	return http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
+	return http.ListenAndServe(fmt.Sprintf(":%d", port), handler)
+}
+
+// Handler 创建本地服务器的处理器，包括站点文件、JSON 接口与健康检查
+// 启用热重载时监听输入目录直到 ctx 结束
+func (g *Generator) Handler(ctx context.Context) http.Handler {
+	// 未生成过站点时加载小说数据供接口使用
+	if len(g.novelSnapshot()) == 0 {
+		g.buildMu.Lock()
+		if err := g.parseNovels(); err != nil {
+			fmt.Printf("警告：加载小说数据失败，接口将返回空数据: %v\n", err)
+		}
+		g.buildMu.Unlock()
+	}
+
+	g.stateMu.Lock()
+	g.startedAt = time.Now()
+	g.stateMu.Unlock()
+
+	mux := http.NewServeMux()
+	var site http.Handler = withContentTypes(http.FileServer(http.Dir(g.config.OutputDir)))
+	if g.liveReload {
+		hub := newReloadHub()
+		site = injectLiveReload(site)
+		mux.Handle(liveReloadPath, hub)
+		g.startLiveReload(ctx, hub)
+		fmt.Printf("🔁 已启用热重载，小说文件变化时自动重建并刷新浏览器\n")
+	}
+	mux.Handle("/", site)
+	g.registerAPIHandlers(mux)
+
+	return mux
+}
+
+// categoryNode 分类树节点
+type categoryNode struct {
+	name     string
+	novels   []*parser.Novel
+	children map[string]*categoryNode
+}
+
+// sortedCategoryNames 返回排序后的分类名称
+func sortedCategoryNames(nodes map[string]*categoryNode) []string {
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // generateCategoryPages 生成分类页面
 func (g *Generator) generateCategoryPages() error {
 	// 按分类组织小说
-	categoryMap := make(map[string][]*parser.Novel)
-	
-	for _, novel := range g.novels {
-		category := novel.Category
-		if category == "" {
-			category = "未分类"
-		}
-		categoryMap[category] = append(categoryMap[category], novel)
-	}
+	tree := g.buildNovelIndex().categories
+	names := sortedCategoryNames(tree)
 
 	// 生成分类列表页面
-	categories := make([]map[string]interface{}, 0)
-	for category, novels := range categoryMap {
+	categories := make([]map[string]interface{}, 0, len(names))
+	for _, category := range names {
+		node := tree[category]
 		categoryData := map[string]interface{}{
-			"name":        category,
-			"count":       len(novels),
-			"description": g.getCategoryDescription(category),
-			"color":       g.getCategoryColor(category),
-			"icon":        g.getCategoryIcon(category),
+			"name":           category,
+			"count":          len(node.novels),
+			"description":    g.getCategoryDescription(category),
+			"color":          g.getCategoryColor(category),
+			"icon":           g.getCategoryIcon(category),
+			"sub_categories": g.subCategoryList(node),
 		}
 		categories = append(categories, categoryData)
 	}
@@ -337,21 +802,23 @@ func (g *Generator) generateCategoryPages() error {
 		"Description": "按分类浏览所有小说",
 	}
 
-	if err := g.renderTemplate("category-list", filepath.Join(g.config.OutputDir, "categories.html"), categoryListData); err != nil {
+	if err := g.renderTemplate("category-list", "categories.html", categoryListData); err != nil {
 		return fmt.Errorf("生成分类列表页面失败: %v", err)
 	}
 
 	// 生成每个分类的详情页面
-	for category, novels := range categoryMap {
+	for _, category := range names {
+		node := tree[category]
 		categoryData := map[string]interface{}{
-			"Config":      g.config,
-			"Category":    category,
-			"Novels":      novels,
-			"Count":       len(novels),
-			"Description": g.getCategoryDescription(category),
-			"Color":       g.getCategoryColor(category),
-			"Icon":        g.getCategoryIcon(category),
-			"Title":       fmt.Sprintf("%s - 分类浏览", category),
+			"Config":        g.config,
+			"Category":      category,
+			"Novels":        node.novels,
+			"Count":         len(node.novels),
+			"Description":   g.getCategoryDescription(category),
+			"Color":         g.getCategoryColor(category),
+			"Icon":          g.getCategoryIcon(category),
+			"SubCategories": g.subCategoryList(node),
+			"Title":         fmt.Sprintf("%s - 分类浏览", category),
 		}
 
 		categoryPath := filepath.Join(g.config.OutputDir, "categories", fmt.Sprintf("%s.html", g.sanitizeFileName(category)))
@@ -361,27 +828,133 @@ func (g *Generator) generateCategoryPages() error {
 			return fmt.Errorf("创建分类目录失败: %v", err)
 		}
 
-		if err := g.renderTemplate("category", categoryPath, categoryData); err != nil {
+		if err := g.renderTemplateToFile("category", categoryPath, categoryData); err != nil {
 			return fmt.Errorf("生成分类 %s 页面失败: %v", category, err)
 		}
+
+		if err := g.generateSubCategoryPages(node); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// generateAuthorPages 生成作者页面
-func (g *Generator) generateAuthorPages() error {
-	// 按作者组织小说
-	authorMap := make(map[string][]*parser.Novel)
-	
+// generateSubCategoryPages 生成 categories/<分类>/<子分类>.html 页面
+func (g *Generator) generateSubCategoryPages(parent *categoryNode) error {
+	for _, sub := range sortedCategoryNames(parent.children) {
+		node := parent.children[sub]
+		subConfig := g.findSubCategoryConfig(parent.name, sub)
+
+		subData := map[string]interface{}{
+			"Config":      g.config,
+			"Parent":      parent.name,
+			"Category":    sub,
+			"Novels":      node.novels,
+			"Count":       len(node.novels),
+			"Description": subConfig.Description,
+			"Color":       subConfig.Color,
+			"Icon":        subConfig.Icon,
+			"Title":       fmt.Sprintf("%s - %s - 分类浏览", sub, parent.name),
+		}
+		if subData["Color"] == "" {
+			subData["Color"] = g.getCategoryColor(parent.name)
+		}
+		if subData["Icon"] == "" {
+			subData["Icon"] = g.getCategoryIcon(parent.name)
+		}
+
+		subPath := filepath.Join(g.config.OutputDir, "categories", g.sanitizeFileName(parent.name), fmt.Sprintf("%s.html", g.sanitizeFileName(sub)))
+		if err := os.MkdirAll(filepath.Dir(subPath), 0755); err != nil {
+			return fmt.Errorf("创建子分类目录失败: %v", err)
+		}
+
+		if err := g.renderTemplateToFile("category", subPath, subData); err != nil {
+			return fmt.Errorf("生成子分类 %s/%s 页面失败: %v", parent.name, sub, err)
+		}
+	}
+
+	return nil
+}
+
+// subCategoryList 生成子分类列表的模板数据
+func (g *Generator) subCategoryList(node *categoryNode) []map[string]interface{} {
+	subs := make([]map[string]interface{}, 0, len(node.children))
+	for _, sub := range sortedCategoryNames(node.children) {
+		subConfig := g.findSubCategoryConfig(node.name, sub)
+		subs = append(subs, map[string]interface{}{
+			"name":        sub,
+			"count":       len(node.children[sub].novels),
+			"description": subConfig.Description,
+			"icon":        subConfig.Icon,
+			"url":         fmt.Sprintf("categories/%s/%s.html", g.sanitizeFileName(node.name), g.sanitizeFileName(sub)),
+		})
+	}
+	return subs
+}
+
+// findCategoryConfig 查找配置中预定义的分类
+func (g *Generator) findCategoryConfig(category string) *config.Category {
+	for i := range g.config.Site.Categories {
+		if g.config.Site.Categories[i].Name == category {
+			return &g.config.Site.Categories[i]
+		}
+	}
+	return nil
+}
+
+// findSubCategoryConfig 查找配置中预定义的子分类，未定义时返回只有名称的配置
+func (g *Generator) findSubCategoryConfig(category, sub string) config.Category {
+	if parent := g.findCategoryConfig(category); parent != nil {
+		for _, subConfig := range parent.SubCategories {
+			if subConfig.Name == sub {
+				return subConfig
+			}
+		}
+	}
+	return config.Category{Name: sub}
+}
+
+// generateGenrePages 生成题材页面
+func (g *Generator) generateGenrePages() error {
+	// 按题材组织小说，一部小说可属于多个题材
+	genreMap := make(map[string][]*parser.Novel)
+
 	for _, novel := range g.novels {
-		author := novel.Author
-		if author == "" {
-			author = "未知作者"
+		for _, genre := range novel.Genre {
+			genreMap[genre] = append(genreMap[genre], novel)
+		}
+	}
+
+	for genre, novels := range genreMap {
+		genreData := map[string]interface{}{
+			"Config": g.config,
+			"Genre":  genre,
+			"Novels": novels,
+			"Count":  len(novels),
+			"Title":  fmt.Sprintf("%s - 题材", genre),
+		}
+
+		genrePath := filepath.Join(g.config.OutputDir, "genres", fmt.Sprintf("%s.html", g.sanitizeFileName(genre)))
+
+		// 确保目录存在
+		if err := os.MkdirAll(filepath.Dir(genrePath), 0755); err != nil {
+			return fmt.Errorf("创建题材目录失败: %v", err)
+		}
+
+		if err := g.renderTemplateToFile("genre", genrePath, genreData); err != nil {
+			return fmt.Errorf("生成题材 %s 页面失败: %v", genre, err)
 		}
-		authorMap[author] = append(authorMap[author], novel)
 	}
 
+	return nil
+}
+
+// generateAuthorPages 生成作者页面
+func (g *Generator) generateAuthorPages() error {
+	// 按作者组织小说
+	authorMap := g.buildNovelIndex().authors
+
 	// 生成作者列表页面
 	authors := make([]map[string]interface{}, 0)
 	for author, novels := range authorMap {
@@ -402,7 +975,7 @@ func (g *Generator) generateAuthorPages() error {
 		"Description": "按作者浏览所有作品",
 	}
 
-	if err := g.renderTemplate("author-list", filepath.Join(g.config.OutputDir, "authors.html"), authorListData); err != nil {
+	if err := g.renderTemplate("author-list", "authors.html", authorListData); err != nil {
 		return fmt.Errorf("生成作者列表页面失败: %v", err)
 	}
 
@@ -415,6 +988,7 @@ func (g *Generator) generateAuthorPages() error {
 			"Count":       len(novels),
 			"TotalWords":  g.calculateTotalWords(novels),
 			"LastUpdated": g.getLastUpdated(novels),
+			"Stats":       g.getAuthorStats(author, novels),
 			"Title":       fmt.Sprintf("%s - 作者作品", author),
 		}
 
@@ -425,7 +999,7 @@ func (g *Generator) generateAuthorPages() error {
 			return fmt.Errorf("创建作者目录失败: %v", err)
 		}
 
-		if err := g.renderTemplate("author", authorPath, authorData); err != nil {
+		if err := g.renderTemplateToFile("author", authorPath, authorData); err != nil {
 			return fmt.Errorf("生成作者 %s 页面失败: %v", author, err)
 		}
 	}
@@ -435,6 +1009,10 @@ func (g *Generator) generateAuthorPages() error {
 
 // getCategoryDescription 获取分类描述
 func (g *Generator) getCategoryDescription(category string) string {
+	if c := g.findCategoryConfig(category); c != nil && c.Description != "" {
+		return c.Description
+	}
+
 	descriptions := map[string]string{
 		"科幻":     "探索未来科技与宇宙奥秘的科幻小说",
 		"现代":     "反映现代都市生活的现实主义小说",
@@ -450,6 +1028,10 @@ func (g *Generator) getCategoryDescription(category string) string {
 
 // getCategoryColor 获取分类颜色
 func (g *Generator) getCategoryColor(category string) string {
+	if c := g.findCategoryConfig(category); c != nil && c.Color != "" {
+		return c.Color
+	}
+
 	colors := map[string]string{
 		"科幻":   "#3498db",
 		"现代":   "#2ecc71",
@@ -465,6 +1047,10 @@ func (g *Generator) getCategoryColor(category string) string {
 
 // getCategoryIcon 获取分类图标
 func (g *Generator) getCategoryIcon(category string) string {
+	if c := g.findCategoryConfig(category); c != nil && c.Icon != "" {
+		return c.Icon
+	}
+
 	icons := map[string]string{
 		"科幻":   "🚀",
 		"现代":   "🏢",
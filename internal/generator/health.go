@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"net/http"
+	"time"
+)
+
+// Version Creeper 版本号
+const Version = "1.0.0"
+
+// handleHealth 处理 GET /health，服务器存活即返回 ok
+func (g *Generator) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	g.stateMu.RLock()
+	startedAt := g.startedAt
+	g.stateMu.RUnlock()
+
+	g.writeJSON(w, map[string]string{
+		"status":  "ok",
+		"uptime":  time.Since(startedAt).Round(time.Second).String(),
+		"version": Version,
+	})
+}
+
+// handleReady 处理 GET /ready，最近一次生成失败时返回 503
+func (g *Generator) handleReady(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	g.stateMu.RLock()
+	lastError := g.lastError
+	g.stateMu.RUnlock()
+
+	if lastError != nil {
+		g.writeJSONStatus(w, http.StatusServiceUnavailable, map[string]string{
+			"status": "error",
+			"error":  lastError.Error(),
+		})
+		return
+	}
+
+	g.writeJSON(w, map[string]string{"status": "ready"})
+}
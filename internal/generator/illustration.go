@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"creeper/internal/parser"
+)
+
+// illustrationDir 插图目录（相对项目根目录与输出目录）
+var illustrationDir = filepath.Join("static", "images", "illustrations")
+
+// illustrationExtensions 支持的插图格式，按优先级排列
+var illustrationExtensions = []string{".png", ".jpg", ".jpeg", ".webp", ".gif", ".svg"}
+
+// embedIllustrations 为章节中的插图占位嵌入图片
+// 仅处理带说明文字的标记，图片需以说明文字命名放在 static/images/illustrations/ 下
+func (g *Generator) embedIllustrations(chapter *parser.Chapter) error {
+	for _, marker := range chapter.IllustrationMarkers {
+		if marker.Label == "" {
+			continue
+		}
+
+		source := g.findIllustration(marker.Label)
+		if source == "" {
+			continue
+		}
+
+		fileName := filepath.Base(source)
+		target := filepath.Join(g.config.OutputDir, illustrationDir, fileName)
+		g.illustrationMu.Lock()
+		err := copyFile(source, target)
+		g.illustrationMu.Unlock()
+		if err != nil {
+			return fmt.Errorf("复制插图 %s 失败: %v", source, err)
+		}
+
+		anchor := fmt.Sprintf(`data-illustration="%s">`, html.EscapeString(marker.Label))
+		if strings.Contains(chapter.HTMLContent, anchor+"<img") {
+			// 重复生成时已嵌入
+			continue
+		}
+		img := fmt.Sprintf(`<img src="%sstatic/images/illustrations/%s" alt="%s" loading="lazy">`,
+			g.config.Site.BaseURL, html.EscapeString(fileName), html.EscapeString(marker.Label))
+		chapter.HTMLContent = strings.Replace(chapter.HTMLContent, anchor, anchor+img, 1)
+	}
+
+	return nil
+}
+
+// findIllustration 查找与标记同名的插图文件，未找到时返回空字符串
+func (g *Generator) findIllustration(label string) string {
+	name := filepath.Base(filepath.Clean(label))
+	if name == "." || name == string(filepath.Separator) {
+		return ""
+	}
+
+	// 与封面一致，先在输入目录的上级查找，再在项目根目录查找
+	roots := []string{filepath.Join(g.config.InputDir, ".."), "."}
+	for _, root := range roots {
+		for _, ext := range illustrationExtensions {
+			path := filepath.Join(root, illustrationDir, name+ext)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// copyFile 复制文件，目标目录不存在时自动创建
+func copyFile(source, target string) error {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0644)
+}
@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BrokenLink 失效的站内链接
+type BrokenLink struct {
+	File   string // 所在文件（相对输出目录）
+	Line   int    // 所在行号
+	Link   string // 链接原文
+	Target string // 解析后的目标文件
+}
+
+// String 格式化输出
+func (bl BrokenLink) String() string {
+	return fmt.Sprintf("%s:%d: %s (未找到 %s)", bl.File, bl.Line, bl.Link, bl.Target)
+}
+
+// linkAttrRegex 匹配 href 与 src 属性
+var linkAttrRegex = regexp.MustCompile(`(?i)(?:^|\s)(?:href|src)\s*=\s*["']([^"']*)["']`)
+
+// CheckLinks 检查输出目录中所有 HTML 文件的站内链接
+// baseURL 为站点根路径，以其开头的链接按输出目录根解析
+func CheckLinks(outputDir, baseURL string) ([]BrokenLink, error) {
+	var files []string
+	err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".html") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("遍历输出目录失败: %v", err)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		broken   []BrokenLink
+		firstErr error
+	)
+
+	jobs := make(chan string)
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				links, err := checkFileLinks(outputDir, baseURL, path)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				broken = append(broken, links...)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, path := range files {
+		jobs <- path
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(broken, func(i, j int) bool {
+		if broken[i].File != broken[j].File {
+			return broken[i].File < broken[j].File
+		}
+		return broken[i].Line < broken[j].Line
+	})
+	return broken, nil
+}
+
+// checkFileLinks 检查单个 HTML 文件中的链接
+func checkFileLinks(outputDir, baseURL, path string) ([]BrokenLink, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开文件 %s 失败: %v", path, err)
+	}
+	defer file.Close()
+
+	relFile, _ := filepath.Rel(outputDir, path)
+	var broken []BrokenLink
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		for _, match := range linkAttrRegex.FindAllStringSubmatch(scanner.Text(), -1) {
+			target, ok := resolveInternalLink(outputDir, baseURL, filepath.Dir(path), match[1])
+			if !ok || linkTargetExists(target) {
+				continue
+			}
+			relTarget, _ := filepath.Rel(outputDir, target)
+			broken = append(broken, BrokenLink{
+				File:   relFile,
+				Line:   line,
+				Link:   match[1],
+				Target: relTarget,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取文件 %s 失败: %v", path, err)
+	}
+
+	return broken, nil
+}
+
+// resolveInternalLink 将站内链接解析为输出目录中的文件路径，外部链接返回 false
+func resolveInternalLink(outputDir, baseURL, fileDir, link string) (string, bool) {
+	link = strings.TrimSpace(link)
+	if link == "" || strings.HasPrefix(link, "#") || strings.HasPrefix(link, "//") {
+		return "", false
+	}
+
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return "", false
+	}
+
+	p := u.Path
+	if p == "" {
+		return "", false
+	}
+
+	var target string
+	switch {
+	case baseURL != "" && strings.HasPrefix(p, baseURL):
+		target = filepath.Join(outputDir, filepath.FromSlash(strings.TrimPrefix(p, baseURL)))
+	case strings.HasPrefix(p, "/"):
+		target = filepath.Join(outputDir, filepath.FromSlash(p))
+	default:
+		target = filepath.Join(fileDir, filepath.FromSlash(p))
+	}
+
+	if strings.HasSuffix(p, "/") {
+		target = filepath.Join(target, "index.html")
+	}
+	return target, true
+}
+
+// linkTargetExists 目标文件或带 index.html 的目录是否存在
+func linkTargetExists(target string) bool {
+	info, err := os.Stat(target)
+	if err != nil {
+		return false
+	}
+	if info.IsDir() {
+		_, err := os.Stat(filepath.Join(target, "index.html"))
+		return err == nil
+	}
+	return true
+}
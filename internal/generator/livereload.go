@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// liveReloadPath Server-Sent Events 端点
+const liveReloadPath = "/sse"
+
+// liveReloadKeepAlive SSE 心跳间隔，避免代理或浏览器断开空闲连接
+const liveReloadKeepAlive = 30 * time.Second
+
+// liveReloadScript 注入到每个 HTML 页面的脚本，收到 reload 事件后刷新页面
+const liveReloadScript = `<script>(function(){if(!window.EventSource)return;var es=new EventSource('` + liveReloadPath + `');es.addEventListener('reload',function(){es.close();location.reload();});})();</script>`
+
+// liveReloadExtensions 触发重建的小说文件扩展名
+var liveReloadExtensions = []string{".md", ".txt", ".docx"}
+
+// SetLiveReload 设置本地服务器是否启用热重载
+// 启用后 Serve 会监听输入目录，小说文件变化时增量重建并通知浏览器刷新
+func (g *Generator) SetLiveReload(liveReload bool) {
+	g.liveReload = liveReload
+}
+
+// reloadHub 管理连接到 SSE 端点的浏览器
+type reloadHub struct {
+	mu      sync.Mutex
+	clients map[chan struct{}]bool
+
+	// done 监听结束后关闭，断开浏览器连接，使其重新连接到新的处理器
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newReloadHub 创建 reloadHub
+func newReloadHub() *reloadHub {
+	return &reloadHub{clients: make(map[chan struct{}]bool), done: make(chan struct{})}
+}
+
+// close 断开所有浏览器连接
+func (h *reloadHub) close() {
+	h.closeOnce.Do(func() { close(h.done) })
+}
+
+// subscribe 注册浏览器连接
+func (h *reloadHub) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	h.clients[ch] = true
+	h.mu.Unlock()
+	return ch
+}
+
+// unsubscribe 移除浏览器连接
+func (h *reloadHub) unsubscribe(ch chan struct{}) {
+	h.mu.Lock()
+	delete(h.clients, ch)
+	h.mu.Unlock()
+}
+
+// broadcast 通知所有浏览器刷新，尚未处理上一次通知的连接不会重复排队
+func (h *reloadHub) broadcast() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ServeHTTP 处理 GET /sse，保持连接并在重建完成后发送 reload 事件
+func (h *reloadHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+
+	ticker := time.NewTicker(liveReloadKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.done:
+			return
+		case <-ch:
+			fmt.Fprint(w, "event: reload\ndata: {}\n\n")
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// startLiveReload 监听输入目录（以及配置的模板目录），变化时重建并通知浏览器
+func (g *Generator) startLiveReload(ctx context.Context, hub *reloadHub) {
+	options := WatchOptions{
+		Input:           true,
+		Templates:       g.config.Theme.TemplateDir != "",
+		InputExtensions: liveReloadExtensions,
+		OnRebuild:       hub.broadcast,
+	}
+
+	go func() {
+		if err := g.Watch(ctx, options); err != nil {
+			fmt.Printf("⚠️  热重载监听失败: %v\n", err)
+		}
+		// 处理器被替换（如重新加载配置）后断开旧连接
+		if ctx.Err() != nil {
+			hub.close()
+		}
+	}()
+}
+
+// injectLiveReload 在 HTML 响应的 </body> 前插入热重载脚本
+func injectLiveReload(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// 不让浏览器发送条件请求，304 响应没有可注入的内容
+		r.Header.Del("If-Modified-Since")
+		r.Header.Del("If-None-Match")
+		r.Header.Del("Range")
+
+		rec := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		body := rec.body.Bytes()
+		if strings.HasPrefix(rec.header.Get("Content-Type"), "text/html") {
+			if i := bytes.LastIndex(body, []byte("</body>")); i >= 0 {
+				body = append(body[:i:i], append([]byte(liveReloadScript), body[i:]...)...)
+			} else {
+				body = append(body, liveReloadScript...)
+			}
+			rec.header.Set("Content-Length", strconv.Itoa(len(body)))
+			rec.header.Set("Cache-Control", "no-cache")
+		}
+
+		for key, values := range rec.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(rec.status)
+		w.Write(body)
+	})
+}
+
+// bufferedResponse 缓存响应内容，供注入脚本后再写出
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	return b.body.Write(data)
+}
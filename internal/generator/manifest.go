@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"creeper/internal/parser"
+)
+
+// buildManifestFile 增量构建清单文件名（位于输出目录），部署时由 deploy 包跳过
+const buildManifestFile = ".creeper-build-manifest.json"
+
+// buildManifestVersion 清单格式版本
+const buildManifestVersion = 1
+
+// buildManifest 增量构建清单，记录每部小说源文件的哈希与生成的文件
+type buildManifest struct {
+	Version int `json:"version"`
+	// Fingerprint 配置、自定义模板与生成器程序的指纹，变化时所有小说都需要重新生成
+	Fingerprint string                   `json:"fingerprint"`
+	Novels      map[string]manifestEntry `json:"novels"`
+}
+
+// manifestEntry 单部小说的构建记录
+type manifestEntry struct {
+	Hash    string   `json:"hash"`
+	Outputs []string `json:"outputs"` // 相对输出目录的路径
+}
+
+// newBuildManifest 创建空的构建清单
+func newBuildManifest(fingerprint string) *buildManifest {
+	return &buildManifest{
+		Version:     buildManifestVersion,
+		Fingerprint: fingerprint,
+		Novels:      make(map[string]manifestEntry),
+	}
+}
+
+// loadBuildManifest 读取上次构建的清单
+// 清单不存在、无法解析或指纹不一致时返回空清单，即所有小说都重新生成
+func (g *Generator) loadBuildManifest(fingerprint string) *buildManifest {
+	data, err := os.ReadFile(filepath.Join(g.config.OutputDir, buildManifestFile))
+	if err != nil {
+		return newBuildManifest(fingerprint)
+	}
+
+	var manifest buildManifest
+	if err := json.Unmarshal(data, &manifest); err != nil ||
+		manifest.Version != buildManifestVersion || manifest.Fingerprint != fingerprint || manifest.Novels == nil {
+		return newBuildManifest(fingerprint)
+	}
+	return &manifest
+}
+
+// saveBuildManifest 保存构建清单
+func (g *Generator) saveBuildManifest(manifest *buildManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化构建清单失败: %v", err)
+	}
+	return os.WriteFile(filepath.Join(g.config.OutputDir, buildManifestFile), data, 0644)
+}
+
+// isUpToDate 小说源文件未变化且上次生成的文件都还在时返回 true
+func (m *buildManifest) isUpToDate(source, hash, outputDir string) bool {
+	entry, exists := m.Novels[source]
+	if !exists || entry.Hash != hash || len(entry.Outputs) == 0 {
+		return false
+	}
+	for _, output := range entry.Outputs {
+		if _, err := os.Stat(filepath.Join(outputDir, filepath.FromSlash(output))); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// buildFingerprint 计算影响所有页面的输入指纹：配置、自定义模板文件与生成器程序本身
+func (g *Generator) buildFingerprint() string {
+	h := sha256.New()
+
+	if data, err := json.Marshal(g.config); err == nil {
+		h.Write(data)
+	}
+
+	if dir := g.config.Theme.TemplateDir; dir != "" {
+		hashTree(h, dir)
+	}
+
+	// 内置模板编译在程序中，程序更新后需要完整重建
+	if exe, err := os.Executable(); err == nil {
+		if info, err := os.Stat(exe); err == nil {
+			fmt.Fprintf(h, "%s:%d:%d", exe, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// novelSourceHash 计算小说的内容哈希
+// 包括小说源文件（单个文件或整个目录），以及位于小说目录之外的封面与插图文件
+func (g *Generator) novelSourceHash(novel *parser.Novel) (string, error) {
+	h := sha256.New()
+	if err := hashTree(h, novel.Path); err != nil {
+		return "", err
+	}
+
+	var extra []string
+	coverPath := novel.Cover
+	if coverPath == "" {
+		coverPath = "static/images/default-cover.svg"
+	}
+	for _, candidate := range []string{filepath.Join(g.config.InputDir, "..", coverPath), coverPath} {
+		if _, err := os.Stat(candidate); err == nil {
+			extra = append(extra, candidate)
+			break
+		}
+	}
+	for _, chapter := range novel.Chapters {
+		for _, marker := range chapter.IllustrationMarkers {
+			if marker.Label == "" {
+				continue
+			}
+			if source := g.findIllustration(marker.Label); source != "" {
+				extra = append(extra, source)
+			}
+		}
+	}
+	for _, path := range extra {
+		if err := hashTree(h, path); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashTree 将文件或目录下所有非隐藏文件的相对路径与内容写入哈希
+func hashTree(h io.Writer, root string) error {
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		rel, _ := filepath.Rel(root, path)
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(h, file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+		h.Write([]byte{0})
+	}
+	return nil
+}
+
+// listOutputs 列出目录下生成的文件（相对输出目录）
+func (g *Generator) listOutputs(dir string) []string {
+	var outputs []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		if rel, err := filepath.Rel(g.config.OutputDir, path); err == nil {
+			outputs = append(outputs, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(outputs)
+	return outputs
+}
+
+// removeStaleNovelDirs 删除输出目录中已不存在对应小说的目录
+func (g *Generator) removeStaleNovelDirs(keep map[string]bool) error {
+	novelsDir := filepath.Join(g.config.OutputDir, "novels")
+	entries, err := os.ReadDir(novelsDir)
+	if err != nil {
+		return nil
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || keep[entry.Name()] {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(novelsDir, entry.Name())); err != nil {
+			return fmt.Errorf("删除过期的小说目录 %s 失败: %v", entry.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsUpToDate(t *testing.T) {
+	outputDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(outputDir, "novels", "a"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "novels", "a", "index.html"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := newBuildManifest("fp")
+	manifest.Novels["a.md"] = manifestEntry{Hash: "h1", Outputs: []string{"novels/a/index.html"}}
+	manifest.Novels["missing.md"] = manifestEntry{Hash: "h1", Outputs: []string{"novels/a/index.html", "novels/a/chapter-1.html"}}
+	manifest.Novels["empty.md"] = manifestEntry{Hash: "h1"}
+
+	tests := []struct {
+		name   string
+		source string
+		hash   string
+		want   bool
+	}{
+		{"未变化且输出都在", "a.md", "h1", true},
+		{"哈希变化", "a.md", "h2", false},
+		{"清单中没有记录", "new.md", "h1", false},
+		{"输出文件缺失", "missing.md", "h1", false},
+		{"没有记录输出", "empty.md", "h1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := manifest.isUpToDate(tt.source, tt.hash, outputDir); got != tt.want {
+				t.Errorf("isUpToDate(%q, %q) = %v, want %v", tt.source, tt.hash, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildManifestRoundTrip(t *testing.T) {
+	g := newTestGenerator(t, nil)
+	if err := os.MkdirAll(g.config.OutputDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := newBuildManifest("fp")
+	manifest.Novels["a.md"] = manifestEntry{Hash: "h1", Outputs: []string{"novels/a/index.html"}}
+	if err := g.saveBuildManifest(manifest); err != nil {
+		t.Fatalf("saveBuildManifest() error = %v", err)
+	}
+
+	if got := g.loadBuildManifest("fp"); !reflect.DeepEqual(got, manifest) {
+		t.Errorf("loadBuildManifest() = %+v, want %+v", got, manifest)
+	}
+	if got := g.loadBuildManifest("other"); len(got.Novels) != 0 || got.Fingerprint != "other" {
+		t.Errorf("指纹不一致时 loadBuildManifest() = %+v, want 空清单", got)
+	}
+
+	manifest.Version = buildManifestVersion + 1
+	if err := g.saveBuildManifest(manifest); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.loadBuildManifest("fp"); len(got.Novels) != 0 {
+		t.Errorf("版本不一致时 loadBuildManifest() = %+v, want 空清单", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(g.config.OutputDir, buildManifestFile), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := g.loadBuildManifest("fp"); len(got.Novels) != 0 {
+		t.Errorf("清单无法解析时 loadBuildManifest() = %+v, want 空清单", got)
+	}
+}
+
+// writeMarker 在小说输出目录中写入标记文件，目录被清理重建后标记消失
+func writeMarker(t *testing.T, g *Generator, slug string) string {
+	t.Helper()
+	path := filepath.Join(g.config.OutputDir, "novels", slug, "marker")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestIncrementalBuildSkipsUnchangedNovels(t *testing.T) {
+	g := newTestGenerator(t, map[string]string{
+		"a.md": markdownNovel("小说甲", 2),
+		"b.md": markdownNovel("小说乙", 2),
+	})
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	unchanged := writeMarker(t, g, g.sanitizeFileName("小说甲"))
+	changed := writeMarker(t, g, g.sanitizeFileName("小说乙"))
+	if err := os.WriteFile(filepath.Join(g.config.InputDir, "b.md"), []byte(markdownNovel("小说乙", 3)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if _, err := os.Stat(unchanged); err != nil {
+		t.Errorf("未变化的小说被重新生成: %v", err)
+	}
+	if _, err := os.Stat(changed); err == nil {
+		t.Errorf("变化的小说没有重新生成")
+	}
+}
+
+func TestIncrementalBuildSameSlugNovels(t *testing.T) {
+	g := newTestGenerator(t, map[string]string{
+		"a.md": markdownNovel("同名小说", 4),
+		"b.md": markdownNovel("同名小说", 2),
+	})
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// 同组小说记录的输出都应存在，否则每次构建都会互相删除输出并重新生成
+	manifest := g.loadBuildManifest(g.buildFingerprint())
+	for _, novel := range g.novelSnapshot() {
+		hash, err := g.novelSourceHash(novel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !manifest.isUpToDate(novel.Path, hash, g.config.OutputDir) {
+			t.Errorf("首次构建后小说 %s 的记录已过期: %+v", novel.Path, manifest.Novels[novel.Path])
+		}
+	}
+
+	marker := writeMarker(t, g, g.sanitizeFileName("同名小说"))
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("未变化的同名小说被重新生成: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(g.config.InputDir, "a.md"), []byte(markdownNovel("同名小说", 5)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Errorf("同组小说变化后没有重新生成")
+	}
+	manifest = g.loadBuildManifest(g.buildFingerprint())
+	for _, novel := range g.novelSnapshot() {
+		hash, _ := g.novelSourceHash(novel)
+		if !manifest.isUpToDate(novel.Path, hash, g.config.OutputDir) {
+			t.Errorf("重新生成后小说 %s 的记录已过期", novel.Path)
+		}
+	}
+}
+
+func TestDisabledFeedAndSitemapRemoved(t *testing.T) {
+	g := newTestGenerator(t, map[string]string{"a.md": markdownNovel("小说甲", 2)})
+	g.config.Sitemap.MaxPerFile = 2
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, name := range []string{"feed.xml", "sitemap.xml", "sitemap-1.xml"} {
+		if _, err := os.Stat(filepath.Join(g.config.OutputDir, name)); err != nil {
+			t.Fatalf("启用时没有生成 %s: %v", name, err)
+		}
+	}
+
+	g.config.Feed.Enabled = false
+	g.config.Sitemap.Enabled = false
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, name := range []string{"feed.xml", "sitemap.xml", "sitemap-1.xml", "novels/" + g.sanitizeFileName("小说甲") + "/feed.xml"} {
+		if _, err := os.Stat(filepath.Join(g.config.OutputDir, filepath.FromSlash(name))); err == nil {
+			t.Errorf("关闭后 %s 仍然存在", name)
+		}
+	}
+}
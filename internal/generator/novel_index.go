@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"strings"
+
+	"creeper/internal/parser"
+)
+
+// NovelIndex 按分类、作者与标签组织的小说索引，供分类、作者、标签页面共用
+type NovelIndex struct {
+	// categories 分类树，子分类中的小说同时计入其父分类；没有分类的小说归入“未分类”
+	categories map[string]*categoryNode
+	// authors 作者对应的小说，没有作者的小说归入“未知作者”
+	authors map[string][]*parser.Novel
+	// tags 标签对应的小说，一部小说可属于多个标签，同一小说的重复标签只计一次
+	tags map[string][]*parser.Novel
+}
+
+// buildNovelIndex 遍历一次小说列表建立索引，结果缓存到下次解析小说
+func (g *Generator) buildNovelIndex() *NovelIndex {
+	g.novelIndexMu.Lock()
+	defer g.novelIndexMu.Unlock()
+
+	if g.novelIndex != nil {
+		return g.novelIndex
+	}
+
+	index := &NovelIndex{
+		categories: make(map[string]*categoryNode),
+		authors:    make(map[string][]*parser.Novel),
+		tags:       make(map[string][]*parser.Novel),
+	}
+	for _, novel := range g.novels {
+		index.addCategory(novel)
+
+		author := novel.Author
+		if author == "" {
+			author = "未知作者"
+		}
+		index.authors[author] = append(index.authors[author], novel)
+
+		seen := make(map[string]bool)
+		for _, tag := range novel.Tags {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			index.tags[tag] = append(index.tags[tag], novel)
+		}
+	}
+
+	g.novelIndex = index
+	return index
+}
+
+// resetNovelIndex 丢弃缓存的索引，小说列表变化后调用
+func (g *Generator) resetNovelIndex() {
+	g.novelIndexMu.Lock()
+	g.novelIndex = nil
+	g.novelIndexMu.Unlock()
+}
+
+// addCategory 将小说加入分类树
+func (index *NovelIndex) addCategory(novel *parser.Novel) {
+	category := novel.Category
+	if category == "" {
+		category = "未分类"
+	}
+
+	node, exists := index.categories[category]
+	if !exists {
+		node = &categoryNode{name: category, children: make(map[string]*categoryNode)}
+		index.categories[category] = node
+	}
+	node.novels = append(node.novels, novel)
+
+	if novel.SubCategory == "" {
+		return
+	}
+	child, exists := node.children[novel.SubCategory]
+	if !exists {
+		child = &categoryNode{name: novel.SubCategory}
+		node.children[novel.SubCategory] = child
+	}
+	child.novels = append(child.novels, novel)
+}
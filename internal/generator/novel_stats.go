@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"strings"
+
+	"creeper/internal/parser"
+)
+
+// statsReadingWPM 统计页估算阅读时间使用的阅读速度（字/分钟）
+const statsReadingWPM = 300
+
+// generateNovelStats 生成 novels/<标题>/stats.html：章节字数柱状图与字数统计
+func (g *Generator) generateNovelStats(novel *parser.Novel) error {
+	data := map[string]interface{}{
+		"Config": g.config,
+		"Novel":  novel,
+		"Title":  fmt.Sprintf("阅读统计 - %s", novel.Title),
+		"Chart":  g.chapterWordChart(novel.Chapters),
+	}
+	for key, value := range novelWordStats(novel.Chapters) {
+		data[key] = value
+	}
+
+	statsPath := filepath.Join(g.config.OutputDir, "novels", g.sanitizeFileName(novel.Title), "stats.html")
+	return g.renderTemplateToFile("novel-stats", statsPath, data)
+}
+
+// novelWordStats 计算总字数、章节数、平均/最短/最长章节字数与按 300 字/分钟估算的阅读时间
+func novelWordStats(chapters []*parser.Chapter) map[string]interface{} {
+	stats := map[string]interface{}{
+		"ChapterCount": len(chapters),
+	}
+	if len(chapters) == 0 {
+		return stats
+	}
+
+	total := 0
+	shortest, longest := chapters[0], chapters[0]
+	for _, chapter := range chapters {
+		total += chapter.WordCount
+		if chapter.WordCount < shortest.WordCount {
+			shortest = chapter
+		}
+		if chapter.WordCount > longest.WordCount {
+			longest = chapter
+		}
+	}
+
+	minutes := (total + statsReadingWPM - 1) / statsReadingWPM
+	stats["TotalWords"] = total
+	stats["AverageWords"] = total / len(chapters)
+	stats["ShortestChapter"] = shortest
+	stats["LongestChapter"] = longest
+	stats["ReadingHours"] = minutes / 60
+	stats["ReadingMinutes"] = minutes % 60
+	stats["ReadingWPM"] = statsReadingWPM
+	return stats
+}
+
+// chapterWordChart 生成每章字数的 SVG 柱状图，鼠标悬停显示章节标题与字数
+func (g *Generator) chapterWordChart(chapters []*parser.Chapter) template.HTML {
+	const (
+		height    = 200
+		axisSpace = 20
+		barStep   = 24
+		minWidth  = 240
+		maxWidth  = 800
+	)
+
+	maxWords := 0
+	for _, chapter := range chapters {
+		if chapter.WordCount > maxWords {
+			maxWords = chapter.WordCount
+		}
+	}
+	if maxWords == 0 {
+		return ""
+	}
+
+	// 每章约 24 像素宽，图表宽度限制在 240～800 像素之间
+	width := float64(barStep * len(chapters))
+	if width < minWidth {
+		width = minWidth
+	} else if width > maxWidth {
+		width = maxWidth
+	}
+	step := width / float64(len(chapters))
+	barWidth := step * 0.8
+	barSpace := float64(height - axisSpace)
+	color := template.HTMLEscapeString(g.config.Theme.PrimaryColor)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg class="chapter-word-chart" width="%.0f" height="%d" viewBox="0 0 %.0f %d" role="img" aria-label="每章字数">`,
+		width, height, width, height))
+	for i, chapter := range chapters {
+		barHeight := barSpace * float64(chapter.WordCount) / float64(maxWords)
+		sb.WriteString(fmt.Sprintf(`<a href="chapter-%d.html"><rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s：%s</title></rect></a>`,
+			chapter.ID, float64(i)*step, barSpace-barHeight, barWidth, barHeight, color,
+			template.HTMLEscapeString(chapter.Title), g.formatWordCount(chapter.WordCount)))
+	}
+	sb.WriteString(fmt.Sprintf(`<line x1="0" y1="%.0f" x2="%.0f" y2="%.0f" stroke="currentColor" stroke-opacity="0.3"/>`, barSpace, width, barSpace))
+	sb.WriteString(fmt.Sprintf(`<text x="0" y="%d" font-size="12" fill="currentColor">第 1 章</text>`, height-4))
+	if len(chapters) > 1 {
+		sb.WriteString(fmt.Sprintf(`<text x="%.0f" y="%d" font-size="12" fill="currentColor" text-anchor="end">第 %d 章</text>`, width, height-4, len(chapters)))
+	}
+	sb.WriteString(`</svg>`)
+
+	return template.HTML(sb.String())
+}
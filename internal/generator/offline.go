@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// generateServiceWorker 生成离线阅读使用的 Service Worker
+// sw.js 位于站点根目录，作用域覆盖整个站点；离线保存的章节存放在 IndexedDB 中，
+// 与 reading-enhanced.js 中的 OfflineQueue 共用同一个数据库
+func (g *Generator) generateServiceWorker() error {
+	js := `// Creeper 离线阅读 Service Worker
+const OFFLINE_DB_NAME = 'creeper-offline';
+const OFFLINE_DB_VERSION = 1;
+const OFFLINE_STORE = 'chapters';
+const ASSET_CACHE = 'creeper-assets-v1';
+
+self.addEventListener('install', () => self.skipWaiting());
+self.addEventListener('activate', event => event.waitUntil(self.clients.claim()));
+
+function openOfflineDB() {
+    return new Promise((resolve, reject) => {
+        const request = indexedDB.open(OFFLINE_DB_NAME, OFFLINE_DB_VERSION);
+        request.onupgradeneeded = () => {
+            const db = request.result;
+            if (!db.objectStoreNames.contains(OFFLINE_STORE)) {
+                db.createObjectStore(OFFLINE_STORE, { keyPath: 'url' });
+            }
+        };
+        request.onsuccess = () => resolve(request.result);
+        request.onerror = () => reject(request.error);
+    });
+}
+
+// getSavedChapter 查找离线保存的章节，读取失败时视为未保存
+function getSavedChapter(path) {
+    return openOfflineDB().then(db => new Promise((resolve, reject) => {
+        const request = db.transaction(OFFLINE_STORE, 'readonly').objectStore(OFFLINE_STORE).get(path);
+        request.onsuccess = () => resolve(request.result);
+        request.onerror = () => reject(request.error);
+    })).catch(() => null);
+}
+
+// 样式与脚本优先使用网络，离线时回退到缓存，保证离线章节的排版
+function fetchAsset(request) {
+    return fetch(request).then(response => {
+        if (response.ok) {
+            const copy = response.clone();
+            caches.open(ASSET_CACHE).then(cache => cache.put(request, copy));
+        }
+        return response;
+    }).catch(() => caches.match(request).then(cached => cached || Response.error()));
+}
+
+self.addEventListener('fetch', event => {
+    const request = event.request;
+    if (request.method !== 'GET') return;
+
+    const url = new URL(request.url);
+    if (url.origin !== self.location.origin) return;
+
+    // 页面请求先查 IndexedDB，已离线保存的章节不再访问网络
+    if (request.mode === 'navigate') {
+        event.respondWith(getSavedChapter(url.pathname).then(saved => {
+            if (saved) {
+                return new Response(saved.html, {
+                    headers: { 'Content-Type': 'text/html; charset=utf-8' }
+                });
+            }
+            return fetch(request);
+        }));
+        return;
+    }
+
+    if (url.pathname.indexOf('/static/css/') !== -1 || url.pathname.indexOf('/static/js/') !== -1) {
+        event.respondWith(fetchAsset(request));
+    }
+});
+`
+
+	swPath := filepath.Join(g.config.OutputDir, "sw.js")
+	return os.WriteFile(swPath, []byte(js), 0644)
+}
+
+// generateOfflineQueuePage 生成离线阅读队列管理页面
+// 页面本身是静态的，列表由浏览器端从 IndexedDB 读取
+func (g *Generator) generateOfflineQueuePage() error {
+	data := map[string]interface{}{
+		"Config": g.config,
+		"Title":  fmt.Sprintf("离线阅读 - %s", g.config.Site.Title),
+	}
+
+	return g.renderTemplate("offline-queue", "offline-queue.html", data)
+}
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"time"
+
+	"creeper/internal/parser"
+)
+
+// OPDS 相关的媒体类型与链接关系
+const (
+	opdsAcquisitionType = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	opdsRelAcquisition  = "http://opds-spec.org/acquisition"
+	opdsRelImage        = "http://opds-spec.org/image"
+	opdsRelThumbnail    = "http://opds-spec.org/image/thumbnail"
+)
+
+// opdsFeed OPDS 1.2 目录（Atom 格式）
+type opdsFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	XmlnsDC string      `xml:"xmlns:dc,attr"`
+	XmlnsOP string      `xml:"xmlns:opds,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  *opdsAuthor `xml:"author,omitempty"`
+	Links   []opdsLink  `xml:"link"`
+	Entries []opdsEntry `xml:"entry"`
+}
+
+// opdsAuthor 作者
+type opdsAuthor struct {
+	Name string `xml:"name"`
+}
+
+// opdsLink 链接
+type opdsLink struct {
+	Rel   string `xml:"rel,attr"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr"`
+	Title string `xml:"title,attr,omitempty"`
+}
+
+// opdsCategory 分类
+type opdsCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// opdsEntry 目录条目
+type opdsEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Updated    string         `xml:"updated"`
+	Author     *opdsAuthor    `xml:"author,omitempty"`
+	Language   string         `xml:"dc:language"`
+	Issued     string         `xml:"dc:issued,omitempty"`
+	Summary    string         `xml:"summary,omitempty"`
+	Categories []opdsCategory `xml:"category"`
+	Links      []opdsLink     `xml:"link"`
+}
+
+// generateOPDSCatalog 生成供 KOReader、Calibre 等阅读器订阅的 opds.xml
+func (g *Generator) generateOPDSCatalog() error {
+	baseURL := g.config.Site.BaseURL
+	catalogURL := baseURL + "opds.xml"
+
+	feed := opdsFeed{
+		Xmlns:   "http://www.w3.org/2005/Atom",
+		XmlnsDC: "http://purl.org/dc/terms/",
+		XmlnsOP: "http://opds-spec.org/2010/catalog",
+		ID:      "urn:creeper:catalog:" + g.config.Site.Title,
+		Title:   g.config.Site.Title,
+		Updated: time.Now().Format(time.RFC3339),
+		Links: []opdsLink{
+			{Rel: "self", Href: catalogURL, Type: opdsAcquisitionType},
+			{Rel: "start", Href: catalogURL, Type: opdsAcquisitionType},
+			{Rel: "alternate", Href: baseURL, Type: "text/html"},
+		},
+	}
+	if g.config.Site.Author != "" {
+		feed.Author = &opdsAuthor{Name: g.config.Site.Author}
+	}
+
+	for _, novel := range g.novels {
+		feed.Entries = append(feed.Entries, g.buildOPDSEntry(novel))
+	}
+
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(g.config.OutputDir, "opds.xml")
+	return os.WriteFile(outputPath, append([]byte(xml.Header), data...), 0644)
+}
+
+// buildOPDSEntry 生成单部小说的目录条目
+func (g *Generator) buildOPDSEntry(novel *parser.Novel) opdsEntry {
+	slug := g.sanitizeFileName(novel.Title)
+	novelURL := g.novelURL(novel)
+
+	entry := opdsEntry{
+		Title:    novel.Title,
+		ID:       "urn:creeper:novel:" + slug,
+		Updated:  novel.UpdatedAt.Format(time.RFC3339),
+		Language: "zh-CN",
+		Summary:  truncate(novel.Description, descriptionExcerptLength),
+	}
+	if novel.Author != "" {
+		entry.Author = &opdsAuthor{Name: novel.Author}
+	}
+	if !novel.CreatedAt.IsZero() {
+		entry.Issued = novel.CreatedAt.Format("2006-01-02")
+	}
+	if novel.Category != "" {
+		entry.Categories = append(entry.Categories, opdsCategory{Term: novel.Category, Label: novel.Category})
+	}
+	for _, genre := range novel.Genre {
+		entry.Categories = append(entry.Categories, opdsCategory{Term: genre, Label: genre})
+	}
+
+	// 封面
+	if _, err := os.Stat(filepath.Join(g.config.OutputDir, "novels", slug, "cover.svg")); err == nil {
+		entry.Links = append(entry.Links,
+			opdsLink{Rel: opdsRelImage, Href: novelURL + "cover.svg", Type: "image/svg+xml"},
+			opdsLink{Rel: opdsRelThumbnail, Href: novelURL + "cover.svg", Type: "image/svg+xml"},
+		)
+	}
+
+	// 获取链接：EPUB（已生成时）与在线阅读
+	if _, err := os.Stat(filepath.Join(g.config.OutputDir, "novels", slug, slug+".epub")); err == nil {
+		entry.Links = append(entry.Links, opdsLink{
+			Rel:   opdsRelAcquisition,
+			Href:  novelURL + slug + ".epub",
+			Type:  "application/epub+zip",
+			Title: "EPUB",
+		})
+	}
+	entry.Links = append(entry.Links, opdsLink{
+		Rel:   opdsRelAcquisition,
+		Href:  novelURL,
+		Type:  "text/html",
+		Title: "在线阅读",
+	})
+
+	return entry
+}
@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// defaultIndexItemsPerPage 未配置 pagination.items_per_page 时首页每页的小说数
+const defaultIndexItemsPerPage = 20
+
+// indexItemsPerPage 首页每页显示的小说数
+func (g *Generator) indexItemsPerPage() int {
+	if g.config.Pagination.ItemsPerPage > 0 {
+		return g.config.Pagination.ItemsPerPage
+	}
+	return defaultIndexItemsPerPage
+}
+
+// indexPageCount 首页的页数，未启用分页时只有一页
+func (g *Generator) indexPageCount() int {
+	if !g.config.Pagination.Enabled || len(g.novels) == 0 {
+		return 1
+	}
+	perPage := g.indexItemsPerPage()
+	return (len(g.novels) + perPage - 1) / perPage
+}
+
+// indexPageName 首页第 page 页的文件名：第一页为 index.html，其余为 index-N.html
+func indexPageName(page int) string {
+	if page <= 1 {
+		return "index.html"
+	}
+	return fmt.Sprintf("index-%d.html", page)
+}
+
+// removeStaleIndexPages 删除小说减少或关闭分页后多出的旧分页
+func (g *Generator) removeStaleIndexPages(totalPages int) error {
+	for page := totalPages + 1; ; page++ {
+		path := filepath.Join(g.config.OutputDir, indexPageName(page))
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("删除旧分页失败: %v", err)
+		}
+	}
+}
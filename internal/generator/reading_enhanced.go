@@ -12,8 +12,6 @@ func (g *Generator) generateEnhancedJS() error {
 (function() {
     'use strict';
     
-    let searchData = [];
-    let searchTimeout;
     let readingSettings = {
         theme: 'light',
         fontSize: 16,
@@ -23,15 +21,27 @@ func (g *Generator) generateEnhancedJS() error {
         fullScreen: false
     };
     
+    // 阅读速度校准
+    const DEFAULT_WPM = 300;
+    const WPM_STORAGE_KEY = 'creeper-user-wpm';
+    const WPM_SAMPLES_KEY = 'creeper-user-wpm-samples';
+    const WPM_MAX_SAMPLES = 7;
+    
+    // 站点根路径，由当前脚本地址推算（脚本位于 static/js/ 下）
+    const SITE_BASE = ((document.currentScript && document.currentScript.src) || '').replace(/static\/js\/reading-enhanced\.js.*$/, '') || '/';
+    
     // 初始化
     document.addEventListener('DOMContentLoaded', function() {
-        initSearch();
         initKeyboardNavigation();
         initReadingProgress();
+        initReadingSpeedCalibration();
         initReadingSettings();
         initThemeSwitcher();
         initAutoScroll();
         initFullScreen();
+        initChapterTransitions();
+        initOfflineReading();
+        initBookmarks();
         loadUserSettings();
     });
     
@@ -78,11 +88,13 @@ func (g *Generator) generateEnhancedJS() error {
     
     // 切换主题
     function toggleTheme() {
-        const themes = ['light', 'dark', 'sepia', 'green'];
-        const currentIndex = themes.indexOf(readingSettings.theme);
-        const nextIndex = (currentIndex + 1) % themes.length;
-        
-        readingSettings.theme = themes[nextIndex];
+        // 主题持久化由 color-scheme-switcher.js 负责
+        if (window.CreeperColorScheme) {
+            readingSettings.theme = window.CreeperColorScheme.cycle();
+        } else {
+            const themes = ['light', 'dark', 'sepia', 'green'];
+            readingSettings.theme = themes[(themes.indexOf(readingSettings.theme) + 1) % themes.length];
+        }
         applySettings();
         saveUserSettings();
         
@@ -157,6 +169,14 @@ func (g *Generator) generateEnhancedJS() error {
                     </label>
                 </div>
                 
+                <div class="setting-group">
+                    <label>阅读速度</label>
+                    <div class="reading-speed-controls">
+                        <span id="reading-speed-display">300 字/分钟</span>
+                        <button onclick="resetReadingSpeed()">重新校准</button>
+                    </div>
+                </div>
+                
                 <div class="setting-group">
                     <button onclick="resetSettings()" class="reset-btn">恢复默认</button>
                 </div>
@@ -164,6 +184,7 @@ func (g *Generator) generateEnhancedJS() error {
         ` + "`" + `;
         
         document.body.appendChild(panel);
+        updateSettingsPanel();
     }
     
     // 切换设置面板
@@ -214,6 +235,13 @@ func (g *Generator) generateEnhancedJS() error {
         if (pageWidthDisplay) pageWidthDisplay.textContent = readingSettings.pageWidth + 'px';
         if (autoScrollCheck) autoScrollCheck.checked = readingSettings.autoScroll;
         
+        const readingSpeedDisplay = document.getElementById('reading-speed-display');
+        if (readingSpeedDisplay) {
+            const samples = getReadingSpeedSamples();
+            readingSpeedDisplay.textContent = getUserWpm() + ' 字/分钟' +
+                (samples.length > 0 ? '（已校准 ' + samples.length + ' 次）' : '（默认）');
+        }
+        
         // 更新主题按钮状态
         document.querySelectorAll('.theme-btn').forEach(btn => {
             btn.classList.remove('active');
@@ -333,78 +361,7 @@ func (g *Generator) generateEnhancedJS() error {
         return toolbar;
     }
     
-    // 初始化搜索功能（保持原有功能）
-    function initSearch() {
-        const searchInput = document.getElementById('search-input');
-        const searchResults = document.getElementById('search-results');
-        
-        if (!searchInput || !searchResults) return;
-        
-        // 加载搜索数据
-        fetch('/static/js/search-data.json')
-            .then(response => response.json())
-            .then(data => {
-                searchData = data;
-            })
-            .catch(error => {
-                console.warn('搜索数据加载失败:', error);
-            });
-        
-        // 搜索输入事件
-        searchInput.addEventListener('input', function() {
-            const query = this.value.trim();
-            
-            clearTimeout(searchTimeout);
-            searchTimeout = setTimeout(() => {
-                if (query.length >= 2) {
-                    performSearch(query);
-                } else {
-                    hideSearchResults();
-                }
-            }, 300);
-        });
-        
-        // 点击其他地方隐藏搜索结果
-        document.addEventListener('click', function(e) {
-            if (!searchInput.contains(e.target) && !searchResults.contains(e.target)) {
-                hideSearchResults();
-            }
-        });
-    }
-    
-    // 执行搜索
-    function performSearch(query) {
-        const results = searchData.filter(item => {
-            const searchText = (item.title + ' ' + (item.author || '') + ' ' + (item.novel || '')).toLowerCase();
-            return searchText.includes(query.toLowerCase());
-        }).slice(0, 10);
-        
-        displaySearchResults(results);
-    }
-    
-    // 显示搜索结果
-    function displaySearchResults(results) {
-        const searchResults = document.getElementById('search-results');
-        if (!searchResults) return;
-        
-        if (results.length === 0) {
-            searchResults.innerHTML = '<div class="search-result-item">没有找到相关结果</div>';
-        } else {
-            searchResults.innerHTML = results.map(item => {
-                const typeText = item.type === 'novel' ? '小说' : '章节';
-                const metaText = item.type === 'novel' 
-                    ? (item.author ? '作者：' + item.author : '')
-                    : (item.novel ? '来自：' + item.novel : '');
-                
-                return ` + "`" + `<div class="search-result-item" onclick="location.href='${item.url}'">
-                    <div class="search-result-title">[${typeText}] ${item.title}</div>
-                    ${metaText ? ` + "`" + `<div class="search-result-meta">${metaText}</div>` + "`" + ` : ''}
-                </div>` + "`" + `;
-            }).join('');
-        }
-        
-        searchResults.style.display = 'block';
-    }
+    // 搜索框由 main.js 初始化（加载 lunr 索引），这里只负责快捷键关闭搜索结果
     
     // 隐藏搜索结果
     function hideSearchResults() {
@@ -482,17 +439,66 @@ func (g *Generator) generateEnhancedJS() error {
     
     // 导航函数
     function goToPrevChapter() {
-        const prevLink = document.querySelector('a[href*="chapter-"]:nth-of-type(1)');
-        if (prevLink && prevLink.textContent.includes('上一章')) {
-            location.href = prevLink.href;
+        const prevLink = document.querySelector('a[data-nav="prev"]');
+        if (prevLink) {
+            navigateChapter(prevLink.href, 'prev');
         }
     }
     
     function goToNextChapter() {
-        const nextLink = document.querySelector('a[href*="chapter-"]:last-of-type');
-        if (nextLink && nextLink.textContent.includes('下一章')) {
-            location.href = nextLink.href;
+        const nextLink = document.querySelector('a[data-nav="next"]');
+        if (nextLink) {
+            navigateChapter(nextLink.href, 'next');
+        }
+    }
+    
+    // 章节切换动画
+    const TRANSITION_KEY = 'creeper-chapter-direction';
+    const TRANSITION_DURATION = 300;
+    
+    function chapterTransitionsEnabled() {
+        if (window.matchMedia && window.matchMedia('(prefers-reduced-motion: reduce)').matches) {
+            return false;
+        }
+        return !!document.querySelector('.chapter-content[data-transitions]');
+    }
+    
+    function initChapterTransitions() {
+        const article = document.querySelector('.chapter-content[data-transitions]');
+        if (!article) return;
+        
+        // 根据上一页记录的方向播放进入动画
+        const direction = sessionStorage.getItem(TRANSITION_KEY);
+        sessionStorage.removeItem(TRANSITION_KEY);
+        if (direction === 'next' || direction === 'prev') {
+            article.classList.add('entering', 'entering-' + direction);
+            article.addEventListener('animationend', function() {
+                article.classList.remove('entering', 'entering-' + direction);
+            }, { once: true });
         }
+        
+        document.querySelectorAll('a[data-nav]').forEach(link => {
+            link.addEventListener('click', function(e) {
+                if (e.button !== 0 || e.ctrlKey || e.metaKey || e.shiftKey) return;
+                e.preventDefault();
+                navigateChapter(link.href, link.dataset.nav);
+            });
+        });
+    }
+    
+    // 跳转到其他章节，启用动画时先播放离开动画
+    function navigateChapter(href, direction) {
+        const article = document.querySelector('.chapter-content');
+        if (!chapterTransitionsEnabled() || !article) {
+            location.href = href;
+            return;
+        }
+        
+        sessionStorage.setItem(TRANSITION_KEY, direction);
+        article.classList.add('leaving', 'leaving-' + direction);
+        setTimeout(() => {
+            location.href = href;
+        }, TRANSITION_DURATION);
     }
     
     function goToToc() {
@@ -527,6 +533,9 @@ func (g *Generator) generateEnhancedJS() error {
         
         document.body.appendChild(progressContainer);
         
+        // 支持 CSS 滚动驱动动画时进度条由样式表驱动，JS 只更新百分比文字
+        const cssScrollTimeline = !!(window.CSS && CSS.supports && CSS.supports('animation-timeline: scroll()'));
+        
         // 更新进度
         function updateProgress() {
             const scrollTop = window.pageYOffset || document.documentElement.scrollTop;
@@ -536,7 +545,7 @@ func (g *Generator) generateEnhancedJS() error {
             const progressFill = document.querySelector('.progress-fill');
             const progressText = document.querySelector('.progress-text');
             
-            if (progressFill) progressFill.style.width = progress + '%';
+            if (progressFill && !cssScrollTimeline) progressFill.style.width = progress + '%';
             if (progressText) progressText.textContent = Math.round(progress) + '%';
         }
         
@@ -556,7 +565,7 @@ func (g *Generator) generateEnhancedJS() error {
             
             const chapterContent = document.querySelector('.chapter-content');
             const wordCount = chapterContent ? chapterContent.textContent.length : 0;
-            const readingTime = Math.ceil(wordCount / 300); // 假设每分钟300字
+            const readingTime = Math.ceil(wordCount / getUserWpm()); // 使用校准后的阅读速度
             
             infoDiv.innerHTML = ` + "`" + `
                 <span class="reading-time">预计阅读时间: ${readingTime} 分钟</span>
@@ -567,7 +576,286 @@ func (g *Generator) generateEnhancedJS() error {
         }
     }
     
+    // 获取用户阅读速度（字/分钟）
+    function getUserWpm() {
+        const saved = parseInt(localStorage.getItem(WPM_STORAGE_KEY), 10);
+        return saved > 0 ? saved : DEFAULT_WPM;
+    }
+    
+    // 获取阅读速度样本
+    function getReadingSpeedSamples() {
+        try {
+            const samples = JSON.parse(localStorage.getItem(WPM_SAMPLES_KEY) || '[]');
+            return Array.isArray(samples) ? samples : [];
+        } catch (e) {
+            return [];
+        }
+    }
+    
+    // 记录一次阅读速度样本，三次及以上取中位数以平滑异常值
+    function recordReadingSpeedSample(wpm) {
+        const samples = getReadingSpeedSamples();
+        samples.push(Math.round(wpm));
+        while (samples.length > WPM_MAX_SAMPLES) {
+            samples.shift();
+        }
+        localStorage.setItem(WPM_SAMPLES_KEY, JSON.stringify(samples));
+        
+        let calibrated;
+        if (samples.length >= 3) {
+            const sorted = samples.slice().sort((a, b) => a - b);
+            const mid = Math.floor(sorted.length / 2);
+            calibrated = sorted.length % 2 ? sorted[mid] : Math.round((sorted[mid - 1] + sorted[mid]) / 2);
+        } else {
+            calibrated = Math.round(samples.reduce((sum, value) => sum + value, 0) / samples.length);
+        }
+        localStorage.setItem(WPM_STORAGE_KEY, String(calibrated));
+        updateSettingsPanel();
+    }
+    
+    // 重置阅读速度校准
+    function resetReadingSpeed() {
+        localStorage.removeItem(WPM_STORAGE_KEY);
+        localStorage.removeItem(WPM_SAMPLES_KEY);
+        updateSettingsPanel();
+    }
+    
+    // 初始化阅读速度校准：读完章节（滚动到底部）时根据耗时计算阅读速度
+    function initReadingSpeedCalibration() {
+        const chapterContent = document.querySelector('.chapter-content');
+        if (!chapterContent) return;
+        
+        const wordCount = chapterContent.textContent.replace(/\s+/g, '').length;
+        const startTime = Date.now();
+        let recorded = false;
+        
+        function checkFinished() {
+            if (recorded) return;
+            
+            const scrollBottom = (window.pageYOffset || document.documentElement.scrollTop) + window.innerHeight;
+            if (scrollBottom < document.documentElement.scrollHeight - 50) return;
+            
+            const elapsedMs = Date.now() - startTime;
+            // 过短视为跳读，过长视为中途离开，均不计入样本
+            if (elapsedMs < 10000 || elapsedMs > 2 * 60 * 60 * 1000 || wordCount === 0) return;
+            
+            recorded = true;
+            recordReadingSpeedSample(wordCount / (elapsedMs / 60000));
+            window.removeEventListener('scroll', checkFinished);
+        }
+        
+        window.addEventListener('scroll', checkFinished);
+    }
+    
+    // 离线阅读队列，章节页面保存在 IndexedDB 中，Service Worker 离线时直接返回
+    const OFFLINE_DB_NAME = 'creeper-offline';
+    const OFFLINE_DB_VERSION = 1;
+    const OFFLINE_STORE = 'chapters';
+    
+    class OfflineQueue {
+        static isSupported() {
+            return 'indexedDB' in window;
+        }
+        
+        open() {
+            if (!this.dbPromise) {
+                this.dbPromise = new Promise((resolve, reject) => {
+                    const request = indexedDB.open(OFFLINE_DB_NAME, OFFLINE_DB_VERSION);
+                    request.onupgradeneeded = () => {
+                        const db = request.result;
+                        if (!db.objectStoreNames.contains(OFFLINE_STORE)) {
+                            db.createObjectStore(OFFLINE_STORE, { keyPath: 'url' });
+                        }
+                    };
+                    request.onsuccess = () => resolve(request.result);
+                    request.onerror = () => reject(request.error);
+                });
+            }
+            return this.dbPromise;
+        }
+        
+        // 在对象仓库上执行一次操作，返回请求结果
+        transaction(mode, operation) {
+            return this.open().then(db => new Promise((resolve, reject) => {
+                const request = operation(db.transaction(OFFLINE_STORE, mode).objectStore(OFFLINE_STORE));
+                request.onsuccess = () => resolve(request.result);
+                request.onerror = () => reject(request.error);
+            }));
+        }
+        
+        // 保存章节，已存在时覆盖内容并重置为未读
+        add(entry) {
+            return this.transaction('readwrite', store => store.put({
+                url: entry.url,
+                title: entry.title,
+                novel: entry.novel,
+                html: entry.html,
+                savedAt: Date.now(),
+                read: false
+            }));
+        }
+        
+        get(url) {
+            return this.transaction('readonly', store => store.get(url));
+        }
+        
+        // 按保存时间倒序列出所有章节
+        list() {
+            return this.transaction('readonly', store => store.getAll())
+                .then(entries => entries.sort((a, b) => b.savedAt - a.savedAt));
+        }
+        
+        remove(url) {
+            return this.transaction('readwrite', store => store.delete(url));
+        }
+        
+        markRead(url, read) {
+            return this.get(url).then(entry => {
+                if (!entry) return;
+                entry.read = read !== false;
+                return this.transaction('readwrite', store => store.put(entry));
+            });
+        }
+    }
+    
+    // 初始化离线阅读：注册 Service Worker，并在章节页面添加离线保存按钮
+    function initOfflineReading() {
+        if ('serviceWorker' in navigator && location.protocol !== 'file:') {
+            navigator.serviceWorker.register(SITE_BASE + 'sw.js').catch(error => {
+                console.warn('Service Worker 注册失败:', error);
+            });
+        }
+        
+        const article = document.querySelector('.chapter-content');
+        if (!article || !OfflineQueue.isSupported()) return;
+        
+        const queue = new OfflineQueue();
+        const url = location.pathname;
+        const offlineBtn = createToolButton('📥', '离线保存', saveChapter);
+        offlineBtn.dataset.action = 'offline';
+        addToToolbar(offlineBtn);
+        
+        function markSaved() {
+            offlineBtn.textContent = '✅';
+            offlineBtn.title = '已离线保存（点击更新）';
+        }
+        
+        // 保存服务器返回的原始页面，避免把脚本动态插入的元素一并保存
+        function saveChapter() {
+            fetch(location.href, { cache: 'no-cache' })
+                .then(response => {
+                    if (!response.ok) throw new Error(response.status);
+                    return response.text();
+                })
+                .then(html => queue.add({
+                    url: url,
+                    title: article.dataset.chapterTitle || document.title,
+                    novel: article.dataset.novel || '',
+                    html: html
+                }))
+                .then(markSaved)
+                .catch(error => {
+                    console.warn('离线保存失败:', error);
+                    offlineBtn.title = '离线保存失败';
+                });
+        }
+        
+        queue.get(url).then(entry => {
+            if (!entry) return;
+            markSaved();
+            if (entry.read) return;
+            
+            // 读到章节末尾时标记为已读
+            function checkFinished() {
+                const scrollBottom = (window.pageYOffset || document.documentElement.scrollTop) + window.innerHeight;
+                if (scrollBottom < document.documentElement.scrollHeight - 50) return;
+                window.removeEventListener('scroll', checkFinished);
+                queue.markRead(url, true);
+            }
+            window.addEventListener('scroll', checkFinished);
+        }).catch(error => {
+            console.warn('读取离线队列失败:', error);
+        });
+    }
+    
+    // 阅读书签：按小说标题保存最近阅读的章节
+    const BOOKMARK_STORAGE_KEY = 'creeper-bookmarks';
+    const BOOKMARK_SAVE_DELAY = 1000;
+    
+    class BookmarkManager {
+        load() {
+            try {
+                return JSON.parse(localStorage.getItem(BOOKMARK_STORAGE_KEY) || '{}');
+            } catch (e) {
+                return {};
+            }
+        }
+        
+        // 保存 {novelTitle, chapterID, chapterURL, timestamp}，同一小说只保留最新的书签
+        save(bookmark) {
+            const bookmarks = this.load();
+            bookmarks[bookmark.novelTitle] = Object.assign({}, bookmark, { timestamp: Date.now() });
+            localStorage.setItem(BOOKMARK_STORAGE_KEY, JSON.stringify(bookmarks));
+        }
+        
+        get(novelTitle) {
+            return this.load()[novelTitle] || null;
+        }
+        
+        // 最近阅读的书签
+        latest() {
+            const bookmarks = Object.values(this.load());
+            if (bookmarks.length === 0) return null;
+            return bookmarks.reduce((a, b) => (b.timestamp > a.timestamp ? b : a));
+        }
+    }
+    
+    // 章节页面在滚动停止后保存书签，首页在“继续阅读”卡片中显示最近阅读的章节
+    function initBookmarks() {
+        const manager = new BookmarkManager();
+        
+        const article = document.querySelector('.chapter-content');
+        if (article && article.dataset.novel) {
+            const bookmark = {
+                novelTitle: article.dataset.novel,
+                chapterID: parseInt(article.dataset.chapterId, 10) || 0,
+                chapterTitle: article.dataset.chapterTitle || document.title,
+                chapterURL: location.pathname
+            };
+            
+            let saveTimer = null;
+            window.addEventListener('scroll', function() {
+                clearTimeout(saveTimer);
+                saveTimer = setTimeout(() => manager.save(bookmark), BOOKMARK_SAVE_DELAY);
+            }, { passive: true });
+            manager.save(bookmark);
+            return;
+        }
+        
+        const card = document.querySelector('.continue-reading');
+        const bookmark = manager.latest();
+        if (!card || !bookmark) return;
+        
+        const label = document.createElement('span');
+        label.className = 'continue-reading-label';
+        label.textContent = '继续阅读';
+        
+        const link = document.createElement('a');
+        link.className = 'continue-reading-link';
+        link.href = bookmark.chapterURL;
+        link.textContent = bookmark.novelTitle + ' · ' + (bookmark.chapterTitle || '第 ' + bookmark.chapterID + ' 章');
+        
+        const time = document.createElement('span');
+        time.className = 'continue-reading-time';
+        time.textContent = new Date(bookmark.timestamp).toLocaleString();
+        
+        card.append(label, link, time);
+        card.hidden = false;
+    }
+    
     // 暴露全局函数
+    window.CreeperOfflineQueue = OfflineQueue;
+    window.CreeperBookmarks = BookmarkManager;
     window.adjustFontSize = adjustFontSize;
     window.adjustLineHeight = adjustLineHeight;
     window.adjustPageWidth = adjustPageWidth;
@@ -575,6 +863,7 @@ func (g *Generator) generateEnhancedJS() error {
     window.toggleAutoScroll = toggleAutoScroll;
     window.toggleSettingsPanel = toggleSettingsPanel;
     window.resetSettings = resetSettings;
+    window.resetReadingSpeed = resetReadingSpeed;
     
 })();`
 
@@ -584,9 +873,11 @@ func (g *Generator) generateEnhancedJS() error {
 
 // generateEnhancedCSS 生成增强的阅读体验 CSS
 func (g *Generator) generateEnhancedCSS() error {
-	css := fmt.Sprintf(`/* Creeper 增强阅读体验样式 */
+	css := fmt.Sprintf(`/* Creeper 增强阅读体验样式（级联层说明见 style.css） */
+%s
 
 /* CSS 变量定义 */
+@layer theme {
 :root {
     /* 原有变量 */
     --primary-color: %s;
@@ -599,7 +890,7 @@ func (g *Generator) generateEnhancedCSS() error {
     --border-color: #e1e5e9;
     --shadow: 0 2px 4px rgba(0,0,0,0.1);
     --shadow-hover: 0 4px 8px rgba(0,0,0,0.15);
-    
+%s
     /* 阅读体验变量 */
     --reading-font-size: 16px;
     --reading-line-height: 1.6;
@@ -638,12 +929,13 @@ func (g *Generator) generateEnhancedCSS() error {
     --theme-border: #c1d5c1;
     --theme-card-bg: #f0f8f0;
 }
+}
 
+@layer components {
 /* 应用主题 */
 body {
     background-color: var(--theme-bg);
     color: var(--theme-text);
-    transition: background-color 0.3s, color 0.3s;
 }
 
 /* 增强的章节内容样式 */
@@ -653,15 +945,14 @@ body {
     background: var(--theme-card-bg);
     border: 1px solid var(--theme-border);
     border-radius: 8px;
-    padding: 3rem;
+    padding: var(--space-xl);
     box-shadow: var(--shadow);
     font-size: var(--reading-font-size);
     line-height: var(--reading-line-height);
-    transition: all 0.3s ease;
 }
 
 .chapter-content p {
-    margin-bottom: 1.5rem;
+    margin-bottom: var(--space-md);
     text-indent: 2em;
     color: var(--theme-text);
 }
@@ -670,8 +961,8 @@ body {
 .reading-toolbar {
     position: fixed;
     right: 20px;
-    top: 50%;
-    transform: translateY(-50%);
+    top: 50%%;
+    transform: translateY(-50%%);
     background: var(--theme-card-bg);
     border: 1px solid var(--theme-border);
     border-radius: 25px;
@@ -681,7 +972,6 @@ body {
     flex-direction: column;
     gap: 8px;
     z-index: 1000;
-    transition: all 0.3s ease;
 }
 
 .reading-toolbar:hover {
@@ -692,12 +982,11 @@ body {
     width: 40px;
     height: 40px;
     border: none;
-    border-radius: 50%;
+    border-radius: 50%%;
     background: var(--primary-color);
     color: white;
     font-size: 16px;
     cursor: pointer;
-    transition: all 0.3s ease;
     display: flex;
     align-items: center;
     justify-content: center;
@@ -705,15 +994,14 @@ body {
 
 .tool-btn:hover {
     background: var(--secondary-color);
-    transform: scale(1.1);
 }
 
 /* 设置面板 */
 .settings-panel {
     position: fixed;
-    top: 50%;
-    left: 50%;
-    transform: translate(-50%, -50%);
+    top: 50%%;
+    left: 50%%;
+    transform: translate(-50%%, -50%%);
     width: 400px;
     max-width: 90vw;
     background: var(--theme-card-bg);
@@ -722,22 +1010,20 @@ body {
     box-shadow: 0 20px 40px rgba(0,0,0,0.3);
     z-index: 2000;
     display: none;
-    transition: all 0.3s ease;
 }
 
 .settings-panel.active {
     display: block;
-    animation: fadeInScale 0.3s ease;
 }
 
 @keyframes fadeInScale {
     from {
         opacity: 0;
-        transform: translate(-50%, -50%) scale(0.9);
+        transform: translate(-50%%, -50%%) scale(0.9);
     }
     to {
         opacity: 1;
-        transform: translate(-50%, -50%) scale(1);
+        transform: translate(-50%%, -50%%) scale(1);
     }
 }
 
@@ -762,7 +1048,7 @@ body {
     color: var(--theme-secondary);
     width: 30px;
     height: 30px;
-    border-radius: 50%;
+    border-radius: 50%%;
     display: flex;
     align-items: center;
     justify-content: center;
@@ -804,7 +1090,6 @@ body {
     color: var(--theme-text);
     border-radius: 4px;
     cursor: pointer;
-    transition: all 0.3s ease;
 }
 
 .font-size-controls button:hover,
@@ -825,7 +1110,6 @@ body {
     border: 2px solid var(--theme-border);
     border-radius: 8px;
     cursor: pointer;
-    transition: all 0.3s ease;
     font-size: 14px;
     font-weight: 500;
 }
@@ -856,7 +1140,7 @@ body {
 }
 
 .reset-btn {
-    width: 100%;
+    width: 100%%;
     padding: 12px;
     background: var(--secondary-color);
     color: white;
@@ -865,7 +1149,6 @@ body {
     cursor: pointer;
     font-size: 14px;
     font-weight: 500;
-    transition: background 0.3s ease;
 }
 
 .reset-btn:hover {
@@ -884,18 +1167,37 @@ body {
 }
 
 .progress-bar {
-    height: 100%;
+    height: 100%%;
     background: var(--theme-border);
     position: relative;
 }
 
 .progress-fill {
-    height: 100%;
+    height: 100%%;
     background: var(--primary-color);
-    transition: width 0.3s ease;
     position: relative;
 }
 
+/* 支持滚动驱动动画的浏览器直接由 CSS 更新进度条，JS 仅作为回退 */
+@keyframes reading-progress {
+    from {
+        transform: scaleX(0);
+    }
+    to {
+        transform: scaleX(1);
+    }
+}
+
+@supports (animation-timeline: scroll()) {
+    .progress-fill {
+        width: 100%%;
+        transform-origin: left center;
+        transition: none;
+        animation: reading-progress linear both;
+        animation-timeline: scroll(root block);
+    }
+}
+
 .progress-text {
     position: fixed;
     top: 10px;
@@ -937,6 +1239,171 @@ body {
     margin: 0 auto;
 }
 
+/* 章节切换动画：前进向左滑出，后退向右滑出 */
+@keyframes chapter-leave-next {
+    to {
+        opacity: 0;
+        transform: translateX(-40px);
+    }
+}
+
+@keyframes chapter-leave-prev {
+    to {
+        opacity: 0;
+        transform: translateX(40px);
+    }
+}
+
+@keyframes chapter-enter-next {
+    from {
+        opacity: 0;
+        transform: translateX(40px);
+    }
+}
+
+@keyframes chapter-enter-prev {
+    from {
+        opacity: 0;
+        transform: translateX(-40px);
+    }
+}
+
+/* 动画与过渡仅在用户未开启「减少动态效果」时播放（WCAG 2.1 AA） */
+@media (prefers-reduced-motion: no-preference) {
+    body {
+        transition: background-color 0.3s, color 0.3s;
+    }
+
+    .chapter-content,
+    .reading-toolbar,
+    .tool-btn,
+    .settings-panel,
+    .font-size-controls button,
+    .line-height-controls button,
+    .page-width-controls button,
+    .theme-btn {
+        transition: all 0.3s ease;
+    }
+
+    .reset-btn {
+        transition: background 0.3s ease;
+    }
+
+    .tool-btn:hover {
+        transform: scale(1.1);
+    }
+
+    .settings-panel.active {
+        animation: fadeInScale 0.3s ease;
+    }
+
+    .progress-fill {
+        transition: width 0.3s ease;
+    }
+
+    .chapter-content.leaving {
+        animation-duration: 300ms;
+        animation-timing-function: ease-in;
+        animation-fill-mode: forwards;
+    }
+
+    .chapter-content.leaving-next {
+        animation-name: chapter-leave-next;
+    }
+
+    .chapter-content.leaving-prev {
+        animation-name: chapter-leave-prev;
+    }
+
+    .chapter-content.entering {
+        animation-duration: 300ms;
+        animation-timing-function: ease-out;
+    }
+
+    .chapter-content.entering-next {
+        animation-name: chapter-enter-next;
+    }
+
+    .chapter-content.entering-prev {
+        animation-name: chapter-enter-prev;
+    }
+}
+
+/* 首页“继续阅读”卡片 */
+.continue-reading {
+    display: flex;
+    flex-wrap: wrap;
+    align-items: center;
+    gap: var(--space-sm);
+    margin-bottom: var(--space-lg);
+    padding: var(--space-md);
+    background: var(--theme-card-bg, #ffffff);
+    border: 1px solid var(--border-color);
+    border-left: 4px solid var(--primary-color);
+    border-radius: 8px;
+    box-shadow: var(--shadow);
+}
+
+.continue-reading[hidden] {
+    display: none;
+}
+
+.continue-reading-label {
+    font-weight: bold;
+    color: var(--primary-color);
+}
+
+.continue-reading-link {
+    flex: 1;
+    color: var(--text-color);
+    text-decoration: none;
+}
+
+.continue-reading-link:hover {
+    text-decoration: underline;
+}
+
+.continue-reading-time {
+    font-size: 0.85em;
+    opacity: 0.7;
+}
+
+/* 章节列表容器查询：按容器宽度而非视口宽度排列章节卡片 */
+.chapters-list {
+    container-type: inline-size;
+    container-name: chapter-list;
+    contain: layout style;
+}
+
+.chapters-list .chapters-grid {
+    grid-template-columns: 1fr;
+}
+
+@container chapter-list (min-width: 600px) {
+    .chapters-grid {
+        grid-template-columns: repeat(2, minmax(0, 1fr));
+    }
+}
+
+@container chapter-list (min-width: 900px) {
+    .chapters-grid {
+        grid-template-columns: repeat(3, minmax(0, 1fr));
+    }
+}
+
+@container chapter-list (min-width: 1200px) {
+    .chapters-grid {
+        grid-template-columns: repeat(4, minmax(0, 1fr));
+    }
+}
+
+/* 不支持容器查询的浏览器保持原有的自适应网格 */
+@supports not (container-type: inline-size) {
+    .chapters-list .chapters-grid {
+        grid-template-columns: repeat(auto-fill, minmax(250px, 1fr));
+    }
+}
+
 /* 移动端优化 */
 @media (max-width: 768px) {
     .reading-toolbar {
@@ -957,7 +1424,7 @@ body {
     }
     
     .chapter-content {
-        padding: 2rem 1.5rem;
+        padding: var(--space-lg) var(--space-md);
         font-size: var(--reading-font-size);
     }
     
@@ -974,7 +1441,7 @@ body {
 
 @media (max-width: 480px) {
     .chapter-content {
-        padding: 1.5rem 1rem;
+        padding: var(--space-md) var(--space-sm);
     }
     
     .reading-toolbar {
@@ -986,7 +1453,9 @@ body {
         border-radius: 20px;
     }
 }
+}
 `,
+		cssLayerOrder,
 		g.config.Theme.PrimaryColor,
 		g.config.Theme.SecondaryColor,
 		g.config.Theme.BackgroundColor,
@@ -994,6 +1463,7 @@ body {
 		g.config.Theme.FontFamily,
 		g.config.Theme.FontSize,
 		g.config.Theme.LineHeight,
+		g.spacingVariables(),
 	)
 
 	cssPath := filepath.Join(g.config.OutputDir, "static", "css", "reading-enhanced.css")
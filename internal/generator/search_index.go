@@ -0,0 +1,284 @@
+package generator
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// lunrVersion 序列化索引对应的 lunr.js 版本，与页面引入的 lunr.js 保持一致
+const lunrVersion = "2.3.9"
+
+//go:generate curl -fsSL -o third_party/lunr/lunr.min.js https://unpkg.com/lunr@2.3.9/lunr.min.js
+
+// lunrCDNURL 程序未附带 lunr.js 时页面引入的地址
+const lunrCDNURL = "https://unpkg.com/lunr@" + lunrVersion + "/lunr.min.js"
+
+// lunrAssets 随程序附带的 lunr.js，见 third_party/lunr/README.md
+//
+//go:embed third_party/lunr
+var lunrAssets embed.FS
+
+// defaultSearchContentLength 未配置 search.max_content_length 时章节正文的最大索引字符数
+const defaultSearchContentLength = 500
+
+// BM25 参数，与 lunr.Builder 的默认值一致
+const (
+	lunrK1 = 1.2
+	lunrB  = 0.75
+)
+
+// lunrField 索引字段及其权重
+type lunrField struct {
+	name  string
+	boost float64
+}
+
+// searchIndexFields 建立索引的字段，标题命中的结果排在正文命中之前
+var searchIndexFields = []lunrField{
+	{name: "title", boost: 10},
+	{name: "author", boost: 5},
+	{name: "novel", boost: 3},
+	{name: "content", boost: 1},
+}
+
+// lunrIndex lunr.Index.load() 可直接加载的序列化索引
+type lunrIndex struct {
+	Version       string          `json:"version"`
+	Fields        []string        `json:"fields"`
+	FieldVectors  [][]interface{} `json:"fieldVectors"`
+	InvertedIndex [][]interface{} `json:"invertedIndex"`
+	Pipeline      []string        `json:"pipeline"`
+}
+
+// searchIndexFile search-data.json 的内容：预构建的索引与按文档编号存放的结果数据
+type searchIndexFile struct {
+	Index lunrIndex                         `json:"index"`
+	Store map[string]map[string]interface{} `json:"store"`
+}
+
+// generateSearchData 生成 static/js/search-data.json
+func (g *Generator) generateSearchData() error {
+	searchPath := filepath.Join(g.config.OutputDir, "static", "js", "search-data.json")
+	lunrPath := filepath.Join(g.config.OutputDir, "static", "js", "lunr.min.js")
+	if !g.config.Search.Enabled {
+		os.Remove(searchPath)
+		os.Remove(lunrPath)
+		return nil
+	}
+
+	if script, ok := vendoredLunr(); ok {
+		if err := os.WriteFile(lunrPath, script, 0644); err != nil {
+			return fmt.Errorf("写入 lunr.js 失败: %v", err)
+		}
+	} else {
+		os.Remove(lunrPath)
+	}
+
+	entries := g.buildSearchEntries(g.config.Search.IncludeContent)
+
+	store := make(map[string]map[string]interface{}, len(entries))
+	for i, entry := range entries {
+		store[strconv.Itoa(i)] = entry
+	}
+
+	data, err := json.Marshal(searchIndexFile{
+		Index: buildLunrIndex(entries),
+		Store: store,
+	})
+	if err != nil {
+		return fmt.Errorf("序列化搜索数据失败: %v", err)
+	}
+
+	return os.WriteFile(searchPath, data, 0644)
+}
+
+// vendoredLunr 返回随程序附带的 lunr.min.js
+func vendoredLunr() ([]byte, bool) {
+	script, err := lunrAssets.ReadFile("third_party/lunr/lunr.min.js")
+	return script, err == nil
+}
+
+// lunrScriptURL 页面引入的 lunr.js：优先使用站点自带的 static/js/lunr.min.js，
+// 离线阅读时 Service Worker 可以缓存；程序未附带时回退到 CDN
+func (g *Generator) lunrScriptURL() string {
+	if _, ok := vendoredLunr(); ok {
+		return g.config.Site.BaseURL + "static/js/lunr.min.js"
+	}
+	return lunrCDNURL
+}
+
+// searchContentLength 章节正文的最大索引字符数
+func (g *Generator) searchContentLength() int {
+	if g.config.Search.MaxContentLength > 0 {
+		return g.config.Search.MaxContentLength
+	}
+	return defaultSearchContentLength
+}
+
+// buildLunrIndex 按 lunr.Builder 的算法（BM25）建立倒排索引与字段向量
+// 文档编号为条目下标，索引不经过 lunr 的英文词干处理，分词规则见 searchTokens
+func buildLunrIndex(entries []map[string]interface{}) lunrIndex {
+	type fieldDoc struct {
+		ref    string
+		field  lunrField
+		terms  map[string]int
+		length int
+	}
+
+	var fieldDocs []*fieldDoc
+	postings := make(map[string]map[string][]string) // 词 -> 字段 -> 文档编号
+	fieldLengthSum := make(map[string]int)
+
+	for i, entry := range entries {
+		ref := strconv.Itoa(i)
+		for _, field := range searchIndexFields {
+			text, _ := entry[field.name].(string)
+			tokens := searchTokens(text)
+
+			doc := &fieldDoc{ref: ref, field: field, terms: make(map[string]int), length: len(tokens)}
+			for _, token := range tokens {
+				if doc.terms[token] == 0 {
+					if postings[token] == nil {
+						postings[token] = make(map[string][]string)
+					}
+					postings[token][field.name] = append(postings[token][field.name], ref)
+				}
+				doc.terms[token]++
+			}
+			fieldDocs = append(fieldDocs, doc)
+			fieldLengthSum[field.name] += len(tokens)
+		}
+	}
+
+	// lunr 的 TokenSet 要求按 JavaScript 字符串顺序（UTF-16 码元）插入
+	terms := make([]string, 0, len(postings))
+	for term := range postings {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		return lessUTF16(terms[i], terms[j])
+	})
+
+	termIndex := make(map[string]int, len(terms))
+	invertedIndex := make([][]interface{}, 0, len(terms))
+	for i, term := range terms {
+		termIndex[term] = i
+		posting := map[string]interface{}{"_index": i}
+		for _, field := range searchIndexFields {
+			docs := make(map[string]struct{}, len(postings[term][field.name]))
+			for _, ref := range postings[term][field.name] {
+				docs[ref] = struct{}{}
+			}
+			posting[field.name] = docs
+		}
+		invertedIndex = append(invertedIndex, []interface{}{term, posting})
+	}
+
+	documentCount := float64(len(entries))
+	idf := func(term string) float64 {
+		documentsWithTerm := 0
+		for _, refs := range postings[term] {
+			documentsWithTerm += len(refs)
+		}
+		x := (documentCount - float64(documentsWithTerm) + 0.5) / (float64(documentsWithTerm) + 0.5)
+		return math.Log(1 + math.Abs(x))
+	}
+
+	fieldVectors := make([][]interface{}, 0, len(fieldDocs))
+	for _, doc := range fieldDocs {
+		avgLength := float64(fieldLengthSum[doc.field.name]) / documentCount
+
+		indexes := make([]int, 0, len(doc.terms))
+		scores := make(map[int]float64, len(doc.terms))
+		for term, tf := range doc.terms {
+			i := termIndex[term]
+			score := idf(term) * ((lunrK1 + 1) * float64(tf)) /
+				(lunrK1*(1-lunrB+lunrB*(float64(doc.length)/avgLength)) + float64(tf))
+			indexes = append(indexes, i)
+			scores[i] = math.Round(score*doc.field.boost*1000) / 1000
+		}
+		sort.Ints(indexes)
+
+		// 向量按词编号升序展开为 [编号, 分值, 编号, 分值, ...]
+		vector := make([]float64, 0, len(indexes)*2)
+		for _, i := range indexes {
+			vector = append(vector, float64(i), scores[i])
+		}
+		fieldVectors = append(fieldVectors, []interface{}{doc.field.name + "/" + doc.ref, vector})
+	}
+
+	fields := make([]string, 0, len(searchIndexFields))
+	for _, field := range searchIndexFields {
+		fields = append(fields, field.name)
+	}
+
+	return lunrIndex{
+		Version:       lunrVersion,
+		Fields:        fields,
+		FieldVectors:  fieldVectors,
+		InvertedIndex: invertedIndex,
+		Pipeline:      []string{},
+	}
+}
+
+// searchTokens 将文本切分为索引词：
+// 汉字按相邻两字切分（单独的一个汉字保留为单字），其他字母与数字按连续片段切分并转为小写
+// 浏览器端的查询使用相同的规则切分关键词
+func searchTokens(text string) []string {
+	var tokens []string
+	var han, word []rune
+
+	flushHan := func() {
+		if len(han) == 1 {
+			tokens = append(tokens, string(han))
+		}
+		for i := 0; i+1 < len(han); i++ {
+			tokens = append(tokens, string(han[i:i+2]))
+		}
+		han = han[:0]
+	}
+	flushWord := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, string(word))
+		}
+		word = word[:0]
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flushWord()
+			han = append(han, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushHan()
+			word = append(word, r)
+		default:
+			flushHan()
+			flushWord()
+		}
+	}
+	flushHan()
+	flushWord()
+
+	return tokens
+}
+
+// lessUTF16 按 UTF-16 码元比较字符串，与 JavaScript 的字符串比较一致
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"creeper/internal/parser"
+)
+
+// update 为 true 时用当前输出覆盖 testdata 中的黄金文件
+var update = flag.Bool("update", false, "更新 testdata 中的黄金文件")
+
+func TestSearchTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"空文本", "", nil},
+		{"汉字两两切分", "天下无敌", []string{"天下", "下无", "无敌"}},
+		{"单个汉字", "剑", []string{"剑"}},
+		{"标点分隔汉字", "风云，天下", []string{"风云", "天下"}},
+		{"字母转小写", "Hello, World", []string{"hello", "world"}},
+		{"汉字与数字混合", "第12章 风云", []string{"第", "12", "章", "风云"}},
+		{"字母紧跟汉字", "AI觉醒", []string{"ai", "觉醒"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchTokens(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("searchTokens(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSearchEntriesContent(t *testing.T) {
+	content := "# 第1章 开端\n" + strings.Repeat("正文", 20) + "\n\n结尾。"
+	novel := &parser.Novel{
+		Title:       "小说甲",
+		Author:      "作者",
+		Description: "简介",
+		Chapters:    []*parser.Chapter{{ID: 1, Title: "第1章 开端", Content: content}},
+	}
+
+	tests := []struct {
+		name             string
+		includeContent   bool
+		maxContentLength int
+		want             string // 章节条目的 content，空表示不包含
+	}{
+		{"不包含正文", false, 0, ""},
+		{"默认长度不截断", true, 0, strings.Repeat("正文", 20) + " 结尾。"},
+		{"按字符截断", true, 5, "正文正文正…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGenerator(t, nil)
+			g.config.Search.IncludeContent = tt.includeContent
+			g.config.Search.MaxContentLength = tt.maxContentLength
+			g.novels = []*parser.Novel{novel}
+
+			entries := g.buildSearchEntries(g.config.Search.IncludeContent)
+			if len(entries) != 2 {
+				t.Fatalf("len(entries) = %d, want 2", len(entries))
+			}
+
+			got, exists := entries[1]["content"].(string)
+			if !tt.includeContent {
+				if exists {
+					t.Errorf("include_content 关闭时章节条目包含 content = %q", got)
+				}
+				if _, exists := entries[0]["content"]; exists {
+					t.Errorf("include_content 关闭时小说条目包含 content")
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+			if tt.maxContentLength > 0 && utf8.RuneCountInString(got) > tt.maxContentLength+1 {
+				t.Errorf("content 字符数 = %d, 超过 max_content_length %d", utf8.RuneCountInString(got), tt.maxContentLength)
+			}
+		})
+	}
+}
+
+func TestBuildLunrIndexGolden(t *testing.T) {
+	entries := []map[string]interface{}{
+		{"type": "novel", "title": "天下无敌", "author": "张三", "content": "江湖风云"},
+		{"type": "chapter", "title": "第1章 风云", "novel": "天下无敌", "author": "张三", "content": "Sword 风云再起"},
+	}
+
+	got, err := json.MarshalIndent(buildLunrIndex(entries), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "search_index.golden.json")
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("读取黄金文件失败: %v（使用 -update 生成）", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("buildLunrIndex() 与 %s 不一致:\n%s", golden, got)
+	}
+}
+
+func TestLessUTF16(t *testing.T) {
+	// U+FF5E 在 UTF-16 中大于代理对 U+20000（0xD840），按码点比较则相反
+	if !lessUTF16("\U00020000", "～") {
+		t.Errorf("lessUTF16(U+20000, U+FF5E) = false, want true")
+	}
+	if lessUTF16("ab", "a") || !lessUTF16("a", "ab") {
+		t.Errorf("lessUTF16 前缀比较错误")
+	}
+}
+
+func TestLunrScriptServedLocally(t *testing.T) {
+	g := newTestGenerator(t, map[string]string{"a.md": markdownNovel("小说甲", 1)})
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	_, vendored := vendoredLunr()
+	_, err := os.Stat(filepath.Join(g.config.OutputDir, "static", "js", "lunr.min.js"))
+	if vendored != (err == nil) {
+		t.Errorf("附带 lunr.js = %v, 但 static/js/lunr.min.js 存在 = %v", vendored, err == nil)
+	}
+
+	want := lunrCDNURL
+	if vendored {
+		want = g.config.Site.BaseURL + "static/js/lunr.min.js"
+	}
+	if got := g.lunrScriptURL(); got != want {
+		t.Errorf("lunrScriptURL() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// sitemapMaxURLs 站点地图协议规定单个文件最多包含的 URL 数
+const sitemapMaxURLs = 50000
+
+// sitemapNamespace 站点地图协议的命名空间
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// 各类页面的抓取优先级
+const (
+	sitemapPriorityHome    = "1.0"
+	sitemapPriorityNovel   = "0.8"
+	sitemapPriorityChapter = "0.5"
+)
+
+// sitemapURLSet 站点地图
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL 站点地图中的 URL
+type sitemapURL struct {
+	Loc      string `xml:"loc"`
+	LastMod  string `xml:"lastmod,omitempty"`
+	Priority string `xml:"priority"`
+}
+
+// sitemapIndex 站点地图索引，URL 数超过单个文件上限时使用
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	Xmlns    string         `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+// sitemapEntry 站点地图索引中的分片
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// generateSitemap 在输出目录根部生成 sitemap.xml
+// URL 数超过 sitemap.max_per_file 时生成 sitemap.xml 索引与 sitemap-1.xml、sitemap-2.xml 等分片
+func (g *Generator) generateSitemap() error {
+	// 删除上次生成的分片，小说减少后或关闭站点地图后不应残留
+	shards, _ := filepath.Glob(filepath.Join(g.config.OutputDir, "sitemap-*.xml"))
+	for _, shard := range shards {
+		os.Remove(shard)
+	}
+
+	if !g.config.Sitemap.Enabled {
+		os.Remove(filepath.Join(g.config.OutputDir, "sitemap.xml"))
+		return nil
+	}
+
+	urls := g.sitemapURLs()
+	maxPerFile := g.config.Sitemap.MaxPerFile
+	if maxPerFile <= 0 || maxPerFile > sitemapMaxURLs {
+		maxPerFile = sitemapMaxURLs
+	}
+
+	if len(urls) <= maxPerFile {
+		return writeSitemapXML(filepath.Join(g.config.OutputDir, "sitemap.xml"),
+			sitemapURLSet{Xmlns: sitemapNamespace, URLs: urls})
+	}
+
+	index := sitemapIndex{Xmlns: sitemapNamespace}
+	lastMod := time.Now().Format("2006-01-02")
+	for start, n := 0, 1; start < len(urls); start, n = start+maxPerFile, n+1 {
+		end := start + maxPerFile
+		if end > len(urls) {
+			end = len(urls)
+		}
+
+		name := fmt.Sprintf("sitemap-%d.xml", n)
+		if err := writeSitemapXML(filepath.Join(g.config.OutputDir, name),
+			sitemapURLSet{Xmlns: sitemapNamespace, URLs: urls[start:end]}); err != nil {
+			return err
+		}
+		index.Sitemaps = append(index.Sitemaps, sitemapEntry{Loc: g.sitemapBaseURL() + name, LastMod: lastMod})
+	}
+
+	return writeSitemapXML(filepath.Join(g.config.OutputDir, "sitemap.xml"), index)
+}
+
+// sitemapBaseURL 站点地图使用的绝对地址前缀，未配置 sitemap.base_url 时使用 site.base_url
+func (g *Generator) sitemapBaseURL() string {
+	baseURL := g.config.Sitemap.BaseURL
+	if baseURL == "" {
+		baseURL = g.config.Site.BaseURL
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return baseURL
+}
+
+// sitemapURLs 列出首页及其分页、小说目录页、分卷目录页与章节页
+func (g *Generator) sitemapURLs() []sitemapURL {
+	baseURL := g.sitemapBaseURL()
+	urls := []sitemapURL{{Loc: baseURL, Priority: sitemapPriorityHome}}
+	for page := 2; page <= g.indexPageCount(); page++ {
+		urls = append(urls, sitemapURL{Loc: baseURL + indexPageName(page), Priority: sitemapPriorityNovel})
+	}
+
+	for _, novel := range g.novels {
+		novelURL := baseURL + "novels/" + url.PathEscape(g.sanitizeFileName(novel.Title)) + "/"
+		urls = append(urls, sitemapURL{
+			Loc:      novelURL,
+			LastMod:  sitemapDate(novel.UpdatedAt),
+			Priority: sitemapPriorityNovel,
+		})
+
+		for _, volume := range displayVolumes(novel) {
+			urls = append(urls, sitemapURL{
+				Loc:      fmt.Sprintf("%svolume-%d/", novelURL, volume.ID),
+				LastMod:  sitemapDate(novel.UpdatedAt),
+				Priority: sitemapPriorityNovel,
+			})
+		}
+
+		for _, chapter := range novel.Chapters {
+			// 未变化而跳过生成的小说没有填充 Parts，这里重新计算分页
+			parts := len(g.chapterParts(chapter))
+			if parts == 0 {
+				parts = 1
+			}
+			for part := 1; part <= parts; part++ {
+				urls = append(urls, sitemapURL{
+					Loc:      novelURL + chapterPartName(chapter.ID, part),
+					LastMod:  sitemapDate(chapter.CreatedAt),
+					Priority: sitemapPriorityChapter,
+				})
+			}
+		}
+	}
+
+	return urls
+}
+
+// sitemapDate 格式化 lastmod，时间未知时省略
+func sitemapDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// writeSitemapXML 将站点地图或索引写入文件
+func writeSitemapXML(path string, v interface{}) error {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化站点地图失败: %v", err)
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), data...), 0644)
+}
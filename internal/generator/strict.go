@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PartialBuildError 严格模式下站点已生成完毕，但过程中有被跳过的错误（如封面生成失败）
+type PartialBuildError struct {
+	Errors []error
+}
+
+func (e *PartialBuildError) Error() string {
+	return fmt.Sprintf("构建完成，但有 %d 个错误:\n%v", len(e.Errors), errors.Join(e.Errors...))
+}
+
+// Unwrap 支持 errors.Is / errors.As 检查其中的单个错误
+func (e *PartialBuildError) Unwrap() []error {
+	return e.Errors
+}
+
+// warn 输出警告并继续生成；启用 build.strict_mode 时同时记录，生成结束后一并返回
+func (g *Generator) warn(err error) {
+	fmt.Printf("警告：%v\n", err)
+	if !g.config.Build.StrictMode {
+		return
+	}
+
+	g.warningsMu.Lock()
+	g.warnings = append(g.warnings, err)
+	g.warningsMu.Unlock()
+}
+
+// takeWarnings 取出并清空已记录的警告，没有警告时返回 nil
+func (g *Generator) takeWarnings() error {
+	g.warningsMu.Lock()
+	defer g.warningsMu.Unlock()
+
+	if len(g.warnings) == 0 {
+		return nil
+	}
+	err := &PartialBuildError{Errors: g.warnings}
+	g.warnings = nil
+	return err
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+
+	"creeper/internal/parser"
+)
+
+// charsPerPage 估算页数时每页的字数
+const charsPerPage = 500
+
+// buildSiteJSONLD 生成首页的 WebSite 与 ItemList 结构化数据
+func (g *Generator) buildSiteJSONLD() (template.JS, error) {
+	baseURL := g.config.Site.BaseURL
+
+	items := make([]map[string]interface{}, 0, len(g.novels))
+	for i, novel := range g.novels {
+		items = append(items, map[string]interface{}{
+			"@type":       "ListItem",
+			"position":    i + 1,
+			"name":        novel.Title,
+			"url":         g.novelURL(novel),
+			"description": novel.Description,
+		})
+	}
+
+	graph := []map[string]interface{}{
+		{
+			"@type":       "WebSite",
+			"name":        g.config.Site.Title,
+			"description": g.config.Site.Description,
+			"url":         baseURL,
+			"potentialAction": map[string]interface{}{
+				"@type":       "SearchAction",
+				"target":      baseURL + "search.html?q={search_term_string}",
+				"query-input": "required name=search_term_string",
+			},
+		},
+		{
+			"@type":           "ItemList",
+			"numberOfItems":   len(items),
+			"itemListElement": items,
+		},
+	}
+
+	return marshalJSONLD(map[string]interface{}{
+		"@context": "https://schema.org",
+		"@graph":   graph,
+	})
+}
+
+// buildBookJSONLD 生成小说详情页的 Book 结构化数据
+func (g *Generator) buildBookJSONLD(novel *parser.Novel) (template.JS, error) {
+	totalWords := 0
+	for _, chapter := range novel.Chapters {
+		totalWords += chapter.WordCount
+	}
+
+	pages := (totalWords + charsPerPage - 1) / charsPerPage
+	if pages < 1 {
+		pages = 1
+	}
+
+	book := map[string]interface{}{
+		"@context":      "https://schema.org",
+		"@type":         "Book",
+		"name":          novel.Title,
+		"url":           g.novelURL(novel),
+		"description":   novel.Description,
+		"numberOfPages": pages,
+		"inLanguage":    "zh-CN",
+	}
+	if novel.Author != "" {
+		book["author"] = map[string]interface{}{
+			"@type": "Person",
+			"name":  novel.Author,
+		}
+	}
+	if !novel.CreatedAt.IsZero() {
+		book["datePublished"] = novel.CreatedAt.Format("2006-01-02")
+	}
+	if novel.Category != "" {
+		book["genre"] = novel.Category
+	}
+
+	return marshalJSONLD(book)
+}
+
+// novelURL 小说详情页地址
+func (g *Generator) novelURL(novel *parser.Novel) string {
+	return fmt.Sprintf("%snovels/%s/", g.config.Site.BaseURL, g.sanitizeFileName(novel.Title))
+}
+
+// marshalJSONLD 序列化结构化数据
+// json.Marshal 会转义 <、> 与 &，可安全嵌入 <script> 标签
+func marshalJSONLD(data interface{}) (template.JS, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("序列化结构化数据失败: %v", err)
+	}
+	return template.JS(raw), nil
+}
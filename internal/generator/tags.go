@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"creeper/internal/parser"
+)
+
+// sortedTagNames 按小说数量倒序、名称升序排列标签
+func sortedTagNames(tagMap map[string][]*parser.Novel) []string {
+	names := make([]string, 0, len(tagMap))
+	for tag := range tagMap {
+		names = append(names, tag)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(tagMap[names[i]]) != len(tagMap[names[j]]) {
+			return len(tagMap[names[i]]) > len(tagMap[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// generateTagPages 生成标签列表页 tags.html 与每个标签的 tags/<标签>.html
+func (g *Generator) generateTagPages() error {
+	tagMap := g.buildNovelIndex().tags
+	names := sortedTagNames(tagMap)
+
+	tags := make([]map[string]interface{}, 0, len(names))
+	for _, tag := range names {
+		tags = append(tags, map[string]interface{}{
+			"name":  tag,
+			"count": len(tagMap[tag]),
+			"url":   fmt.Sprintf("tags/%s.html", g.sanitizeFileName(tag)),
+		})
+	}
+
+	tagListData := map[string]interface{}{
+		"Config":      g.config,
+		"Tags":        tags,
+		"Title":       "标签",
+		"Description": "按标签浏览所有小说",
+	}
+
+	if err := g.renderTemplateToFile("tag-list", filepath.Join(g.config.OutputDir, "tags.html"), tagListData); err != nil {
+		return fmt.Errorf("生成标签列表页面失败: %v", err)
+	}
+
+	for _, tag := range names {
+		novels := tagMap[tag]
+		tagData := map[string]interface{}{
+			"Config": g.config,
+			"Tag":    tag,
+			"Novels": novels,
+			"Count":  len(novels),
+			"Title":  fmt.Sprintf("%s - 标签", tag),
+		}
+
+		tagPath := filepath.Join(g.config.OutputDir, "tags", fmt.Sprintf("%s.html", g.sanitizeFileName(tag)))
+
+		// 确保目录存在
+		if err := os.MkdirAll(filepath.Dir(tagPath), 0755); err != nil {
+			return fmt.Errorf("创建标签目录失败: %v", err)
+		}
+
+		if err := g.renderTemplateToFile("tag", tagPath, tagData); err != nil {
+			return fmt.Errorf("生成标签 %s 页面失败: %v", tag, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// BaseLayout 基础布局名称
+const BaseLayout = "base"
+
+// extendsRegex 匹配模板开头的 {{extends "name"}} 指令
+var extendsRegex = regexp.MustCompile(`^\s*\{\{-?\s*extends\s+"([^"]+)"\s*-?\}\}`)
+
+// TemplatePreprocessor 模板预处理器，为 html/template 提供 {{extends "name"}} 继承指令
+type TemplatePreprocessor struct {
+	layouts map[string]string
+}
+
+// NewTemplatePreprocessor 创建模板预处理器
+func NewTemplatePreprocessor() *TemplatePreprocessor {
+	return &TemplatePreprocessor{
+		layouts: make(map[string]string),
+	}
+}
+
+// RegisterLayout 注册可被继承的父模板
+func (p *TemplatePreprocessor) RegisterLayout(name, source string) {
+	p.layouts[name] = source
+}
+
+// Process 展开 {{extends "name"}} 指令
+// 子模板中定义的块（如 {{define "content"}}）会注入到父模板中，父模板自身也可以继续继承
+func (p *TemplatePreprocessor) Process(source string) (string, error) {
+	return p.process(source, make(map[string]bool))
+}
+
+// process 递归展开继承链并检测循环继承
+func (p *TemplatePreprocessor) process(source string, visited map[string]bool) (string, error) {
+	matches := extendsRegex.FindStringSubmatchIndex(source)
+	if matches == nil {
+		return source, nil
+	}
+
+	name := source[matches[2]:matches[3]]
+	if visited[name] {
+		return "", fmt.Errorf("模板循环继承: %s", name)
+	}
+	visited[name] = true
+
+	parent, exists := p.layouts[name]
+	if !exists {
+		return "", fmt.Errorf("父模板不存在: %s", name)
+	}
+
+	expandedParent, err := p.process(parent, visited)
+	if err != nil {
+		return "", err
+	}
+
+	return expandedParent + source[matches[1]:], nil
+}
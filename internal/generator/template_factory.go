@@ -3,6 +3,8 @@ package generator
 import (
 	"fmt"
 	"html/template"
+	"os"
+	"sort"
 )
 
 // TemplateType 模板类型
@@ -16,6 +18,14 @@ const (
 	CategoryTemplate    TemplateType = "category"
 	AuthorListTemplate  TemplateType = "author-list"
 	AuthorTemplate      TemplateType = "author"
+	SearchTemplate      TemplateType = "search"
+	GenreTemplate       TemplateType = "genre"
+	ChangelogTemplate   TemplateType = "changelog"
+	OfflineQueueTemplate TemplateType = "offline-queue"
+	TagListTemplate     TemplateType = "tag-list"
+	TagTemplate         TemplateType = "tag"
+	VolumeTemplate      TemplateType = "volume"
+	NovelStatsTemplate  TemplateType = "novel-stats"
 )
 
 // TemplateBuilder 模板构建器接口
@@ -28,12 +38,28 @@ type TemplateBuilder interface {
 type BaseTemplateBuilder struct {
 	templateType TemplateType
 	baseTemplate string
+	preprocessor *TemplatePreprocessor
 }
 
 func (b *BaseTemplateBuilder) GetType() TemplateType {
 	return b.templateType
 }
 
+// SetPreprocessor 设置模板预处理器
+func (b *BaseTemplateBuilder) SetPreprocessor(preprocessor *TemplatePreprocessor) {
+	b.preprocessor = preprocessor
+}
+
+// preprocess 展开页面模板中的 {{extends}} 指令，未设置预处理器时继承基础模板
+func (b *BaseTemplateBuilder) preprocess(content string) (string, error) {
+	preprocessor := b.preprocessor
+	if preprocessor == nil {
+		preprocessor = NewTemplatePreprocessor()
+		preprocessor.RegisterLayout(BaseLayout, b.baseTemplate)
+	}
+	return preprocessor.Process(content)
+}
+
 // IndexTemplateBuilder 首页模板构建器
 type IndexTemplateBuilder struct {
 	*BaseTemplateBuilder
@@ -49,11 +75,14 @@ func NewIndexTemplateBuilder(baseTemplate string) *IndexTemplateBuilder {
 }
 
 func (b *IndexTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
-	indexContent := `
+	indexContent := `{{extends "base"}}
 {{define "content"}}
+{{if not .PrevURL}}
+<div class="continue-reading" hidden></div>
+{{end}}
 <div class="hero">
     <h2>{{.Config.Site.Description}}</h2>
-    <p>共收录 {{len .Novels}} 部小说</p>
+    <p>共收录 {{if .TotalNovels}}{{.TotalNovels}}{{else}}{{len .Novels}}{{end}} 部小说</p>
 </div>
 
 <div class="novels-grid">
@@ -71,7 +100,7 @@ func (b *IndexTemplateBuilder) Build(funcMap template.FuncMap) (*template.Templa
             <p class="novel-author">作者：{{.Author}}</p>
             {{end}}
             {{if .Description}}
-            <p class="novel-description">{{.Description}}</p>
+            <p class="novel-description">{{truncate .Description 100}}</p>
             {{end}}
             <div class="novel-stats">
                 <span class="chapter-count">{{len .Chapters}} 章</span>
@@ -79,14 +108,46 @@ func (b *IndexTemplateBuilder) Build(funcMap template.FuncMap) (*template.Templa
                 {{if .Category}}
                 <span class="novel-category">{{.Category}}</span>
                 {{end}}
+                {{if .Difficulty}}
+                <span class="difficulty-badge difficulty-{{.Difficulty}}">{{difficultyLabel .Difficulty}}</span>
+                {{end}}
             </div>
+            {{if .Genre}}
+            <div class="novel-genres">
+                {{range .Genre}}
+                <a href="{{$.Config.Site.BaseURL}}genres/{{sanitizeFileName .}}.html" class="genre-badge">{{.}}</a>
+                {{end}}
+            </div>
+            {{end}}
+            {{if .Tags}}
+            <div class="novel-tags">
+                {{range .Tags}}
+                <a href="{{$.Config.Site.BaseURL}}tags/{{sanitizeFileName .}}.html" class="tag-badge">#{{.}}</a>
+                {{end}}
+            </div>
+            {{end}}
         </div>
     </div>
     {{end}}
 </div>
+
+{{if or .PrevURL .NextURL}}
+<nav class="pagination">
+    {{if .PrevURL}}
+    <a href="{{.PrevURL}}" class="btn btn-nav" rel="prev">上一页</a>
+    {{end}}
+    <span class="pagination-info">第 {{.CurrentPage}} / {{.TotalPages}} 页</span>
+    {{if .NextURL}}
+    <a href="{{.NextURL}}" class="btn btn-nav" rel="next">下一页</a>
+    {{end}}
+</nav>
+{{end}}
 {{end}}`
 
-	templateContent := b.baseTemplate + indexContent
+	templateContent, err := b.preprocess(indexContent)
+	if err != nil {
+		return nil, err
+	}
 	return template.New("index").Funcs(funcMap).Parse(templateContent)
 }
 
@@ -105,7 +166,7 @@ func NewNovelTemplateBuilder(baseTemplate string) *NovelTemplateBuilder {
 }
 
 func (b *NovelTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
-	novelContent := `
+	novelContent := `{{extends "base"}}
 {{define "content"}}
 <div class="novel-header">
     <div class="novel-meta">
@@ -123,15 +184,58 @@ func (b *NovelTemplateBuilder) Build(funcMap template.FuncMap) (*template.Templa
             {{end}}
             <div class="novel-stats">
                 <span class="chapter-count">共 {{len .Novel.Chapters}} 章</span>
+                {{if eq .Novel.Status "completed"}}
+                <span class="novel-status status-completed">已完结</span>
+                {{else if eq .Novel.Status "ongoing"}}
+                <span class="novel-status status-ongoing">连载中</span>
+                {{end}}
                 <span class="update-time">更新于 {{.Novel.UpdatedAt.Format "2006-01-02"}}</span>
+                {{with .Novel.EstimatedCompletionDate}}
+                <span class="completion-estimate" title="根据最近章节的更新频率估算，预计共 {{$.Novel.ExpectedChapters}} 章">预计完结：约 {{.Format "2006-01"}}
+                    <span class="confidence-badge confidence-{{$.Novel.CompletionConfidence}}">可信度{{confidenceLabel $.Novel.CompletionConfidence}}</span>
+                </span>
+                {{end}}
             </div>
             <div class="novel-actions">
                 <a href="chapter-1.html" class="btn btn-primary">开始阅读</a>
+                <a href="changelog.html" class="btn">更新日志</a>
+                <a href="stats.html" class="btn">阅读统计</a>
             </div>
         </div>
     </div>
 </div>
 
+{{if .Volumes}}
+{{if .UngroupedChapters}}
+<div class="chapters-list">
+    <h2>章节目录</h2>
+    <div class="chapters-grid">
+        {{range .UngroupedChapters}}
+        <div class="chapter-item">
+            <a href="chapter-{{.ID}}.html" class="chapter-link">
+                <span class="chapter-title">{{.Title}}</span>
+                <span class="chapter-stats">{{formatWordCount .WordCount}}</span>
+            </a>
+        </div>
+        {{end}}
+    </div>
+</div>
+{{end}}
+
+<div class="chapters-list volumes-list">
+    <h2>分卷目录</h2>
+    <div class="chapters-grid">
+        {{range .Volumes}}
+        <div class="chapter-item">
+            <a href="volume-{{.ID}}/index.html" class="chapter-link">
+                <span class="chapter-title">{{.Title}}</span>
+                <span class="chapter-stats">{{len .Chapters}} 章 · {{totalWordCount .Chapters}}</span>
+            </a>
+        </div>
+        {{end}}
+    </div>
+</div>
+{{else}}
 <div class="chapters-list">
     <h2>章节目录</h2>
     <div class="chapters-grid">
@@ -145,9 +249,13 @@ func (b *NovelTemplateBuilder) Build(funcMap template.FuncMap) (*template.Templa
         {{end}}
     </div>
 </div>
+{{end}}
 {{end}}`
 
-	templateContent := b.baseTemplate + novelContent
+	templateContent, err := b.preprocess(novelContent)
+	if err != nil {
+		return nil, err
+	}
 	return template.New("novel").Funcs(funcMap).Parse(templateContent)
 }
 
@@ -166,7 +274,7 @@ func NewChapterTemplateBuilder(baseTemplate string) *ChapterTemplateBuilder {
 }
 
 func (b *ChapterTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
-	chapterContent := `
+	chapterContent := `{{extends "base"}}
 {{define "content"}}
 <div class="chapter-header">
     <nav class="breadcrumb">
@@ -181,19 +289,38 @@ func (b *ChapterTemplateBuilder) Build(funcMap template.FuncMap) (*template.Temp
     
     <div class="chapter-nav">
         {{if gt .Chapter.ID 1}}
-        <a href="chapter-{{sub .Chapter.ID 1}}.html" class="btn btn-nav">上一章</a>
+        <a href="chapter-{{sub .Chapter.ID 1}}.html" class="btn btn-nav" data-nav="prev">上一章</a>
         {{end}}
         <a href="./index.html" class="btn btn-nav">目录</a>
         {{if lt .Chapter.ID (len .Novel.Chapters)}}
-        <a href="chapter-{{add .Chapter.ID 1}}.html" class="btn btn-nav">下一章</a>
+        <a href="chapter-{{add .Chapter.ID 1}}.html" class="btn btn-nav" data-nav="next">下一章</a>
         {{end}}
     </div>
 </div>
 
-<article class="chapter-content">
-    {{.Chapter.HTMLContent | printf "%s" | safeHTML}}
+<article class="chapter-content"{{with .Novel.Language}} lang="{{.}}"{{end}} data-novel="{{.Novel.Title}}" data-chapter-id="{{.Chapter.ID}}" data-chapter-title="{{.Chapter.Title}}"{{if .Config.Build.ChapterTransitions}} data-transitions{{end}}>
+    {{if .TotalParts}}{{.PartContent | printf "%s" | safeHTML}}{{else}}{{.Chapter.HTMLContent | printf "%s" | safeHTML}}{{end}}
 </article>
 
+{{if .TotalParts}}
+<nav class="pagination chapter-parts">
+    {{with .PrevPartURL}}
+    <a href="{{.}}" class="btn btn-nav" rel="prev">上一页</a>
+    {{end}}
+    <span class="pagination-info">第 {{.Part}} / {{.TotalParts}} 页</span>
+    {{with .NextPartURL}}
+    <a href="{{.}}" class="btn btn-nav" rel="next">下一页</a>
+    {{end}}
+</nav>
+{{end}}
+
+{{if and .Chapter.AuthorNote (not .NextPartURL)}}
+<aside class="author-note">
+    <h3 class="author-note-title">作者有话说</h3>
+    <div class="author-note-content">{{.Chapter.AuthorNote}}</div>
+</aside>
+{{end}}
+
 <div class="chapter-footer">
     <div class="chapter-info">
         <p>字数：{{formatWordCount .Chapter.WordCount}}</p>
@@ -201,17 +328,20 @@ func (b *ChapterTemplateBuilder) Build(funcMap template.FuncMap) (*template.Temp
     
     <div class="chapter-nav">
         {{if gt .Chapter.ID 1}}
-        <a href="chapter-{{sub .Chapter.ID 1}}.html" class="btn btn-nav">上一章</a>
+        <a href="chapter-{{sub .Chapter.ID 1}}.html" class="btn btn-nav" data-nav="prev">上一章</a>
         {{end}}
         <a href="./index.html" class="btn btn-nav">目录</a>
         {{if lt .Chapter.ID (len .Novel.Chapters)}}
-        <a href="chapter-{{add .Chapter.ID 1}}.html" class="btn btn-nav">下一章</a>
+        <a href="chapter-{{add .Chapter.ID 1}}.html" class="btn btn-nav" data-nav="next">下一章</a>
         {{end}}
     </div>
 </div>
 {{end}}`
 
-	templateContent := b.baseTemplate + chapterContent
+	templateContent, err := b.preprocess(chapterContent)
+	if err != nil {
+		return nil, err
+	}
 	return template.New("chapter").Funcs(funcMap).Parse(templateContent)
 }
 
@@ -219,6 +349,7 @@ func (b *ChapterTemplateBuilder) Build(funcMap template.FuncMap) (*template.Temp
 type TemplateFactory struct {
 	baseTemplate string
 	builders     map[TemplateType]TemplateBuilder
+	preprocessor *TemplatePreprocessor
 }
 
 // NewTemplateFactory 创建模板工厂
@@ -226,7 +357,9 @@ func NewTemplateFactory(baseTemplate string) *TemplateFactory {
 	factory := &TemplateFactory{
 		baseTemplate: baseTemplate,
 		builders:     make(map[TemplateType]TemplateBuilder),
+		preprocessor: NewTemplatePreprocessor(),
 	}
+	factory.RegisterLayout(BaseLayout, baseTemplate)
 	
 	// 注册模板构建器
 	factory.RegisterBuilder(NewIndexTemplateBuilder(baseTemplate))
@@ -236,15 +369,61 @@ func NewTemplateFactory(baseTemplate string) *TemplateFactory {
 	factory.RegisterBuilder(NewCategoryTemplateBuilder(baseTemplate))
 	factory.RegisterBuilder(NewAuthorListTemplateBuilder(baseTemplate))
 	factory.RegisterBuilder(NewAuthorTemplateBuilder(baseTemplate))
+	factory.RegisterBuilder(NewSearchTemplateBuilder(baseTemplate))
+	factory.RegisterBuilder(NewGenreTemplateBuilder(baseTemplate))
+	factory.RegisterBuilder(NewChangelogTemplateBuilder(baseTemplate))
+	factory.RegisterBuilder(NewOfflineQueueTemplateBuilder(baseTemplate))
+	factory.RegisterBuilder(NewTagListTemplateBuilder(baseTemplate))
+	factory.RegisterBuilder(NewTagTemplateBuilder(baseTemplate))
+	factory.RegisterBuilder(NewVolumeTemplateBuilder(baseTemplate))
+	factory.RegisterBuilder(NewNovelStatsTemplateBuilder(baseTemplate))
 	
 	return factory
 }
 
 // RegisterBuilder 注册模板构建器
 func (f *TemplateFactory) RegisterBuilder(builder TemplateBuilder) {
+	if aware, ok := builder.(interface{ SetPreprocessor(*TemplatePreprocessor) }); ok {
+		aware.SetPreprocessor(f.preprocessor)
+	}
 	f.builders[builder.GetType()] = builder
 }
 
+// RegisterLayout 注册可通过 {{extends "name"}} 继承的父模板
+func (f *TemplateFactory) RegisterLayout(name, source string) {
+	f.preprocessor.RegisterLayout(name, source)
+}
+
+// FileTemplateBuilder 从磁盘文件读取模板内容的构建器，用于覆盖内置模板
+type FileTemplateBuilder struct {
+	*BaseTemplateBuilder
+	path string
+}
+
+// NewFileTemplateBuilder 创建文件模板构建器
+func NewFileTemplateBuilder(templateType TemplateType, path, baseTemplate string) *FileTemplateBuilder {
+	return &FileTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: templateType,
+			baseTemplate: baseTemplate,
+		},
+		path: path,
+	}
+}
+
+func (b *FileTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	content, err := os.ReadFile(b.path)
+	if err != nil {
+		return nil, fmt.Errorf("读取模板文件 %s 失败: %v", b.path, err)
+	}
+
+	templateContent, err := b.preprocess(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("预处理模板文件 %s 失败: %v", b.path, err)
+	}
+	return template.New(string(b.templateType)).Funcs(funcMap).Parse(templateContent)
+}
+
 // CreateTemplate 创建模板
 func (f *TemplateFactory) CreateTemplate(templateType TemplateType, funcMap template.FuncMap) (*template.Template, error) {
 	builder, exists := f.builders[templateType]
@@ -255,12 +434,13 @@ func (f *TemplateFactory) CreateTemplate(templateType TemplateType, funcMap temp
 	return builder.Build(funcMap)
 }
 
-// GetAvailableTypes 获取可用的模板类型
+// GetAvailableTypes 获取可用的模板类型，按名称排序
 func (f *TemplateFactory) GetAvailableTypes() []TemplateType {
 	types := make([]TemplateType, 0, len(f.builders))
 	for templateType := range f.builders {
 		types = append(types, templateType)
 	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
 	return types
 }
 
@@ -279,7 +459,7 @@ func NewCategoryListTemplateBuilder(baseTemplate string) *CategoryListTemplateBu
 }
 
 func (b *CategoryListTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
-	categoryListContent := `
+	categoryListContent := `{{extends "base"}}
 {{define "content"}}
 <div class="page-header">
     <h1>分类浏览</h1>
@@ -298,13 +478,23 @@ func (b *CategoryListTemplateBuilder) Build(funcMap template.FuncMap) (*template
             <div class="category-stats">
                 <span class="novel-count">{{.count}} 部小说</span>
             </div>
+            {{if .sub_categories}}
+            <ul class="sub-categories">
+                {{range .sub_categories}}
+                <li><a href="{{$.Config.Site.BaseURL}}{{.url}}">{{.name}}<span class="sub-category-count">{{.count}}</span></a></li>
+                {{end}}
+            </ul>
+            {{end}}
         </div>
     </div>
     {{end}}
 </div>
 {{end}}`
 
-	templateContent := b.baseTemplate + categoryListContent
+	templateContent, err := b.preprocess(categoryListContent)
+	if err != nil {
+		return nil, err
+	}
 	return template.New("category-list").Funcs(funcMap).Parse(templateContent)
 }
 
@@ -323,7 +513,7 @@ func NewCategoryTemplateBuilder(baseTemplate string) *CategoryTemplateBuilder {
 }
 
 func (b *CategoryTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
-	categoryContent := `
+	categoryContent := `{{extends "base"}}
 {{define "content"}}
 <div class="page-header">
     <nav class="breadcrumb">
@@ -331,6 +521,10 @@ func (b *CategoryTemplateBuilder) Build(funcMap template.FuncMap) (*template.Tem
         <span class="separator">/</span>
         <a href="{{$.Config.Site.BaseURL}}categories.html">分类</a>
         <span class="separator">/</span>
+        {{if .Parent}}
+        <a href="{{$.Config.Site.BaseURL}}categories/{{sanitizeFileName .Parent}}.html">{{.Parent}}</a>
+        <span class="separator">/</span>
+        {{end}}
         <span class="current">{{.Category}}</span>
     </nav>
     
@@ -344,6 +538,13 @@ func (b *CategoryTemplateBuilder) Build(funcMap template.FuncMap) (*template.Tem
             </div>
         </div>
     </div>
+    {{if .SubCategories}}
+    <ul class="sub-categories">
+        {{range .SubCategories}}
+        <li><a href="{{$.Config.Site.BaseURL}}{{.url}}" title="{{.description}}">{{.icon}} {{.name}}<span class="sub-category-count">{{.count}}</span></a></li>
+        {{end}}
+    </ul>
+    {{end}}
 </div>
 
 <div class="novels-grid">
@@ -361,7 +562,7 @@ func (b *CategoryTemplateBuilder) Build(funcMap template.FuncMap) (*template.Tem
             <p class="novel-author">作者：{{.Author}}</p>
             {{end}}
             {{if .Description}}
-            <p class="novel-description">{{.Description}}</p>
+            <p class="novel-description">{{truncate .Description 100}}</p>
             {{end}}
             <div class="novel-stats">
                 <span class="chapter-count">{{len .Chapters}} 章</span>
@@ -373,7 +574,10 @@ func (b *CategoryTemplateBuilder) Build(funcMap template.FuncMap) (*template.Tem
 </div>
 {{end}}`
 
-	templateContent := b.baseTemplate + categoryContent
+	templateContent, err := b.preprocess(categoryContent)
+	if err != nil {
+		return nil, err
+	}
 	return template.New("category").Funcs(funcMap).Parse(templateContent)
 }
 
@@ -392,7 +596,7 @@ func NewAuthorListTemplateBuilder(baseTemplate string) *AuthorListTemplateBuilde
 }
 
 func (b *AuthorListTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
-	authorListContent := `
+	authorListContent := `{{extends "base"}}
 {{define "content"}}
 <div class="page-header">
     <h1>作者作品</h1>
@@ -420,7 +624,10 @@ func (b *AuthorListTemplateBuilder) Build(funcMap template.FuncMap) (*template.T
 </div>
 {{end}}`
 
-	templateContent := b.baseTemplate + authorListContent
+	templateContent, err := b.preprocess(authorListContent)
+	if err != nil {
+		return nil, err
+	}
 	return template.New("author-list").Funcs(funcMap).Parse(templateContent)
 }
 
@@ -439,7 +646,7 @@ func NewAuthorTemplateBuilder(baseTemplate string) *AuthorTemplateBuilder {
 }
 
 func (b *AuthorTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
-	authorContent := `
+	authorContent := `{{extends "base"}}
 {{define "content"}}
 <div class="page-header">
     <nav class="breadcrumb">
@@ -460,11 +667,51 @@ func (b *AuthorTemplateBuilder) Build(funcMap template.FuncMap) (*template.Templ
                 {{if .LastUpdated}}
                 <span class="last-updated">最后更新：{{.LastUpdated}}</span>
                 {{end}}
+                {{with .Stats.AverageUpdateDays}}
+                <span class="update-frequency">平均 {{printf "%.1f" .}} 天更新一章</span>
+                {{end}}
             </div>
         </div>
     </div>
 </div>
 
+<div class="author-profile">
+    {{with .Stats.CategoryChart}}
+    <section class="author-profile-section">
+        <h2>分类分布</h2>
+        {{.}}
+    </section>
+    {{end}}
+
+    {{with .Stats.TopChapters}}
+    <section class="author-profile-section">
+        <h2>字数最多的章节</h2>
+        <ol class="author-top-chapters">
+            {{range .}}
+            <li>
+                <a href="{{$.Config.Site.BaseURL}}{{.URL}}">{{.Title}}</a>
+                <span class="author-chapter-meta">{{.Novel}} · {{formatWordCount .WordCount}}</span>
+            </li>
+            {{end}}
+        </ol>
+    </section>
+    {{end}}
+
+    {{with .Stats.Timeline}}
+    <section class="author-profile-section">
+        <h2>作品时间线</h2>
+        <ol class="author-timeline">
+            {{range .}}
+            <li>
+                <time datetime="{{.CreatedAt.Format "2006-01-02"}}">{{.CreatedAt.Format "2006-01"}}</time>
+                <a href="{{$.Config.Site.BaseURL}}novels/{{sanitizeFileName .Title}}/">{{.Title}}</a>
+            </li>
+            {{end}}
+        </ol>
+    </section>
+    {{end}}
+</div>
+
 <div class="novels-grid">
     {{range .Novels}}
     <div class="novel-card">
@@ -480,18 +727,551 @@ func (b *AuthorTemplateBuilder) Build(funcMap template.FuncMap) (*template.Templ
             <p class="novel-category">分类：{{.Category}}</p>
             {{end}}
             {{if .Description}}
-            <p class="novel-description">{{.Description}}</p>
+            <p class="novel-description">{{truncate .Description 100}}</p>
             {{end}}
             <div class="novel-stats">
                 <span class="chapter-count">{{len .Chapters}} 章</span>
                 <span class="word-count">{{totalWordCount .Chapters}} 总字数</span>
             </div>
+            <div class="novel-word-bar">{{wordCountBar (chapterWordCount .Chapters) $.TotalWords}}</div>
         </div>
     </div>
     {{end}}
 </div>
 {{end}}`
 
-	templateContent := b.baseTemplate + authorContent
+	templateContent, err := b.preprocess(authorContent)
+	if err != nil {
+		return nil, err
+	}
 	return template.New("author").Funcs(funcMap).Parse(templateContent)
 }
+
+// SearchTemplateBuilder 搜索页模板构建器
+// 静态页面无法在服务端按关键词筛选，筛选依赖 JavaScript，noscript 中提示改用浏览器页内查找
+type SearchTemplateBuilder struct {
+	*BaseTemplateBuilder
+}
+
+func NewSearchTemplateBuilder(baseTemplate string) *SearchTemplateBuilder {
+	return &SearchTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: SearchTemplate,
+			baseTemplate: baseTemplate,
+		},
+	}
+}
+
+func (b *SearchTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	searchContent := `{{extends "base"}}
+{{define "content"}}
+<div class="page-header">
+    <h1>搜索</h1>
+    <form action="{{$.Config.Site.BaseURL}}search.html" method="get" class="search-form">
+        <input type="text" name="q" id="search-page-query" placeholder="搜索小说或章节...">
+        <button type="submit" class="btn">搜索</button>
+    </form>
+    <p class="search-summary" id="search-page-summary">共 {{len .Entries}} 条内容</p>
+    <noscript><p class="search-summary">按关键词筛选需要启用 JavaScript，下面列出全部小说与章节，可使用浏览器的页内查找（Ctrl+F）定位。</p></noscript>
+</div>
+
+<ul class="search-page-results" id="search-page-results">
+    {{range .Entries}}
+    <li class="search-result-item" data-search="{{.title}} {{.author}} {{.novel}}">
+        <div class="search-result-title">
+            [{{if eq .type "novel"}}小说{{else}}章节{{end}}]
+            <a href="{{$.Config.Site.BaseURL}}{{.url}}">{{.title}}</a>
+        </div>
+        <div class="search-result-meta">{{if .novel}}{{.novel}} · {{end}}{{.author}}</div>
+    </li>
+    {{end}}
+</ul>
+
+<script>
+(function() {
+    const query = (new URLSearchParams(location.search).get('q') || '').trim();
+    if (!query) return;
+
+    const input = document.getElementById('search-page-query');
+    const summary = document.getElementById('search-page-summary');
+    const items = document.querySelectorAll('#search-page-results .search-result-item');
+    const keyword = query.toLowerCase();
+    let matched = 0;
+
+    input.value = query;
+    items.forEach(item => {
+        const hit = item.dataset.search.toLowerCase().includes(keyword);
+        item.style.display = hit ? '' : 'none';
+        if (hit) matched++;
+    });
+    summary.textContent = '“' + query + '” 共找到 ' + matched + ' 条结果';
+})();
+</script>
+{{end}}`
+
+	templateContent, err := b.preprocess(searchContent)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("search").Funcs(funcMap).Parse(templateContent)
+}
+
+// OfflineQueueTemplateBuilder 离线阅读队列模板构建器
+type OfflineQueueTemplateBuilder struct {
+	*BaseTemplateBuilder
+}
+
+func NewOfflineQueueTemplateBuilder(baseTemplate string) *OfflineQueueTemplateBuilder {
+	return &OfflineQueueTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: OfflineQueueTemplate,
+			baseTemplate: baseTemplate,
+		},
+	}
+}
+
+func (b *OfflineQueueTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	offlineContent := `{{extends "base"}}
+{{define "content"}}
+<div class="page-header">
+    <h1>离线阅读</h1>
+    <p class="offline-summary" id="offline-summary">正在读取离线章节...</p>
+    <noscript><p>离线阅读需要启用 JavaScript。</p></noscript>
+</div>
+
+<ul class="offline-queue" id="offline-queue"></ul>
+
+<script>
+document.addEventListener('DOMContentLoaded', function() {
+    const summary = document.getElementById('offline-summary');
+    const list = document.getElementById('offline-queue');
+    const OfflineQueue = window.CreeperOfflineQueue;
+
+    if (!OfflineQueue || !OfflineQueue.isSupported()) {
+        summary.textContent = '当前浏览器不支持离线阅读';
+        return;
+    }
+
+    const queue = new OfflineQueue();
+
+    function button(text, onClick) {
+        const btn = document.createElement('button');
+        btn.className = 'btn btn-nav';
+        btn.textContent = text;
+        btn.addEventListener('click', onClick);
+        return btn;
+    }
+
+    function render() {
+        queue.list().then(function(entries) {
+            const unread = entries.filter(function(entry) { return !entry.read; }).length;
+            summary.textContent = entries.length === 0
+                ? '还没有离线保存的章节，可在章节页面的工具栏中点击 📥 保存'
+                : '共 ' + entries.length + ' 章，未读 ' + unread + ' 章';
+
+            list.innerHTML = '';
+            entries.forEach(function(entry) {
+                const item = document.createElement('li');
+                item.className = 'offline-item' + (entry.read ? ' offline-read' : '');
+
+                const info = document.createElement('div');
+                info.className = 'offline-info';
+                const link = document.createElement('a');
+                link.href = entry.url;
+                link.className = 'offline-title';
+                link.textContent = entry.title;
+                const meta = document.createElement('div');
+                meta.className = 'offline-meta';
+                meta.textContent = (entry.novel ? entry.novel + ' · ' : '') +
+                    '保存于 ' + new Date(entry.savedAt).toLocaleString();
+                info.appendChild(link);
+                info.appendChild(meta);
+
+                const status = document.createElement('span');
+                status.className = 'offline-status';
+                status.textContent = entry.read ? '已读' : '未读';
+
+                const actions = document.createElement('div');
+                actions.className = 'offline-actions';
+                actions.appendChild(button(entry.read ? '标为未读' : '标为已读', function() {
+                    queue.markRead(entry.url, !entry.read).then(render);
+                }));
+                actions.appendChild(button('删除', function() {
+                    queue.remove(entry.url).then(render);
+                }));
+
+                item.appendChild(info);
+                item.appendChild(status);
+                item.appendChild(actions);
+                list.appendChild(item);
+            });
+        }).catch(function(error) {
+            summary.textContent = '读取离线章节失败: ' + error;
+        });
+    }
+
+    render();
+});
+</script>
+{{end}}`
+
+	templateContent, err := b.preprocess(offlineContent)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("offline-queue").Funcs(funcMap).Parse(templateContent)
+}
+
+// GenreTemplateBuilder 题材详情模板构建器
+type GenreTemplateBuilder struct {
+	*BaseTemplateBuilder
+}
+
+func NewGenreTemplateBuilder(baseTemplate string) *GenreTemplateBuilder {
+	return &GenreTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: GenreTemplate,
+			baseTemplate: baseTemplate,
+		},
+	}
+}
+
+func (b *GenreTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	genreContent := `{{extends "base"}}
+{{define "content"}}
+<div class="page-header">
+    <nav class="breadcrumb">
+        <a href="{{$.Config.Site.BaseURL}}">首页</a>
+        <span class="separator">/</span>
+        <span class="current">{{.Genre}}</span>
+    </nav>
+
+    <div class="genre-header">
+        <h1><span class="genre-badge">{{.Genre}}</span></h1>
+        <div class="genre-stats">
+            <span class="novel-count">{{.Count}} 部小说</span>
+        </div>
+    </div>
+</div>
+
+<div class="novels-grid">
+    {{range .Novels}}
+    <div class="novel-card">
+        <div class="novel-cover">
+            <img src="{{$.Config.Site.BaseURL}}novels/{{sanitizeFileName .Title}}/cover.svg" alt="{{.Title}} 封面" 
+                 onerror="this.src='{{$.Config.Site.BaseURL}}static/images/default-cover.svg'">
+        </div>
+        <div class="novel-info">
+            <h3 class="novel-title">
+                <a href="{{$.Config.Site.BaseURL}}novels/{{sanitizeFileName .Title}}/">{{.Title}}</a>
+            </h3>
+            {{if .Author}}
+            <p class="novel-author">作者：{{.Author}}</p>
+            {{end}}
+            {{if .Description}}
+            <p class="novel-description">{{truncate .Description 100}}</p>
+            {{end}}
+            <div class="novel-stats">
+                <span class="chapter-count">{{len .Chapters}} 章</span>
+                <span class="word-count">{{totalWordCount .Chapters}} 总字数</span>
+            </div>
+            <div class="novel-genres">
+                {{range .Genre}}
+                <a href="{{$.Config.Site.BaseURL}}genres/{{sanitizeFileName .}}.html" class="genre-badge">{{.}}</a>
+                {{end}}
+            </div>
+        </div>
+    </div>
+    {{end}}
+</div>
+{{end}}`
+
+	templateContent, err := b.preprocess(genreContent)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("genre").Funcs(funcMap).Parse(templateContent)
+}
+
+// ChangelogTemplateBuilder 更新日志模板构建器
+type ChangelogTemplateBuilder struct {
+	*BaseTemplateBuilder
+}
+
+func NewChangelogTemplateBuilder(baseTemplate string) *ChangelogTemplateBuilder {
+	return &ChangelogTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: ChangelogTemplate,
+			baseTemplate: baseTemplate,
+		},
+	}
+}
+
+func (b *ChangelogTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	changelogContent := `{{extends "base"}}
+{{define "content"}}
+<div class="page-header">
+    <nav class="breadcrumb">
+        <a href="{{$.Config.Site.BaseURL}}">首页</a>
+        <span class="separator">/</span>
+        <a href="./index.html">{{.Novel.Title}}</a>
+        <span class="separator">/</span>
+        <span class="current">更新日志</span>
+    </nav>
+    <h1>更新日志</h1>
+    <p>按发布时间倒序排列，{{.NewChapterDays}} 天内发布的章节标记为新章节</p>
+</div>
+
+<div class="changelog-list">
+    {{range .Entries}}
+    <div class="changelog-item">
+        <span class="changelog-date">{{.Chapter.CreatedAt.Format "2006-01-02"}}</span>
+        <a href="chapter-{{.Chapter.ID}}.html" class="chapter-link">{{.Chapter.Title}}</a>
+        {{if .IsNew}}<span class="new-badge">New</span>{{end}}
+    </div>
+    {{end}}
+</div>
+{{end}}`
+
+	templateContent, err := b.preprocess(changelogContent)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("changelog").Funcs(funcMap).Parse(templateContent)
+}
+
+// TagListTemplateBuilder 标签列表模板构建器
+type TagListTemplateBuilder struct {
+	*BaseTemplateBuilder
+}
+
+func NewTagListTemplateBuilder(baseTemplate string) *TagListTemplateBuilder {
+	return &TagListTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: TagListTemplate,
+			baseTemplate: baseTemplate,
+		},
+	}
+}
+
+func (b *TagListTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	tagListContent := `{{extends "base"}}
+{{define "content"}}
+<div class="page-header">
+    <h1>标签</h1>
+    <p>共 {{len .Tags}} 个标签</p>
+</div>
+
+<div class="tag-cloud">
+    {{range .Tags}}
+    <a href="{{$.Config.Site.BaseURL}}{{.url}}" class="tag-badge">#{{.name}}<span class="tag-count">{{.count}}</span></a>
+    {{end}}
+</div>
+{{end}}`
+
+	templateContent, err := b.preprocess(tagListContent)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("tag-list").Funcs(funcMap).Parse(templateContent)
+}
+
+// TagTemplateBuilder 标签详情模板构建器
+type TagTemplateBuilder struct {
+	*BaseTemplateBuilder
+}
+
+func NewTagTemplateBuilder(baseTemplate string) *TagTemplateBuilder {
+	return &TagTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: TagTemplate,
+			baseTemplate: baseTemplate,
+		},
+	}
+}
+
+func (b *TagTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	tagContent := `{{extends "base"}}
+{{define "content"}}
+<div class="page-header">
+    <nav class="breadcrumb">
+        <a href="{{$.Config.Site.BaseURL}}">首页</a>
+        <span class="separator">/</span>
+        <a href="{{$.Config.Site.BaseURL}}tags.html">标签</a>
+        <span class="separator">/</span>
+        <span class="current">{{.Tag}}</span>
+    </nav>
+
+    <div class="tag-header">
+        <h1><span class="tag-badge">#{{.Tag}}</span></h1>
+        <div class="tag-stats">
+            <span class="novel-count">{{.Count}} 部小说</span>
+        </div>
+    </div>
+</div>
+
+<div class="novels-grid">
+    {{range .Novels}}
+    <div class="novel-card">
+        <div class="novel-cover">
+            <img src="{{$.Config.Site.BaseURL}}novels/{{sanitizeFileName .Title}}/cover.svg" alt="{{.Title}} 封面" 
+                 onerror="this.src='{{$.Config.Site.BaseURL}}static/images/default-cover.svg'">
+        </div>
+        <div class="novel-info">
+            <h3 class="novel-title">
+                <a href="{{$.Config.Site.BaseURL}}novels/{{sanitizeFileName .Title}}/">{{.Title}}</a>
+            </h3>
+            {{if .Author}}
+            <p class="novel-author">作者：{{.Author}}</p>
+            {{end}}
+            {{if .Description}}
+            <p class="novel-description">{{truncate .Description 100}}</p>
+            {{end}}
+            <div class="novel-stats">
+                <span class="chapter-count">{{len .Chapters}} 章</span>
+                <span class="word-count">{{totalWordCount .Chapters}} 总字数</span>
+            </div>
+            <div class="novel-tags">
+                {{range .Tags}}
+                <a href="{{$.Config.Site.BaseURL}}tags/{{sanitizeFileName .}}.html" class="tag-badge">#{{.}}</a>
+                {{end}}
+            </div>
+        </div>
+    </div>
+    {{end}}
+</div>
+{{end}}`
+
+	templateContent, err := b.preprocess(tagContent)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("tag").Funcs(funcMap).Parse(templateContent)
+}
+
+// VolumeTemplateBuilder 分卷目录模板构建器
+type VolumeTemplateBuilder struct {
+	*BaseTemplateBuilder
+}
+
+func NewVolumeTemplateBuilder(baseTemplate string) *VolumeTemplateBuilder {
+	return &VolumeTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: VolumeTemplate,
+			baseTemplate: baseTemplate,
+		},
+	}
+}
+
+func (b *VolumeTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	volumeContent := `{{extends "base"}}
+{{define "content"}}
+<div class="page-header">
+    <nav class="breadcrumb">
+        <a href="{{$.Config.Site.BaseURL}}">首页</a>
+        <span class="separator">/</span>
+        <a href="../index.html">{{.Novel.Title}}</a>
+        <span class="separator">/</span>
+        <span class="current">{{.Volume.Title}}</span>
+    </nav>
+
+    <h1>{{.Volume.Title}}</h1>
+    <div class="novel-stats">
+        <span class="chapter-count">共 {{len .Volume.Chapters}} 章</span>
+        <span class="word-count">{{totalWordCount .Volume.Chapters}}</span>
+    </div>
+</div>
+
+<div class="chapters-list">
+    <h2>章节目录</h2>
+    <div class="chapters-grid">
+        {{range .Volume.Chapters}}
+        <div class="chapter-item">
+            <a href="../chapter-{{.ID}}.html" class="chapter-link">
+                <span class="chapter-title">{{.Title}}</span>
+                <span class="chapter-stats">{{formatWordCount .WordCount}}</span>
+            </a>
+        </div>
+        {{end}}
+    </div>
+</div>
+
+<div class="chapter-nav">
+    {{with .PrevVolume}}
+    <a href="../volume-{{.ID}}/index.html" class="btn btn-nav">上一卷</a>
+    {{end}}
+    <a href="../index.html" class="btn btn-nav">目录</a>
+    {{with .NextVolume}}
+    <a href="../volume-{{.ID}}/index.html" class="btn btn-nav">下一卷</a>
+    {{end}}
+</div>
+{{end}}`
+
+	templateContent, err := b.preprocess(volumeContent)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("volume").Funcs(funcMap).Parse(templateContent)
+}
+
+// NovelStatsTemplateBuilder 小说阅读统计模板构建器
+type NovelStatsTemplateBuilder struct {
+	*BaseTemplateBuilder
+}
+
+func NewNovelStatsTemplateBuilder(baseTemplate string) *NovelStatsTemplateBuilder {
+	return &NovelStatsTemplateBuilder{
+		BaseTemplateBuilder: &BaseTemplateBuilder{
+			templateType: NovelStatsTemplate,
+			baseTemplate: baseTemplate,
+		},
+	}
+}
+
+func (b *NovelStatsTemplateBuilder) Build(funcMap template.FuncMap) (*template.Template, error) {
+	statsContent := `{{extends "base"}}
+{{define "content"}}
+<div class="page-header">
+    <nav class="breadcrumb">
+        <a href="{{$.Config.Site.BaseURL}}">首页</a>
+        <span class="separator">/</span>
+        <a href="./index.html">{{.Novel.Title}}</a>
+        <span class="separator">/</span>
+        <span class="current">阅读统计</span>
+    </nav>
+    <h1>阅读统计</h1>
+</div>
+
+<div class="novel-stats-summary">
+    <div class="stat-item"><span class="stat-label">总字数</span><span class="stat-value">{{formatWordCount .TotalWords}}</span></div>
+    <div class="stat-item"><span class="stat-label">章节数</span><span class="stat-value">{{.ChapterCount}} 章</span></div>
+    <div class="stat-item"><span class="stat-label">平均每章</span><span class="stat-value">{{formatWordCount .AverageWords}}</span></div>
+    {{with .ShortestChapter}}
+    <div class="stat-item"><span class="stat-label">最短章节</span><span class="stat-value"><a href="chapter-{{.ID}}.html">{{.Title}}</a> · {{formatWordCount .WordCount}}</span></div>
+    {{end}}
+    {{with .LongestChapter}}
+    <div class="stat-item"><span class="stat-label">最长章节</span><span class="stat-value"><a href="chapter-{{.ID}}.html">{{.Title}}</a> · {{formatWordCount .WordCount}}</span></div>
+    {{end}}
+    {{if .ReadingWPM}}
+    <div class="stat-item"><span class="stat-label">预计阅读时间</span><span class="stat-value">{{if .ReadingHours}}{{.ReadingHours}} 小时 {{end}}{{.ReadingMinutes}} 分钟</span><span class="stat-note">按每分钟 {{.ReadingWPM}} 字估算</span></div>
+    {{end}}
+</div>
+
+{{with .Chart}}
+<section class="novel-stats-chart">
+    <h2>每章字数</h2>
+    <div class="chart-scroll">{{.}}</div>
+</section>
+{{end}}
+
+<div class="chapter-nav">
+    <a href="./index.html" class="btn btn-nav">返回目录</a>
+</div>
+{{end}}`
+
+	templateContent, err := b.preprocess(statsContent)
+	if err != nil {
+		return nil, err
+	}
+	return template.New("novel-stats").Funcs(funcMap).Parse(templateContent)
+}
@@ -2,7 +2,14 @@ package generator
 
 import (
 	"fmt"
+	"html"
 	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"creeper/internal/common"
 	"creeper/internal/parser"
 )
 
@@ -14,23 +21,51 @@ func (g *Generator) loadTemplates() error {
 	// 基础模板
 	baseTemplate := g.getBaseTemplate()
 	
-	// 使用工厂模式创建模板
+	// 使用工厂模式创建模板，页面模板通过 {{extends "base"}} 继承基础模板
 	factory := NewTemplateFactory(baseTemplate)
 	
-	// 创建各种类型的模板
-	templateTypes := []TemplateType{IndexTemplate, NovelTemplate, ChapterTemplate}
+	// 创建工厂中注册的全部模板，新增模板类型只需在 NewTemplateFactory 中注册
+	templateTypes := factory.GetAvailableTypes()
 	
+	// 自定义模板目录中的同名文件覆盖内置模板
+	for _, templateType := range templateTypes {
+		if path := g.customTemplatePath(templateType); path != "" {
+			factory.RegisterBuilder(NewFileTemplateBuilder(templateType, path, baseTemplate))
+		}
+	}
+	
+	templates := make(map[string]*template.Template, len(templateTypes))
 	for _, templateType := range templateTypes {
 		tmpl, err := factory.CreateTemplate(templateType, funcMap)
 		if err != nil {
 			return fmt.Errorf("创建%s模板失败: %v", templateType, err)
 		}
-		g.templates[string(templateType)] = tmpl
+		templates[string(templateType)] = tmpl
+	}
+
+	// 全部编译成功后再替换，渲染中的页面不会读到不完整的模板集合
+	g.templatesMu.Lock()
+	for name, tmpl := range templates {
+		g.templates[name] = tmpl
 	}
+	g.templatesMu.Unlock()
 
 	return nil
 }
 
+// customTemplatePath 返回自定义模板目录中对应模板类型的文件路径，不存在时返回空字符串
+func (g *Generator) customTemplatePath(templateType TemplateType) string {
+	if g.config.Theme.TemplateDir == "" {
+		return ""
+	}
+
+	path := filepath.Join(g.config.Theme.TemplateDir, string(templateType)+".html")
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return ""
+	}
+	return path
+}
+
 // getBaseTemplate 获取基础模板
 func (g *Generator) getBaseTemplate() string {
 	return `<!DOCTYPE html>
@@ -43,7 +78,12 @@ func (g *Generator) getBaseTemplate() string {
     <meta name="author" content="{{.Config.Site.Author}}">
     <link rel="stylesheet" href="{{.Config.Site.BaseURL}}static/css/style.css">
     <link rel="stylesheet" href="{{.Config.Site.BaseURL}}static/css/reading-enhanced.css">
+    <link rel="stylesheet" href="{{.Config.Site.BaseURL}}static/css/loading-skeleton.css">
     <link rel="icon" type="image/x-icon" href="{{.Config.Site.BaseURL}}static/images/favicon.ico">
+    <script>{{colorSchemeScript}}</script>
+    {{if .OPDS}}<link rel="opds-catalog" type="application/atom+xml;profile=opds-catalog;kind=acquisition" href="{{.OPDS}}" title="{{.Config.Site.Title}} OPDS">{{end}}
+    {{if .Feed}}<link rel="alternate" type="application/atom+xml" href="{{.Feed}}" title="{{.Title}} 更新">{{end}}
+    {{if .JSONLD}}<script type="application/ld+json">{{.JSONLD}}</script>{{end}}
 </head>
 <body>
     <header class="header">
@@ -55,10 +95,19 @@ func (g *Generator) getBaseTemplate() string {
                 <a href="{{.Config.Site.BaseURL}}" class="nav-link">首页</a>
                 <a href="{{.Config.Site.BaseURL}}categories.html" class="nav-link">分类</a>
                 <a href="{{.Config.Site.BaseURL}}authors.html" class="nav-link">作者</a>
+                <a href="{{.Config.Site.BaseURL}}offline-queue.html" class="nav-link">离线</a>
+                {{if .Config.Search.Enabled}}
                 <div class="search-box">
                     <input type="text" id="search-input" placeholder="搜索小说或章节...">
                     <div id="search-results" class="search-results"></div>
+                    <noscript>
+                        <form action="{{.Config.Site.BaseURL}}search.html" method="get" class="search-form">
+                            <input type="text" name="q" placeholder="搜索小说或章节...">
+                            <button type="submit">搜索</button>
+                        </form>
+                    </noscript>
                 </div>
+                {{end}}
             </nav>
         </div>
     </header>
@@ -75,6 +124,7 @@ func (g *Generator) getBaseTemplate() string {
         </div>
     </footer>
 
+    {{if .Config.Search.Enabled}}<script src="{{lunrScriptURL}}"></script>{{end}}
     <script src="{{.Config.Site.BaseURL}}static/js/main.js"></script>
     <script src="{{.Config.Site.BaseURL}}static/js/reading-enhanced.js"></script>
 </body>
@@ -85,6 +135,33 @@ func (g *Generator) getBaseTemplate() string {
 func (g *Generator) createTemplateFuncs() template.FuncMap {
 	return template.FuncMap{
 		"sanitizeFileName": g.sanitizeFileName,
+		"colorSchemeScript": g.colorSchemeScript,
+		"css":               g.cssSnippet,
+		"truncate":          truncate,
+		"excerpt":           excerpt,
+		"lunrScriptURL":     g.lunrScriptURL,
+		"difficultyLabel": func(difficulty string) string {
+			switch difficulty {
+			case parser.DifficultyBeginner:
+				return "入门"
+			case parser.DifficultyIntermediate:
+				return "进阶"
+			case parser.DifficultyAdvanced:
+				return "高阶"
+			}
+			return difficulty
+		},
+		"confidenceLabel": func(confidence string) string {
+			switch confidence {
+			case parser.CompletionConfidenceHigh:
+				return "高"
+			case parser.CompletionConfidenceMedium:
+				return "中"
+			case parser.CompletionConfidenceLow:
+				return "低"
+			}
+			return confidence
+		},
 		"add": func(a, b int) int {
 			return a + b
 		},
@@ -107,12 +184,49 @@ func (g *Generator) createTemplateFuncs() template.FuncMap {
 			}
 			return g.formatWordCount(total)
 		},
+		"chapterWordCount": func(chapters []*parser.Chapter) int {
+			total := 0
+			for _, chapter := range chapters {
+				total += chapter.WordCount
+			}
+			return total
+		},
+		"wordCountBar": g.wordCountBar,
 		"safeHTML": func(s string) template.HTML {
 			return template.HTML(s)
 		},
 	}
 }
 
+// cssSnippet 通过享元工厂按需生成选择器的 CSS 规则，如 {{css ".btn-primary"}}
+func (g *Generator) cssSnippet(selector string) (template.CSS, error) {
+	context, err := g.flyweights.GetCSS(common.CSSKeyPrefix + selector)
+	if err != nil {
+		return "", err
+	}
+	css, _ := context.GetSharedState()["css"].(string)
+	return template.CSS(css), nil
+}
+
+// wordCountBar 生成表示 count/limit 比例的 SVG 横向条形图
+func (g *Generator) wordCountBar(count, limit int) template.HTML {
+	const width, height = 200, 8
+
+	ratio := 0.0
+	if limit > 0 && count > 0 {
+		ratio = float64(count) / float64(limit)
+		if ratio > 1 {
+			ratio = 1
+		}
+	}
+
+	return template.HTML(fmt.Sprintf(
+		`<svg class="word-count-bar" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%d/%d"><rect width="%d" height="%d" rx="4" fill="#e9ecef"/><rect width="%.1f" height="%d" rx="4" fill="%s"/></svg>`,
+		width, height, width, height, count, limit,
+		width, height, ratio*width, height, template.HTMLEscapeString(g.config.Theme.PrimaryColor),
+	))
+}
+
 // formatWordCount 格式化字数显示
 func (g *Generator) formatWordCount(count int) string {
 	if count < 1000 {
@@ -123,3 +237,29 @@ func (g *Generator) formatWordCount(count int) string {
 		return fmt.Sprintf("%.1f万字", float64(count)/10000)
 	}
 }
+
+// descriptionExcerptLength 列表卡片与订阅源中简介的最大字符数
+const descriptionExcerptLength = 100
+
+// truncate 截取前 n 个字符（按 rune 计，不会截断中文），发生截断时追加省略号
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimRightFunc(string(runes[:n]), unicode.IsSpace) + "…"
+}
+
+// htmlTagRegex 匹配 HTML 标签
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// excerpt 去掉 HTML 标签并合并空白后截取前 maxRunes 个字符，用于搜索结果摘要与列表卡片
+// 如 {{excerpt .HTMLContent 80}}；返回值已转义，可直接输出
+func excerpt(htmlContent string, maxRunes int) template.HTML {
+	text := html.UnescapeString(htmlTagRegex.ReplaceAllString(htmlContent, " "))
+	text = strings.Join(strings.Fields(text), " ")
+	return template.HTML(template.HTMLEscapeString(truncate(text, maxRunes)))
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"creeper/internal/parser"
+)
+
+// displayVolumes 需要按卷展示的分卷，只有一卷（或不分卷）时返回 nil，目录页仍直接列出章节
+func displayVolumes(novel *parser.Novel) []*parser.Volume {
+	if len(novel.Volumes) <= 1 {
+		return nil
+	}
+	return novel.Volumes
+}
+
+// ungroupedChapters 不属于任何卷的章节，如第一卷之前的序章
+func ungroupedChapters(novel *parser.Novel) []*parser.Chapter {
+	inVolume := make(map[*parser.Chapter]bool, len(novel.Chapters))
+	for _, volume := range novel.Volumes {
+		for _, chapter := range volume.Chapters {
+			inVolume[chapter] = true
+		}
+	}
+
+	var chapters []*parser.Chapter
+	for _, chapter := range novel.Chapters {
+		if !inVolume[chapter] {
+			chapters = append(chapters, chapter)
+		}
+	}
+	return chapters
+}
+
+// generateVolumePages 为多卷小说生成 novels/<标题>/volume-<N>/index.html，只列出该卷的章节
+func (g *Generator) generateVolumePages(novel *parser.Novel, novelDir string) error {
+	volumes := displayVolumes(novel)
+	for i, volume := range volumes {
+		data := map[string]interface{}{
+			"Config": g.config,
+			"Novel":  novel,
+			"Volume": volume,
+			"Title":  fmt.Sprintf("%s - %s", volume.Title, novel.Title),
+		}
+		if i > 0 {
+			data["PrevVolume"] = volumes[i-1]
+		}
+		if i+1 < len(volumes) {
+			data["NextVolume"] = volumes[i+1]
+		}
+
+		volumePath := filepath.Join(novelDir, fmt.Sprintf("volume-%d", volume.ID), "index.html")
+		if err := os.MkdirAll(filepath.Dir(volumePath), 0755); err != nil {
+			return fmt.Errorf("创建分卷目录失败: %v", err)
+		}
+
+		if err := g.renderTemplateToFile("volume", volumePath, data); err != nil {
+			return fmt.Errorf("生成第 %d 卷目录页失败: %v", volume.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce 文件变化的默认防抖间隔
+// git checkout 等操作会在短时间内修改大量文件，合并为一次重建
+const DefaultWatchDebounce = 200 * time.Millisecond
+
+// WatchOptions 监听选项
+type WatchOptions struct {
+	Input     bool          // 监听输入目录，变化时完整重建
+	Templates bool          // 监听自定义模板目录，变化时只重新渲染相关页面
+	Debounce  time.Duration // 防抖间隔，为 0 时使用 DefaultWatchDebounce
+
+	// InputExtensions 输入目录中触发重建的文件扩展名（如 ".md"），为空时任何文件变化都会触发
+	InputExtensions []string
+	// OnRebuild 每次重建或重新渲染成功后调用，如通知浏览器刷新
+	OnRebuild func()
+}
+
+// templatePageSteps 模板类型对应的页面渲染步骤，多个模板可能共用同一步骤
+var templatePageSteps = map[TemplateType]string{
+	IndexTemplate:        "index",
+	NovelTemplate:        "novels",
+	ChapterTemplate:      "novels",
+	ChangelogTemplate:    "novels",
+	SearchTemplate:       "search",
+	GenreTemplate:        "genres",
+	CategoryListTemplate: "categories",
+	CategoryTemplate:     "categories",
+	AuthorListTemplate:   "authors",
+	AuthorTemplate:       "authors",
+	OfflineQueueTemplate: "offline",
+	TagListTemplate:      "tags",
+	TagTemplate:          "tags",
+	VolumeTemplate:       "novels",
+	NovelStatsTemplate:   "novels",
+}
+
+// pageRenderSteps 各页面渲染步骤（不重新解析小说）
+func (g *Generator) pageRenderSteps() map[string]func() error {
+	return map[string]func() error{
+		"index": g.generateIndex,
+		"novels": func() error {
+			return firstError(g.workerPool().Run(len(g.novels), func(i int) error {
+				if err := g.generateNovel(g.novels[i]); err != nil {
+					return fmt.Errorf("生成小说 %s 失败: %v", g.novels[i].Title, err)
+				}
+				return nil
+			}))
+		},
+		"search":     g.generateSearchPage,
+		"genres":     g.generateGenrePages,
+		"categories": g.generateCategoryPages,
+		"authors":    g.generateAuthorPages,
+		"offline":    g.generateOfflineQueuePage,
+		"tags":       g.generateTagPages,
+	}
+}
+
+// RebuildTemplates 重新编译模板，并只重新渲染使用了变化模板的页面
+// changed 为变化的模板文件路径；无法对应到模板类型的文件会触发所有页面的重新渲染
+func (g *Generator) RebuildTemplates(changed []string) error {
+	g.buildMu.Lock()
+	defer g.buildMu.Unlock()
+
+	if err := g.loadTemplates(); err != nil {
+		return fmt.Errorf("加载模板失败: %v", err)
+	}
+
+	steps := g.pageRenderSteps()
+	selected := make(map[string]bool)
+	for _, path := range changed {
+		templateType := TemplateType(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		step, known := templatePageSteps[templateType]
+		if !known {
+			selected = nil
+			break
+		}
+		selected[step] = true
+	}
+
+	for _, name := range []string{"index", "novels", "search", "offline", "categories", "authors", "genres", "tags"} {
+		if selected != nil && !selected[name] {
+			continue
+		}
+		if err := steps[name](); err != nil {
+			return fmt.Errorf("重新渲染页面失败: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Watch 监听输入目录与模板目录的变化并自动重建，直到 ctx 结束
+func (g *Generator) Watch(ctx context.Context, options WatchOptions) error {
+	if !options.Input && !options.Templates {
+		return nil
+	}
+	if options.Debounce <= 0 {
+		options.Debounce = DefaultWatchDebounce
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("创建文件监听器失败: %v", err)
+	}
+	defer watcher.Close()
+
+	inputDir := filepath.Clean(g.config.InputDir)
+	templateDir := ""
+	if options.Input {
+		if err := addWatchDirs(watcher, inputDir); err != nil {
+			return fmt.Errorf("监听输入目录失败: %v", err)
+		}
+		fmt.Printf("👀 监听输入目录: %s\n", inputDir)
+	}
+	if options.Templates {
+		if g.config.Theme.TemplateDir == "" {
+			return fmt.Errorf("未配置 theme.templates_dir，无法监听模板变化")
+		}
+		templateDir = filepath.Clean(g.config.Theme.TemplateDir)
+		if err := addWatchDirs(watcher, templateDir); err != nil {
+			return fmt.Errorf("监听模板目录失败: %v", err)
+		}
+		fmt.Printf("👀 监听模板目录: %s\n", templateDir)
+	}
+
+	var (
+		timer           *time.Timer
+		fire            <-chan time.Time
+		inputChanged    bool
+		changedTemplate = make(map[string]bool)
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod || isEditorTempFile(event.Name) {
+				continue
+			}
+
+			// 新建的子目录也需要监听
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					addWatchDirs(watcher, event.Name)
+				}
+			}
+
+			if templateDir != "" && isWithinDir(event.Name, templateDir) {
+				if !strings.EqualFold(filepath.Ext(event.Name), ".html") {
+					continue
+				}
+				changedTemplate[event.Name] = true
+			} else {
+				if !hasExtension(event.Name, options.InputExtensions) {
+					continue
+				}
+				inputChanged = true
+			}
+
+			if timer == nil {
+				timer = time.NewTimer(options.Debounce)
+			} else {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(options.Debounce)
+			}
+			fire = timer.C
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("警告：文件监听出错: %v\n", err)
+
+		case <-fire:
+			fire = nil
+			start := time.Now()
+
+			// 输入变化需要完整重建，已包含模板的重新加载
+			if inputChanged {
+				fmt.Printf("🔄 检测到小说文件变化，重新生成站点...\n")
+				if err := g.Generate(); err != nil {
+					fmt.Printf("❌ 重新生成失败: %v\n", err)
+				} else {
+					fmt.Printf("✅ 重新生成完成，耗时 %v\n", time.Since(start))
+					if options.OnRebuild != nil {
+						options.OnRebuild()
+					}
+				}
+			} else if len(changedTemplate) > 0 {
+				changed := make([]string, 0, len(changedTemplate))
+				for path := range changedTemplate {
+					changed = append(changed, path)
+				}
+				fmt.Printf("🔄 检测到 %d 个模板文件变化，重新渲染页面...\n", len(changed))
+				if err := g.RebuildTemplates(changed); err != nil {
+					fmt.Printf("❌ 重新渲染失败: %v\n", err)
+				} else {
+					fmt.Printf("✅ 重新渲染完成，耗时 %v\n", time.Since(start))
+					if options.OnRebuild != nil {
+						options.OnRebuild()
+					}
+				}
+			}
+
+			inputChanged = false
+			changedTemplate = make(map[string]bool)
+		}
+	}
+}
+
+// addWatchDirs 递归监听目录及其子目录
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if strings.HasPrefix(info.Name(), ".") && path != root {
+				return filepath.SkipDir
+			}
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// isWithinDir 判断路径是否位于目录内
+func isWithinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// hasExtension 判断文件扩展名是否在列表中（不区分大小写），列表为空时总是返回 true
+func hasExtension(path string, extensions []string) bool {
+	if len(extensions) == 0 {
+		return true
+	}
+	ext := filepath.Ext(path)
+	for _, candidate := range extensions {
+		if strings.EqualFold(ext, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEditorTempFile 判断是否为编辑器产生的临时文件（如 .swp、~ 结尾的备份）
+func isEditorTempFile(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") || strings.HasSuffix(name, ".swp")
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"runtime"
+	"sync"
+)
+
+// WorkerPool 固定并发数的任务池，用于并行解析小说与渲染页面
+type WorkerPool struct {
+	workers int
+}
+
+// NewWorkerPool 创建任务池，workers 不大于 0 时使用 CPU 核数
+func NewWorkerPool(workers int) *WorkerPool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &WorkerPool{workers: workers}
+}
+
+// Workers 返回并发数
+func (wp *WorkerPool) Workers() int {
+	return wp.workers
+}
+
+// Run 并发执行 n 个任务，task 接收任务序号，等待所有任务结束后返回
+// 返回的错误与任务序号一一对应；task 只应写入自己序号对应的结果，避免数据竞争
+func (wp *WorkerPool) Run(n int, task func(i int) error) []error {
+	errs := make([]error, n)
+	if n == 0 {
+		return errs
+	}
+
+	workers := wp.workers
+	if workers > n {
+		workers = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = task(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errs
+}
+
+// firstError 返回按任务顺序的第一个错误
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package mediator
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"creeper/internal/config"
 	"creeper/internal/parser"
@@ -28,6 +29,21 @@ type Message struct {
 	Data      interface{}
 	Timestamp int64
 	ID        string
+	ReplyTo   chan *Message
+}
+
+// Reply 向消息的回复通道发送响应，没有回复通道时返回 false
+func (m *Message) Reply(response *Message) bool {
+	if m.ReplyTo == nil {
+		return false
+	}
+
+	select {
+	case m.ReplyTo <- response:
+		return true
+	default:
+		return false
+	}
 }
 
 // Component 组件接口
@@ -42,6 +58,7 @@ type Mediator interface {
 	Register(component Component)
 	Unregister(componentType ComponentType)
 	Send(message *Message) error
+	SendAndReceive(message *Message, timeout time.Duration) (*Message, error)
 	Broadcast(message *Message) error
 	GetComponent(componentType ComponentType) Component
 }
@@ -97,6 +114,37 @@ func (cm *CreeperMediator) Send(message *Message) error {
 	return target.HandleMessage(message)
 }
 
+// SendAndReceive 发送消息并等待目标组件的响应
+func (cm *CreeperMediator) SendAndReceive(message *Message, timeout time.Duration) (*Message, error) {
+	message.ReplyTo = make(chan *Message, 1)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cm.Send(message)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case response := <-message.ReplyTo:
+		return response, nil
+	case err := <-errCh:
+		if err != nil {
+			return nil, err
+		}
+		// 处理完成后再检查一次回复通道
+		select {
+		case response := <-message.ReplyTo:
+			return response, nil
+		default:
+			return nil, fmt.Errorf("组件 %s 未响应消息: %s", message.To, message.Type)
+		}
+	case <-timer.C:
+		return nil, fmt.Errorf("等待 %s 响应超时: %s", message.To, message.Type)
+	}
+}
+
 // Broadcast 广播消息
 func (cm *CreeperMediator) Broadcast(message *Message) error {
 	cm.mutex.RLock()
@@ -126,13 +174,15 @@ func (cm *CreeperMediator) GetComponent(componentType ComponentType) Component {
 // ParserComponent 解析器组件
 type ParserComponentImpl struct {
 	parser   *parser.Parser
+	cache    *parser.CachingParserDecorator
 	mediator Mediator
 }
 
-// NewParserComponent 创建解析器组件
-func NewParserComponent(parser *parser.Parser) *ParserComponentImpl {
+// NewParserComponent 创建解析器组件，解析结果经缓存装饰器复用
+func NewParserComponent(p *parser.Parser) *ParserComponentImpl {
 	return &ParserComponentImpl{
-		parser: parser,
+		parser: p,
+		cache:  parser.NewCachingParserDecorator(parser.NewBaseParserDecorator(p)),
 	}
 }
 
@@ -148,22 +198,32 @@ func (pc *ParserComponentImpl) HandleMessage(message *Message) error {
 	switch message.Type {
 	case "parse_novel":
 		if path, ok := message.Data.(string); ok {
-			novel, err := pc.parser.ParseNovel(path)
+			novel, err := pc.cache.ParseNovel(path)
 			if err != nil {
 				return err
 			}
-			
-			// 通知生成器
+
 			response := &Message{
 				Type: "novel_parsed",
 				From: ParserComponent,
-				To:   GeneratorComponent,
+				To:   message.From,
 				Data: novel,
 			}
-			
+
+			// 优先回复请求方，否则通知生成器
+			if message.Reply(response) {
+				return nil
+			}
+
+			response.To = GeneratorComponent
 			return pc.mediator.Send(response)
 		}
 		
+	case "invalidate_parse_cache":
+		// 输入目录变化后丢弃旧的解析结果，下次解析从源文件重新读取
+		pc.cache.ClearCache()
+		common.GetLogger().Info("解析缓存已清空")
+		
 	case "get_supported_formats":
 		// 返回支持的格式
 		formats := []string{"md", "txt"}
@@ -259,6 +319,7 @@ func (cc *ConfigComponentImpl) HandleMessage(message *Message) error {
 		if updates, ok := message.Data.(map[string]interface{}); ok {
 			// 使用建造者模式更新配置
 			builder := config.Builder().WithDefaults()
+			previousInputDir := cc.config.InputDir
 			
 			// 应用更新
 			for key, value := range updates {
@@ -278,7 +339,11 @@ func (cc *ConfigComponentImpl) HandleMessage(message *Message) error {
 				}
 			}
 			
-			cc.config = builder.Build()
+			updated, err := builder.Build()
+			if err != nil {
+				return fmt.Errorf("更新配置失败: %w", err)
+			}
+			cc.config = updated
 			
 			// 广播配置更新消息
 			broadcast := &Message{
@@ -287,7 +352,21 @@ func (cc *ConfigComponentImpl) HandleMessage(message *Message) error {
 				Data: cc.config,
 			}
 			
-			return cc.mediator.Broadcast(broadcast)
+			if err := cc.mediator.Broadcast(broadcast); err != nil {
+				return err
+			}
+			
+			// 输入目录变化时通知解析器清空缓存，确保立即重新解析
+			if cc.config.InputDir != previousInputDir && cc.mediator.GetComponent(ParserComponent) != nil {
+				return cc.mediator.Send(&Message{
+					Type: "invalidate_parse_cache",
+					From: ConfigComponent,
+					To:   ParserComponent,
+					Data: cc.config.InputDir,
+				})
+			}
+			
+			return nil
 		}
 	}
 	
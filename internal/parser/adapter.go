@@ -2,10 +2,10 @@ package parser
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
-
-	"github.com/russross/blackfriday/v2"
+	"unicode/utf8"
 )
 
 // ContentAdapter 内容适配器接口
@@ -17,11 +17,13 @@ type ContentAdapter interface {
 }
 
 // MarkdownAdapter Markdown 内容适配器
-type MarkdownAdapter struct{}
+type MarkdownAdapter struct {
+	renderer MarkdownRenderer
+}
 
 // NewMarkdownAdapter 创建 Markdown 适配器
 func NewMarkdownAdapter() *MarkdownAdapter {
-	return &MarkdownAdapter{}
+	return &MarkdownAdapter{renderer: NewBlackfridayRenderer()}
 }
 
 func (ma *MarkdownAdapter) GetContentType() string {
@@ -57,7 +59,7 @@ func (ma *MarkdownAdapter) PreprocessContent(content string) string {
 
 func (ma *MarkdownAdapter) ConvertToHTML(content string) string {
 	preprocessed := ma.PreprocessContent(content)
-	html := string(blackfriday.Run([]byte(preprocessed)))
+	html := renderMarkdown(ma.renderer, preprocessed)
 	return ma.PostprocessContent(html)
 }
 
@@ -68,11 +70,13 @@ func (ma *MarkdownAdapter) PostprocessContent(content string) string {
 }
 
 // TxtAdapter TXT 内容适配器
-type TxtAdapter struct{}
+type TxtAdapter struct {
+	renderer MarkdownRenderer
+}
 
 // NewTxtAdapter 创建 TXT 适配器
 func NewTxtAdapter() *TxtAdapter {
-	return &TxtAdapter{}
+	return &TxtAdapter{renderer: NewBlackfridayRenderer()}
 }
 
 func (ta *TxtAdapter) GetContentType() string {
@@ -96,7 +100,10 @@ func (ta *TxtAdapter) PreprocessContent(content string) string {
 		}
 
 		// 处理特殊格式
-		if ta.isDialogue(trimmed) {
+		if trimmed == "---" || strings.HasPrefix(trimmed, ">") {
+			// 分隔线与引用块保持 Markdown 原样
+			processedLines = append(processedLines, trimmed)
+		} else if ta.isDialogue(trimmed) {
 			// 对话处理
 			processedLines = append(processedLines, "> "+trimmed)
 		} else if ta.isEmphasis(trimmed) {
@@ -114,7 +121,7 @@ func (ta *TxtAdapter) ConvertToHTML(content string) string {
 	preprocessed := ta.PreprocessContent(content)
 
 	// 将预处理后的内容转换为 Markdown，然后转换为 HTML
-	html := string(blackfriday.Run([]byte(preprocessed)))
+	html := renderMarkdown(ta.renderer, preprocessed)
 
 	return ta.PostprocessContent(html)
 }
@@ -173,9 +180,24 @@ func (caf *ContentAdapterFactory) GetAdapter(fileType string) ContentAdapter {
 		return adapter
 	}
 	// 默认返回 TXT 适配器
+	if adapter, exists := caf.adapters["txt"]; exists {
+		return adapter
+	}
 	return NewTxtAdapter()
 }
 
+// SetMarkdownRenderer 设置内置 Markdown 与 TXT 适配器使用的渲染器
+func (caf *ContentAdapterFactory) SetMarkdownRenderer(renderer MarkdownRenderer) {
+	for _, adapter := range caf.adapters {
+		switch a := adapter.(type) {
+		case *MarkdownAdapter:
+			a.renderer = renderer
+		case *TxtAdapter:
+			a.renderer = renderer
+		}
+	}
+}
+
 // GetSupportedTypes 获取支持的文件类型
 func (caf *ContentAdapterFactory) GetSupportedTypes() []string {
 	types := make([]string, 0, len(caf.adapters))
@@ -199,6 +221,11 @@ func NewChapterAdapter() *ChapterAdapter {
 	}
 }
 
+// SetMarkdownRenderer 设置章节转换使用的 Markdown 渲染器
+func (ca *ChapterAdapter) SetMarkdownRenderer(renderer MarkdownRenderer) {
+	ca.contentFactory.SetMarkdownRenderer(renderer)
+}
+
 // Subscribe 订阅解析事件
 func (ca *ChapterAdapter) Subscribe(observer ParseObserver) {
 	ca.notifier.Subscribe(observer)
@@ -212,11 +239,8 @@ func (ca *ChapterAdapter) ConvertChapter(chapter *Chapter, sourceType string) er
 		ChapterInfo: chapter,
 	})
 
-	// 获取适配器
-	adapter := ca.contentFactory.GetAdapter(sourceType)
-
 	// 转换内容
-	chapter.HTMLContent = adapter.ConvertToHTML(chapter.Content)
+	chapter.HTMLContent = ca.ConvertContentToHTML(chapter.Content, sourceType)
 	chapter.WordCount = len([]rune(chapter.Content))
 	chapter.CreatedAt = time.Now()
 
@@ -229,6 +253,88 @@ func (ca *ChapterAdapter) ConvertChapter(chapter *Chapter, sourceType string) er
 	return nil
 }
 
+// ConvertContentToHTML 按来源类型将内容转换为 HTML
+func (ca *ChapterAdapter) ConvertContentToHTML(content, sourceType string) string {
+	// 获取适配器
+	adapter := ca.contentFactory.GetAdapter(sourceType)
+
+	// TXT 内容先转换为 Markdown 结构
+	if adapter.GetContentType() == "txt" {
+		content = ca.ConvertTxtToMarkdown(content)
+	}
+
+	return adapter.ConvertToHTML(content)
+}
+
+// txtDividerRegex 匹配 "---- 第X章 ----"、"======" 等分隔线
+var txtDividerRegex = regexp.MustCompile(`^(?:[-=*~—─]{3,}.*[-=*~—─]{3,}|[-=*~—─]{3,})$`)
+
+// ConvertTxtToMarkdown 将 TXT 内容预处理为 Markdown
+// 分隔线转换为 ---，以 > 开头的行转换为引用块，空行分隔的段落合并为一个段落；
+// 没有空行的文本则每行视为一个段落
+func (ca *ChapterAdapter) ConvertTxtToMarkdown(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	// 判断文本是否使用空行分隔段落
+	blankSeparated := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			blankSeparated = true
+			break
+		}
+	}
+
+	var blocks []string
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, joinParagraphLines(paragraph))
+			paragraph = nil
+		}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+		case txtDividerRegex.MatchString(trimmed):
+			flush()
+			blocks = append(blocks, "---")
+		case strings.HasPrefix(trimmed, ">") || strings.HasPrefix(trimmed, "＞"):
+			flush()
+			quote := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(trimmed, ">"), "＞"))
+			blocks = append(blocks, "> "+quote)
+		default:
+			paragraph = append(paragraph, trimmed)
+			if !blankSeparated {
+				flush()
+			}
+		}
+	}
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// joinParagraphLines 合并段落中的多行，中文直接相连，西文单词之间补空格
+func joinParagraphLines(lines []string) string {
+	var builder strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			prev := []rune(lines[i-1])
+			next := []rune(line)
+			if prev[len(prev)-1] < utf8.RuneSelf && next[0] < utf8.RuneSelf {
+				builder.WriteString(" ")
+			}
+		}
+		builder.WriteString(line)
+	}
+	return builder.String()
+}
+
 // ConvertNovel 转换整部小说
 func (ca *ChapterAdapter) ConvertNovel(novel *Novel, sourceType string) error {
 	totalChapters := len(novel.Chapters)
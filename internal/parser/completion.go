@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// 完结日期估算的置信度
+const (
+	CompletionConfidenceLow    = "low"
+	CompletionConfidenceMedium = "medium"
+	CompletionConfidenceHigh   = "high"
+)
+
+// completionSampleSize 估算更新频率时参考的最近章节数
+const completionSampleSize = 5
+
+// EstimateCompletion 根据最近章节的更新间隔估算连载小说的完结日期
+// 需要在元数据中设置 expected_chapters，且至少有两个不同的章节时间；无法估算时返回 nil
+func EstimateCompletion(novel *Novel) (*time.Time, string) {
+	remaining := novel.ExpectedChapters - len(novel.Chapters)
+	if novel.ExpectedChapters <= 0 || remaining <= 0 {
+		return nil, ""
+	}
+
+	times := make([]time.Time, 0, len(novel.Chapters))
+	for _, chapter := range novel.Chapters {
+		if !chapter.CreatedAt.IsZero() {
+			times = append(times, chapter.CreatedAt)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	if len(times) > completionSampleSize {
+		times = times[len(times)-completionSampleSize:]
+	}
+	if len(times) < 2 {
+		return nil, ""
+	}
+
+	gaps := make([]float64, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		gaps = append(gaps, times[i].Sub(times[i-1]).Seconds())
+	}
+
+	mean := 0.0
+	for _, gap := range gaps {
+		mean += gap
+	}
+	mean /= float64(len(gaps))
+	if mean <= 0 {
+		// 所有章节时间相同（如单文件小说），无法推算更新频率
+		return nil, ""
+	}
+
+	estimated := times[len(times)-1].Add(time.Duration(mean * float64(remaining) * float64(time.Second)))
+	return &estimated, completionConfidence(gaps, mean, remaining)
+}
+
+// completionConfidence 根据样本数、更新间隔的离散程度与剩余章节数评估置信度
+func completionConfidence(gaps []float64, mean float64, remaining int) string {
+	variance := 0.0
+	for _, gap := range gaps {
+		variance += (gap - mean) * (gap - mean)
+	}
+	variance /= float64(len(gaps))
+	cv := math.Sqrt(variance) / mean
+
+	switch {
+	case len(gaps) >= completionSampleSize-1 && cv < 0.5 && remaining <= 100*len(gaps):
+		return CompletionConfidenceHigh
+	case len(gaps) >= 2 && cv < 1:
+		return CompletionConfidenceMedium
+	default:
+		return CompletionConfidenceLow
+	}
+}
@@ -0,0 +1,29 @@
+package parser
+
+import "creeper/internal/config"
+
+// OptionsFromConfig 将 parsing 配置转换为解析选项
+func OptionsFromConfig(cfg *config.Config) Options {
+	return Options{
+		NormalizeQuotes:   cfg.Parsing.NormalizeQuotes,
+		QuoteStyle:        cfg.Parsing.QuoteStyle,
+		ComputeDifficulty: cfg.Parsing.ComputeDifficulty,
+		ChapterRegex:      cfg.Parsing.ChapterRegex,
+		VolumeRegex:       cfg.Parsing.VolumeRegex,
+		MinChapterWords:   cfg.Parsing.MinChapterWords,
+		FilenameSeparator: cfg.Parsing.FilenameSeparator,
+	}
+}
+
+// NewFromConfig 按 markdown 与 parsing 配置创建解析器
+// markdown.renderer 无效时使用 blackfriday，并返回错误供调用方提示，解析器仍可使用
+func NewFromConfig(cfg *config.Config) (*Parser, error) {
+	renderer, err := NewMarkdownRenderer(cfg.Markdown.Renderer)
+	if err != nil {
+		renderer = NewBlackfridayRenderer()
+	}
+
+	p := New(WithMarkdownRenderer(renderer))
+	p.SetOptions(OptionsFromConfig(cfg))
+	return p, err
+}
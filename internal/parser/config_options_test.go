@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"testing"
+
+	"creeper/internal/config"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Parsing.NormalizeQuotes = true
+	cfg.Parsing.QuoteStyle = QuoteStyleUnicode
+	cfg.Parsing.MinChapterWords = 100
+	cfg.Parsing.FilenameSeparator = "_"
+
+	p, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	if got, want := p.Options(), OptionsFromConfig(cfg); got != want {
+		t.Errorf("Options() = %+v, want %+v", got, want)
+	}
+	if got := p.Options(); !got.NormalizeQuotes || got.MinChapterWords != 100 || got.FilenameSeparator != "_" {
+		t.Errorf("Options() = %+v, 没有使用 parsing 配置", got)
+	}
+}
+
+func TestNewFromConfigUnknownRenderer(t *testing.T) {
+	cfg := config.Default()
+	cfg.Markdown.Renderer = "unknown"
+
+	p, err := NewFromConfig(cfg)
+	if err == nil {
+		t.Error("NewFromConfig() error = nil, want 未知渲染器错误")
+	}
+	if p == nil {
+		t.Fatal("NewFromConfig() 返回 nil 解析器，want 回退到 blackfriday")
+	}
+	if _, ok := p.markdown.(*BlackfridayRenderer); !ok {
+		t.Errorf("markdown = %T, want *BlackfridayRenderer", p.markdown)
+	}
+}
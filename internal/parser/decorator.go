@@ -152,30 +152,81 @@ func (cpd *CachingParserDecorator) getFileInfo(path string) (FileInfo, error) {
 func (cpd *CachingParserDecorator) cloneNovel(original *Novel) *Novel {
 	clone := &Novel{
 		Title:       original.Title,
+		Subtitle:    original.Subtitle,
 		Author:      original.Author,
 		Description: original.Description,
 		Cover:       original.Cover,
+		Category:    original.Category,
+		SubCategory: original.SubCategory,
+		Tags:        append([]string(nil), original.Tags...),
+		Genre:       append([]string(nil), original.Genre...),
+		Difficulty:  original.Difficulty,
+		Status:      original.Status,
+		Language:    original.Language,
 		CreatedAt:   original.CreatedAt,
 		UpdatedAt:   original.UpdatedAt,
 		Path:        original.Path,
 		Chapters:    make([]*Chapter, len(original.Chapters)),
+
+		ExpectedChapters:        original.ExpectedChapters,
+		EstimatedCompletionDate: original.EstimatedCompletionDate,
+		CompletionConfidence:    original.CompletionConfidence,
+	}
+
+	if stats, ok := original.ParseStats.(*TxtParseStats); ok {
+		statsCopy := *stats
+		clone.ParseStats = &statsCopy
 	}
 
+	// 原章节到克隆章节的映射，用于让卷中的章节指向克隆后的同一对象
+	cloned := make(map[*Chapter]*Chapter, len(original.Chapters))
 	for i, chapter := range original.Chapters {
-		clone.Chapters[i] = &Chapter{
-			ID:          chapter.ID,
-			Title:       chapter.Title,
-			Content:     chapter.Content,
-			HTMLContent: chapter.HTMLContent,
-			WordCount:   chapter.WordCount,
-			CreatedAt:   chapter.CreatedAt,
-			Path:        chapter.Path,
+		clone.Chapters[i] = cloneChapter(chapter)
+		cloned[chapter] = clone.Chapters[i]
+	}
+
+	if original.Volumes != nil {
+		clone.Volumes = make([]*Volume, len(original.Volumes))
+		for i, volume := range original.Volumes {
+			volumeCopy := &Volume{
+				ID:       volume.ID,
+				Title:    volume.Title,
+				Chapters: make([]*Chapter, len(volume.Chapters)),
+			}
+			for j, chapter := range volume.Chapters {
+				if c, ok := cloned[chapter]; ok {
+					volumeCopy.Chapters[j] = c
+				} else {
+					// 不在 Chapters 中的章节单独复制，避免与原对象共享
+					volumeCopy.Chapters[j] = cloneChapter(chapter)
+				}
+			}
+			clone.Volumes[i] = volumeCopy
 		}
 	}
 
 	return clone
 }
 
+// cloneChapter 深拷贝章节
+func cloneChapter(chapter *Chapter) *Chapter {
+	return &Chapter{
+		ID:          chapter.ID,
+		Title:       chapter.Title,
+		Content:     chapter.Content,
+		HTMLContent: chapter.HTMLContent,
+		AuthorNote:  chapter.AuthorNote,
+		WordCount:   chapter.WordCount,
+		CreatedAt:   chapter.CreatedAt,
+		Path:        chapter.Path,
+
+		IllustrationMarkers: append([]IllustrationMarker(nil), chapter.IllustrationMarkers...),
+		Parts:               append([]string(nil), chapter.Parts...),
+
+		heading: chapter.heading,
+	}
+}
+
 // ClearCache 清空缓存
 func (cpd *CachingParserDecorator) ClearCache() {
 	cpd.cache = make(map[string]*CachedNovel)
@@ -0,0 +1,65 @@
+package parser
+
+import "testing"
+
+func TestCloneNovelDeepCopiesVolumesAndParts(t *testing.T) {
+	first := &Chapter{ID: 1, Title: "第一章", Parts: []string{"<p>上</p>", "<p>下</p>"}}
+	second := &Chapter{ID: 2, Title: "第二章"}
+	original := &Novel{
+		Title:    "测试小说",
+		Chapters: []*Chapter{first, second},
+		Volumes: []*Volume{
+			{ID: 1, Title: "第一卷", Chapters: []*Chapter{first}},
+			{ID: 2, Title: "第二卷", Chapters: []*Chapter{second}},
+		},
+	}
+
+	clone := (&CachingParserDecorator{}).cloneNovel(original)
+
+	if len(clone.Volumes) != len(original.Volumes) {
+		t.Fatalf("len(Volumes) = %d, want %d", len(clone.Volumes), len(original.Volumes))
+	}
+	for i, volume := range clone.Volumes {
+		if volume == original.Volumes[i] {
+			t.Errorf("Volumes[%d] 与原对象共享", i)
+		}
+		if volume.ID != original.Volumes[i].ID || volume.Title != original.Volumes[i].Title {
+			t.Errorf("Volumes[%d] = %+v, want %+v", i, volume, original.Volumes[i])
+		}
+	}
+
+	// 卷中的章节应指向克隆后的章节，而不是原章节
+	if clone.Volumes[0].Chapters[0] != clone.Chapters[0] {
+		t.Error("Volumes[0].Chapters[0] 未指向克隆后的 Chapters[0]")
+	}
+	if clone.Volumes[1].Chapters[0] != clone.Chapters[1] {
+		t.Error("Volumes[1].Chapters[0] 未指向克隆后的 Chapters[1]")
+	}
+
+	clone.Chapters[0].Title = "修改后的标题"
+	clone.Chapters[0].Parts[0] = "<p>修改</p>"
+	clone.Volumes[0].Title = "修改后的卷名"
+
+	if first.Title != "第一章" {
+		t.Errorf("原章节标题被修改为 %q", first.Title)
+	}
+	if first.Parts[0] != "<p>上</p>" {
+		t.Errorf("原章节 Parts 被修改为 %q", first.Parts)
+	}
+	if original.Volumes[0].Title != "第一卷" {
+		t.Errorf("原卷名被修改为 %q", original.Volumes[0].Title)
+	}
+	if len(clone.Chapters[0].Parts) != 2 {
+		t.Errorf("len(Parts) = %d, want 2", len(clone.Chapters[0].Parts))
+	}
+}
+
+func TestCloneNovelWithoutVolumes(t *testing.T) {
+	original := &Novel{Chapters: []*Chapter{{ID: 1}}}
+
+	clone := (&CachingParserDecorator{}).cloneNovel(original)
+
+	if clone.Volumes != nil {
+		t.Errorf("Volumes = %v, want nil", clone.Volumes)
+	}
+}
@@ -0,0 +1,118 @@
+package parser
+
+import "unicode"
+
+// 难度等级
+const (
+	DifficultyBeginner     = "beginner"
+	DifficultyIntermediate = "intermediate"
+	DifficultyAdvanced     = "advanced"
+)
+
+// commonHanChars 现代汉语常用字（按字频排序的前五百字左右）
+const commonHanChars = "的一是不了人我在有他这中大来上个国到说们为子和你地出道也时年得就那要下以生会自着去之过家学对可她里后小么心多天而能好都然没日于起还发成事只作当想看文无开手十用主行方又如前所本见经头面公同三已老从动两长知民样现分将外但身些与高意进把法此实回二理美点月明其种声全工己话儿者向情部正名定女问力机给等几很业最间新什打便位因重被走电四第门相次东政海口使教西再平真听世气信北少关并内加化由却代军产入先山五太水万市眼体别处总才场师书比住员九笑性通目华报立马命张活难神数件安表原车白应路期叫死常提感金何更反合放做系计或司利受光王果亲界及今京务制解各任至清物台象记边共风战干接它许八特觉望直服毛林题建南度统色字请交爱让认算论百吃义科怎元社术结六功指思非流每青管夫连远资队跟带花快条院变联言权往展该领传近留红治决周保达办运武半候七必城父强步完革深区即求品士转量空甚众技轻程告江语英基派满式李息写呢识极令黄德收脸钱党倒未持取设始版双历越史商千片容研像找友孩站广改议形委早房音火际则首单据导影失拿网香似斯专石若兵弟谁校志飞观争究包组造落视济喜离虽坏兴切官帮晚复养蓝谈亚断龙举顾"
+
+// difficultySampleSize 难度分析最多采样的汉字数，避免超长小说耗时过多
+const difficultySampleSize = 200000
+
+var commonHanSet = func() map[rune]bool {
+	set := make(map[rune]bool)
+	for _, r := range commonHanChars {
+		set[r] = true
+	}
+	return set
+}()
+
+// DifficultyStats 难度分析的中间结果
+type DifficultyStats struct {
+	HanCount       int     // 采样的汉字数
+	CommonCoverage float64 // 常用字覆盖率
+	BigramRatio    float64 // 每千字窗口中不同二元组的平均比例，样本不足时为 0
+}
+
+// bigramWindow 统计二元组分散度的窗口大小
+const bigramWindow = 1000
+
+// minBigramWindows 二元组分散度参与计算所需的最少窗口数
+const minBigramWindows = 5
+
+// AnalyzeDifficulty 统计小说正文的字频分布
+func AnalyzeDifficulty(novel *Novel) DifficultyStats {
+	var stats DifficultyStats
+	common := 0
+	windows := 0
+	distinct := 0
+	windowSize := 0
+	window := make(map[[2]rune]struct{})
+
+	for _, chapter := range novel.Chapters {
+		var prev rune
+		for _, r := range chapter.Content {
+			if stats.HanCount >= difficultySampleSize {
+				break
+			}
+			if !unicode.Is(unicode.Han, r) {
+				prev = 0
+				continue
+			}
+
+			stats.HanCount++
+			if commonHanSet[r] {
+				common++
+			}
+
+			if prev != 0 {
+				// 按固定窗口统计，消除文本长度对不同二元组比例的影响
+				window[[2]rune{prev, r}] = struct{}{}
+				windowSize++
+				if windowSize == bigramWindow {
+					distinct += len(window)
+					windows++
+					window = make(map[[2]rune]struct{})
+					windowSize = 0
+				}
+			}
+			prev = r
+		}
+	}
+
+	if stats.HanCount == 0 {
+		return stats
+	}
+
+	stats.CommonCoverage = float64(common) / float64(stats.HanCount)
+	if windows >= minBigramWindows {
+		stats.BigramRatio = float64(distinct) / float64(windows*bigramWindow)
+	}
+	return stats
+}
+
+// 难度分数阈值
+const (
+	difficultyBeginnerMax     = 0.27
+	difficultyIntermediateMax = 0.35
+)
+
+// ComputeDifficulty 根据字频分布估算小说的阅读难度
+// 常用字覆盖率越低、二元组越分散，文本越难读
+func ComputeDifficulty(novel *Novel) string {
+	stats := AnalyzeDifficulty(novel)
+	if stats.HanCount == 0 {
+		return ""
+	}
+
+	score := 1 - stats.CommonCoverage
+	if stats.BigramRatio > 0 {
+		// 词汇丰富程度作为补充，现代白话文的比例通常在 0.6 左右
+		score += (stats.BigramRatio - 0.6) * 0.1
+	}
+
+	switch {
+	case score < difficultyBeginnerMax:
+		return DifficultyBeginner
+	case score < difficultyIntermediateMax:
+		return DifficultyIntermediate
+	default:
+		return DifficultyAdvanced
+	}
+}
@@ -0,0 +1,257 @@
+package parser
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	docx "github.com/fumiama/go-docx"
+)
+
+// docxHeadingStyles 视为一级标题的段落样式 ID（中文版 Word 的“标题 1”样式 ID 为 "1"）
+var docxHeadingStyles = map[string]bool{
+	"heading1":  true,
+	"heading 1": true,
+	"1":         true,
+}
+
+// DocxFileStrategy DOCX 文件解析策略
+// 大纲级别为 0 或使用“标题 1”样式的段落作为章节标题，其余段落作为章节正文
+type DocxFileStrategy struct {
+	parser         *Parser
+	chapterAdapter *ChapterAdapter
+}
+
+// NewDocxFileStrategy 创建 DOCX 文件策略
+func NewDocxFileStrategy(parser *Parser) *DocxFileStrategy {
+	strategy := &DocxFileStrategy{
+		parser:         parser,
+		chapterAdapter: NewChapterAdapter(),
+	}
+	strategy.chapterAdapter.SetMarkdownRenderer(parser.MarkdownRenderer())
+	return strategy
+}
+
+func (s *DocxFileStrategy) CanHandle(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir() && strings.HasSuffix(strings.ToLower(path), ".docx")
+}
+
+func (s *DocxFileStrategy) GetName() string {
+	return "DocxFile"
+}
+
+func (s *DocxFileStrategy) Parse(novel *Novel, path string) error {
+	// 使用文件名作为默认标题，“作者 - 书名.docx”形式的文件名同时提供作者
+	novel.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	NewFilenameMetadataStrategy(s.parser.Options().FilenameSeparator).Apply(novel, path)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("读取文件失败: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("读取文件失败: %v", err)
+	}
+
+	doc, err := docx.Parse(file, info.Size())
+	if err != nil {
+		return fmt.Errorf("解析 DOCX 文件 %s 失败: %v", path, err)
+	}
+
+	// go-docx 不保留 <w:outlineLvl>，单独扫描 document.xml 记录大纲级别为 0 的段落
+	outlined, err := docxOutlineParagraphs(file, info.Size())
+	if err != nil {
+		return fmt.Errorf("读取 DOCX 文件 %s 的大纲级别失败: %v", path, err)
+	}
+
+	var (
+		chapter   *Chapter
+		preface   []string
+		paragraph []string
+		index     int
+	)
+
+	saveChapter := func() {
+		if chapter == nil {
+			return
+		}
+		chapter.Content, chapter.AuthorNote = ExtractAuthorNote(strings.Join(paragraph, "\n\n"))
+		novel.Chapters = append(novel.Chapters, chapter)
+		paragraph = nil
+	}
+
+	for _, item := range doc.Document.Body.Items {
+		p, ok := item.(*docx.Paragraph)
+		if !ok {
+			continue
+		}
+		heading := outlined[index] || docxIsHeading(p)
+		index++
+
+		text := strings.TrimSpace(docxParagraphText(p))
+		if text == "" {
+			continue
+		}
+
+		if docxStyle(p) == "title" {
+			novel.Title = text
+			continue
+		}
+
+		if heading {
+			saveChapter()
+			chapter = &Chapter{
+				ID:    len(novel.Chapters) + 1,
+				Title: text,
+				Path:  fmt.Sprintf("chapter-%d", len(novel.Chapters)+1),
+			}
+			continue
+		}
+
+		if chapter == nil {
+			preface = append(preface, text)
+			continue
+		}
+		paragraph = append(paragraph, text)
+	}
+	saveChapter()
+
+	// 没有标题段落时整篇文档作为一章
+	if len(novel.Chapters) == 0 && len(preface) > 0 {
+		novel.Chapters = append(novel.Chapters, &Chapter{
+			ID:      1,
+			Title:   novel.Title,
+			Content: strings.Join(preface, "\n\n"),
+			Path:    "chapter-1",
+		})
+	} else if len(preface) > 0 && novel.Description == "" {
+		// 第一个标题之前的段落作为简介
+		novel.Description = strings.Join(preface, "\n")
+	}
+
+	if len(novel.Chapters) == 0 {
+		return fmt.Errorf("DOCX 文件 %s 中没有正文", path)
+	}
+
+	if err := s.chapterAdapter.ConvertNovel(novel, "txt"); err != nil {
+		return fmt.Errorf("内容转换失败: %v", err)
+	}
+
+	return nil
+}
+
+// docxStyle 段落样式 ID，统一为小写
+func docxStyle(p *docx.Paragraph) string {
+	if p.Properties == nil || p.Properties.Style == nil {
+		return ""
+	}
+	return strings.ToLower(p.Properties.Style.Val)
+}
+
+// docxIsHeading 判断段落是否使用一级标题样式
+func docxIsHeading(p *docx.Paragraph) bool {
+	return docxHeadingStyles[docxStyle(p)]
+}
+
+// docxParagraphText 段落的纯文本，忽略图片等非文本内容
+func docxParagraphText(p *docx.Paragraph) string {
+	var sb strings.Builder
+	writeRun := func(run *docx.Run) {
+		for _, child := range run.Children {
+			switch c := child.(type) {
+			case *docx.Text:
+				sb.WriteString(c.Text)
+			case *docx.Tab:
+				sb.WriteByte('\t')
+			case *docx.BarterRabbet:
+				sb.WriteByte('\n')
+			}
+		}
+	}
+
+	for _, child := range p.Children {
+		switch c := child.(type) {
+		case *docx.Run:
+			writeRun(c)
+		case *docx.Hyperlink:
+			writeRun(&c.Run)
+		}
+	}
+	return sb.String()
+}
+
+// docxOutlineParagraphs 返回 <w:body> 下直接包含 <w:outlineLvl w:val="0"/> 的段落序号
+// 序号只计算正文中的顶层段落，与 go-docx 解析出的段落顺序一致
+func docxOutlineParagraphs(reader io.ReaderAt, size int64) (map[int]bool, error) {
+	zipReader, err := zip.NewReader(reader, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var document *zip.File
+	for _, f := range zipReader.File {
+		if f.Name == "word/document.xml" {
+			document = f
+			break
+		}
+	}
+	if document == nil {
+		return nil, fmt.Errorf("缺少 word/document.xml")
+	}
+
+	rc, err := document.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	outlined := make(map[int]bool)
+	decoder := xml.NewDecoder(rc)
+	var stack []string
+	index := -1
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			// 顶层段落：body > p
+			if t.Name.Local == "p" && len(stack) > 0 && stack[len(stack)-1] == "body" {
+				index++
+			}
+			// body > p > pPr > outlineLvl
+			if t.Name.Local == "outlineLvl" && len(stack) >= 3 &&
+				stack[len(stack)-1] == "pPr" && stack[len(stack)-2] == "p" && stack[len(stack)-3] == "body" {
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "val" && attr.Value == "0" {
+						outlined[index] = true
+					}
+				}
+			}
+			stack = append(stack, t.Name.Local)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	return outlined, nil
+}
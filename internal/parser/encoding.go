@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// 识别出的文本编码名称，记录在解析统计中
+const (
+	EncodingUTF8    = "UTF-8"
+	EncodingUTF8BOM = "UTF-8 (BOM)"
+	EncodingUTF16LE = "UTF-16LE"
+	EncodingUTF16BE = "UTF-16BE"
+	EncodingGBK     = "GBK"
+	EncodingBig5    = "BIG5"
+)
+
+// legacyEncodings 非 UTF 文件依次尝试的编码，GBK 兼容 GB2312
+var legacyEncodings = []struct {
+	name     string
+	encoding encoding.Encoding
+}{
+	{EncodingGBK, simplifiedchinese.GBK},
+	{EncodingBig5, traditionalchinese.Big5},
+}
+
+// detectAndDecodeEncoding 识别 TXT 内容的编码并转为 UTF-8
+// 依次尝试 UTF-8（开销最小）、带 BOM 的 UTF-16，再尝试 GBK/GB2312 与 BIG5
+func detectAndDecodeEncoding(data []byte) (string, error) {
+	text, _, err := decodeText(data)
+	return text, err
+}
+
+// decodeText 将内容转为 UTF-8，同时返回识别出的编码
+// GBK 与 BIG5 都能完整解码时，选择常用汉字更多的一种
+func decodeText(data []byte) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:]), EncodingUTF8BOM, nil
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		text, err := unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder().Bytes(data)
+		return string(text), EncodingUTF16LE, err
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		text, err := unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder().Bytes(data)
+		return string(text), EncodingUTF16BE, err
+	case utf8.Valid(data):
+		return string(data), EncodingUTF8, nil
+	}
+
+	bestText, bestName, bestScore := "", "", -1
+	for _, candidate := range legacyEncodings {
+		decoded, err := candidate.encoding.NewDecoder().Bytes(data)
+		if err != nil {
+			continue
+		}
+		// 无法映射的字节会被解码为替换字符，说明不是该编码
+		text := string(decoded)
+		if strings.ContainsRune(text, utf8.RuneError) {
+			continue
+		}
+
+		if score := commonHanScore(text); score > bestScore {
+			bestText, bestName, bestScore = text, candidate.name, score
+		}
+	}
+
+	if bestName == "" {
+		return "", "", fmt.Errorf("无法识别文件编码（支持 UTF-8、UTF-16、GBK/GB2312、BIG5）")
+	}
+	return bestText, bestName, nil
+}
+
+// commonHanScore 统计文本中常用汉字的数量，BIG5 文本按 GBK 解码（或反之）得到的多是生僻字
+func commonHanScore(text string) int {
+	score := 0
+	for _, r := range text {
+		if commonHanSet[r] {
+			score++
+		}
+	}
+	return score
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// DefaultFilenameSeparator 文件名中作者与书名之间的默认分隔符
+const DefaultFilenameSeparator = " - "
+
+// FilenameMetadataStrategy 从“作者 - 书名.txt”形式的文件名中提取作者与书名
+// 作为 TXT 解析的预处理步骤，文件内的元数据仍会覆盖这里的结果
+type FilenameMetadataStrategy struct {
+	separator string
+}
+
+// NewFilenameMetadataStrategy 创建文件名元数据策略，separator 为空时使用 DefaultFilenameSeparator
+func NewFilenameMetadataStrategy(separator string) *FilenameMetadataStrategy {
+	if separator == "" {
+		separator = DefaultFilenameSeparator
+	}
+	return &FilenameMetadataStrategy{separator: separator}
+}
+
+// Apply 文件名符合“作者<分隔符>书名.<扩展名>”时填充 novel.Author 与 novel.Title，返回是否匹配
+func (s *FilenameMetadataStrategy) Apply(novel *Novel, path string) bool {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+
+	author, title, found := strings.Cut(name, s.separator)
+	author, title = strings.TrimSpace(author), strings.TrimSpace(title)
+	if !found || author == "" || title == "" {
+		return false
+	}
+
+	novel.Author = author
+	novel.Title = title
+	return true
+}
@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FrontmatterFormat 文件开头元数据块的格式，由起始分隔符决定
+type FrontmatterFormat int
+
+const (
+	// FrontmatterNone 没有元数据块
+	FrontmatterNone FrontmatterFormat = iota
+	// FrontmatterYAML 以 --- 分隔的 YAML 元数据
+	FrontmatterYAML
+	// FrontmatterTOML 以 +++ 分隔的 TOML 元数据
+	FrontmatterTOML
+)
+
+// String 格式名称
+func (f FrontmatterFormat) String() string {
+	switch f {
+	case FrontmatterYAML:
+		return "yaml"
+	case FrontmatterTOML:
+		return "toml"
+	default:
+		return "none"
+	}
+}
+
+// delimiter 元数据块的分隔符
+func (f FrontmatterFormat) delimiter() string {
+	switch f {
+	case FrontmatterYAML:
+		return "---"
+	case FrontmatterTOML:
+		return "+++"
+	default:
+		return ""
+	}
+}
+
+// DetectFrontmatterFormat 根据起始行判断元数据块格式
+func DetectFrontmatterFormat(line string) FrontmatterFormat {
+	switch strings.TrimSpace(line) {
+	case "---":
+		return FrontmatterYAML
+	case "+++":
+		return FrontmatterTOML
+	default:
+		return FrontmatterNone
+	}
+}
+
+// splitFrontmatter 从第一行开始拆分元数据块，返回格式、元数据行、正文行以及元数据块是否闭合
+// 未闭合时其余所有行都作为元数据行返回，没有元数据块时正文为全部行
+func splitFrontmatter(lines []string) (FrontmatterFormat, []string, []string, bool) {
+	if len(lines) == 0 {
+		return FrontmatterNone, nil, lines, false
+	}
+
+	format := DetectFrontmatterFormat(lines[0])
+	if format == FrontmatterNone {
+		return FrontmatterNone, nil, lines, false
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == format.delimiter() {
+			return format, lines[1:i], lines[i+1:], true
+		}
+	}
+	return format, lines[1:], nil, false
+}
+
+// parseFrontmatter 解析元数据块并写入小说
+// YAML 无法解析时（如值中含有未加引号的冒号）退回逐行的“键: 值”解析
+func (p *Parser) parseFrontmatter(novel *Novel, format FrontmatterFormat, lines []string) error {
+	text := strings.Join(lines, "\n")
+	values := make(map[string]interface{})
+
+	switch format {
+	case FrontmatterYAML:
+		if err := yaml.Unmarshal([]byte(text), &values); err != nil {
+			for _, line := range lines {
+				p.parseMetaLine(novel, line)
+			}
+			return nil
+		}
+	case FrontmatterTOML:
+		if _, err := toml.Decode(text, &values); err != nil {
+			return fmt.Errorf("解析 TOML 元数据失败: %v", err)
+		}
+	default:
+		return nil
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		p.setMeta(novel, key, metaValueString(values[key]))
+	}
+	return nil
+}
+
+// metaValueString 将元数据值转为字符串，列表以逗号连接，交给 parseListValue 拆分
+func metaValueString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// normalizeStatus 统一连载状态：ongoing（连载中）或 completed（已完结），无法识别时保留原值
+func normalizeStatus(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "ongoing", "serializing", "连载", "连载中":
+		return NovelStatusOngoing
+	case "completed", "complete", "finished", "完结", "已完结", "完本":
+		return NovelStatusCompleted
+	default:
+		return strings.TrimSpace(value)
+	}
+}
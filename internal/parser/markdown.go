@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+
+	"github.com/russross/blackfriday/v2"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// Markdown 渲染器名称，对应配置中的 markdown.renderer
+const (
+	MarkdownRendererBlackfriday = "blackfriday"
+	MarkdownRendererGoldmark    = "goldmark"
+)
+
+// MarkdownRenderer 将 Markdown 渲染为 HTML
+type MarkdownRenderer interface {
+	Render(src []byte) ([]byte, error)
+}
+
+// BlackfridayRenderer 基于 blackfriday v2 的渲染器（默认）
+type BlackfridayRenderer struct{}
+
+// NewBlackfridayRenderer 创建 blackfriday 渲染器
+func NewBlackfridayRenderer() *BlackfridayRenderer {
+	return &BlackfridayRenderer{}
+}
+
+// Render 渲染 Markdown
+func (r *BlackfridayRenderer) Render(src []byte) ([]byte, error) {
+	return blackfriday.Run(src), nil
+}
+
+// GoldmarkRenderer 基于 goldmark 的 CommonMark 渲染器，启用表格、脚注与排版扩展
+type GoldmarkRenderer struct {
+	md goldmark.Markdown
+}
+
+// NewGoldmarkRenderer 创建 goldmark 渲染器
+func NewGoldmarkRenderer() *GoldmarkRenderer {
+	return &GoldmarkRenderer{
+		md: goldmark.New(
+			goldmark.WithExtensions(
+				extension.Table,
+				extension.Footnote,
+				extension.Typographer,
+			),
+			// 与 blackfriday 一致，保留正文中的原始 HTML（如插图占位）
+			goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+		),
+	}
+}
+
+// Render 渲染 Markdown
+func (r *GoldmarkRenderer) Render(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := r.md.Convert(src, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// NewMarkdownRenderer 按名称创建渲染器，名称为空时使用 blackfriday
+func NewMarkdownRenderer(name string) (MarkdownRenderer, error) {
+	switch name {
+	case "", MarkdownRendererBlackfriday:
+		return NewBlackfridayRenderer(), nil
+	case MarkdownRendererGoldmark:
+		return NewGoldmarkRenderer(), nil
+	default:
+		return nil, fmt.Errorf("未知的 Markdown 渲染器: %s（可选 blackfriday、goldmark）", name)
+	}
+}
+
+// renderMarkdown 使用渲染器将 Markdown 转为 HTML 字符串，渲染失败时按纯文本输出
+func renderMarkdown(renderer MarkdownRenderer, content string) string {
+	if renderer == nil {
+		renderer = NewBlackfridayRenderer()
+	}
+	out, err := renderer.Render([]byte(content))
+	if err != nil {
+		return "<p>" + html.EscapeString(content) + "</p>\n"
+	}
+	return string(out)
+}
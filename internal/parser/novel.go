@@ -11,21 +11,48 @@ import (
 	"strings"
 	"time"
 
-	"github.com/russross/blackfriday/v2"
 )
 
 // Novel 小说结构
 type Novel struct {
 	Title       string     `json:"title"`
+	Subtitle    string     `json:"subtitle"`
 	Author      string     `json:"author"`
 	Description string     `json:"description"`
 	Cover       string     `json:"cover"`
 	Category    string     `json:"category"`
+	SubCategory string     `json:"sub_category,omitempty"`
 	Tags        []string   `json:"tags"`
+	Genre       []string   `json:"genre"`
+	Difficulty  string     `json:"difficulty,omitempty"`
+	// Status 连载状态，见 NovelStatusOngoing 与 NovelStatusCompleted
+	Status   string `json:"status,omitempty"`
+	Language string `json:"language,omitempty"`
+	// 连载小说的预计总章节数与据此估算的完结日期
+	ExpectedChapters        int        `json:"expected_chapters,omitempty"`
+	EstimatedCompletionDate *time.Time `json:"estimated_completion_date,omitempty"`
+	CompletionConfidence    string     `json:"completion_confidence,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	Chapters    []*Chapter `json:"chapters"`
+	// Volumes 分卷信息，卷中的章节与 Chapters 共用同一对象；不分卷的小说为空
+	Volumes []*Volume `json:"volumes,omitempty"`
 	Path        string     `json:"path"`
+	// ParseStats 解析统计，TXT 单文件为 *TxtParseStats，其他格式为 nil
+	ParseStats interface{} `json:"parse_stats,omitempty"`
+}
+
+// 小说连载状态
+const (
+	NovelStatusOngoing   = "ongoing"
+	NovelStatusCompleted = "completed"
+)
+
+// Volume 卷结构
+type Volume struct {
+	ID       int        `json:"id"`
+	Title    string     `json:"title"`
+	Chapters []*Chapter `json:"chapters"`
 }
 
 // Chapter 章节结构
@@ -34,9 +61,40 @@ type Chapter struct {
 	Title       string    `json:"title"`
 	Content     string    `json:"content"`
 	HTMLContent string    `json:"html_content"`
+	AuthorNote  string    `json:"author_note,omitempty"`
 	WordCount   int       `json:"word_count"`
 	CreatedAt   time.Time `json:"created_at"`
 	Path        string    `json:"path"`
+	// 章节中的插图标记
+	IllustrationMarkers []IllustrationMarker `json:"illustration_markers,omitempty"`
+	// Parts 超长章节按段落拆分后每页的 HTML，由生成器根据 build.max_chapter_words_per_page 填充
+	Parts []string `json:"parts,omitempty"`
+
+	// heading TXT 中识别为标题的原始行，章节被并入上一章时还原为正文
+	heading string
+}
+
+// IllustrationMarker 插图标记
+type IllustrationMarker struct {
+	Position int    `json:"position"` // 标记在章节内容中的字符偏移
+	Label    string `json:"label"`    // 标记中的说明文字，[图] 为空
+}
+
+// Options 解析选项
+type Options struct {
+	// NormalizeQuotes 统一 TXT 内容中的引号
+	NormalizeQuotes bool
+	// QuoteStyle 引号统一风格，见 QuoteStyleASCII 与 QuoteStyleUnicode
+	QuoteStyle string
+	// ComputeDifficulty 解析后根据字频估算阅读难度，较耗 CPU
+	ComputeDifficulty bool
+	// ChapterRegex、VolumeRegex 非空时替换 TXT 内置的章节、卷标题规则
+	ChapterRegex string
+	VolumeRegex  string
+	// MinChapterWords 字数少于该值的 TXT 章节并入上一章，为 0 时不合并
+	MinChapterWords int
+	// FilenameSeparator TXT 文件名中作者与书名的分隔符，为空时使用 DefaultFilenameSeparator
+	FilenameSeparator string
 }
 
 // Parser Markdown解析器
@@ -44,15 +102,35 @@ type Parser struct {
 	chapterRegex    *regexp.Regexp
 	metaRegex       *regexp.Regexp
 	strategyManager *StrategyManager
+	options         Options
+	markdown        MarkdownRenderer
+	txtFormat       *TxtFormat
+	txtFormatErr    error
 }
 
-// New 创建新的解析器
-func New() *Parser {
+// ParserOption 解析器构造选项
+type ParserOption func(*Parser)
+
+// WithMarkdownRenderer 指定章节正文使用的 Markdown 渲染器
+func WithMarkdownRenderer(renderer MarkdownRenderer) ParserOption {
+	return func(p *Parser) {
+		p.markdown = renderer
+	}
+}
+
+// New 创建新的解析器，默认使用 blackfriday 渲染 Markdown
+func New(opts ...ParserOption) *Parser {
 	parser := &Parser{
 		// 匹配章节标题，支持多种格式，包括卷和章节
 		chapterRegex: regexp.MustCompile(`^#+\s*(?:第[0-9一二三四五六七八九十百千万]+[卷章回]|Chapter\s*\d+|Volume\s*\d+|[0-9]+\.)\s*(.+)`),
 		// 匹配元数据
 		metaRegex: regexp.MustCompile(`^---\s*$`),
+		markdown:  NewBlackfridayRenderer(),
+		txtFormat: NewTxtFormat(),
+	}
+
+	for _, opt := range opts {
+		opt(parser)
 	}
 	
 	// 初始化策略管理器
@@ -61,6 +139,39 @@ func New() *Parser {
 	return parser
 }
 
+// SetOptions 设置解析选项
+// 自定义的 TXT 标题正则在这里编译，编译失败时解析 TXT 小说会返回该错误
+func (p *Parser) SetOptions(options Options) {
+	p.options = options
+	p.txtFormat, p.txtFormatErr = NewTxtFormatWithPatterns(options.ChapterRegex, options.VolumeRegex)
+}
+
+// Options 获取解析选项
+func (p *Parser) Options() Options {
+	return p.options
+}
+
+// TxtFormat 获取按解析选项编译的 TXT 格式规则
+func (p *Parser) TxtFormat() (*TxtFormat, error) {
+	if p == nil || (p.txtFormat == nil && p.txtFormatErr == nil) {
+		return NewTxtFormat(), nil
+	}
+	return p.txtFormat, p.txtFormatErr
+}
+
+// MarkdownRenderer 获取解析器使用的 Markdown 渲染器
+func (p *Parser) MarkdownRenderer() MarkdownRenderer {
+	if p == nil || p.markdown == nil {
+		return NewBlackfridayRenderer()
+	}
+	return p.markdown
+}
+
+// RegisterStrategy 注册自定义解析策略，priority 越大越先检查，参见 StrategyManager.Register
+func (p *Parser) RegisterStrategy(strategy ParseStrategy, priority int) {
+	p.strategyManager.Register(strategy, priority)
+}
+
 // ParseNovel 解析小说目录
 func (p *Parser) ParseNovel(novelPath string) (*Novel, error) {
 	info, err := os.Stat(novelPath)
@@ -76,10 +187,23 @@ func (p *Parser) ParseNovel(novelPath string) (*Novel, error) {
 	}
 
 	// 使用策略模式选择合适的解析策略
-	strategy := p.strategyManager.SelectStrategy(novelPath)
+	strategy, err := p.strategyManager.SelectStrategy(novelPath)
+	if err != nil {
+		return novel, err
+	}
 	fmt.Printf("使用 %s 策略解析: %s\n", strategy.GetName(), novelPath)
 	
-	return novel, strategy.Parse(novel, novelPath)
+	if err := strategy.Parse(novel, novelPath); err != nil {
+		return novel, err
+	}
+
+	if p.options.ComputeDifficulty {
+		novel.Difficulty = ComputeDifficulty(novel)
+	}
+
+	novel.EstimatedCompletionDate, novel.CompletionConfidence = EstimateCompletion(novel)
+
+	return novel, nil
 }
 
 // parseNovelFromDir 从目录解析小说
@@ -142,36 +266,26 @@ func (p *Parser) parseNovelFromFile(novel *Novel) (*Novel, error) {
 	lines := strings.Split(string(content), "\n")
 	var currentChapter *Chapter
 	var contentLines []string
-	inMeta := false
 	chapterID := 0
 
 	// 使用文件名作为默认标题
 	novel.Title = strings.TrimSuffix(filepath.Base(novel.Path), ".md")
 
-	for i, line := range lines {
-		// 检查是否是元数据分隔符
-		if p.metaRegex.MatchString(line) {
-			if i == 0 {
-				inMeta = true
-				continue
-			} else if inMeta {
-				inMeta = false
-				continue
-			}
-		}
-
-		// 处理元数据
-		if inMeta {
-			p.parseMetaLine(novel, line)
-			continue
+	// 开头的 --- 或 +++ 元数据块
+	if format, metaLines, body, closed := splitFrontmatter(lines); closed {
+		if err := p.parseFrontmatter(novel, format, metaLines); err != nil {
+			return nil, err
 		}
+		lines = body
+	}
 
+	for _, line := range lines {
 		// 检查是否是章节标题
 		if matches := p.chapterRegex.FindStringSubmatch(line); matches != nil {
 			// 保存上一章节
 			if currentChapter != nil {
 				currentChapter.Content = strings.Join(contentLines, "\n")
-				currentChapter.HTMLContent = string(blackfriday.Run([]byte(currentChapter.Content)))
+				currentChapter.HTMLContent = renderMarkdown(p.markdown, currentChapter.Content)
 				currentChapter.WordCount = len([]rune(currentChapter.Content))
 				novel.Chapters = append(novel.Chapters, currentChapter)
 			}
@@ -193,7 +307,7 @@ func (p *Parser) parseNovelFromFile(novel *Novel) (*Novel, error) {
 	// 保存最后一个章节
 	if currentChapter != nil {
 		currentChapter.Content = strings.Join(contentLines, "\n")
-		currentChapter.HTMLContent = string(blackfriday.Run([]byte(currentChapter.Content)))
+		currentChapter.HTMLContent = renderMarkdown(p.markdown, currentChapter.Content)
 		currentChapter.WordCount = len([]rune(currentChapter.Content))
 		novel.Chapters = append(novel.Chapters, currentChapter)
 	}
@@ -209,27 +323,23 @@ func (p *Parser) parseNovelMeta(novel *Novel, metaFile string) error {
 	}
 	defer file.Close()
 
+	var lines []string
 	scanner := bufio.NewScanner(file)
-	inMeta := false
-
 	for scanner.Scan() {
-		line := scanner.Text()
-
-		if p.metaRegex.MatchString(line) {
-			if !inMeta {
-				inMeta = true
-				continue
-			} else {
-				break
-			}
-		}
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
 
-		if inMeta {
-			p.parseMetaLine(novel, line)
+	// 元数据块从第一个 --- 或 +++ 开始，未闭合时读到文件末尾
+	for i, line := range lines {
+		if DetectFrontmatterFormat(line) != FrontmatterNone {
+			format, metaLines, _, _ := splitFrontmatter(lines[i:])
+			return p.parseFrontmatter(novel, format, metaLines)
 		}
 	}
-
-	return scanner.Err()
+	return nil
 }
 
 // parseMetaLine 解析元数据行
@@ -239,12 +349,19 @@ func (p *Parser) parseMetaLine(novel *Novel, line string) {
 		return
 	}
 
-	key := strings.TrimSpace(parts[0])
-	value := strings.TrimSpace(parts[1])
+	p.setMeta(novel, parts[0], parts[1])
+}
+
+// setMeta 设置元数据字段，支持中英文键名
+func (p *Parser) setMeta(novel *Novel, key, value string) {
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
 
 	switch strings.ToLower(key) {
 	case "title", "标题":
 		novel.Title = value
+	case "subtitle", "副标题":
+		novel.Subtitle = value
 	case "author", "作者":
 		novel.Author = value
 	case "description", "简介", "描述":
@@ -253,12 +370,40 @@ func (p *Parser) parseMetaLine(novel *Novel, line string) {
 		novel.Cover = value
 	case "category", "分类":
 		novel.Category = value
+	case "sub_category", "subcategory", "子分类":
+		novel.SubCategory = value
+	case "expected_chapters", "预计章节":
+		if count, err := strconv.Atoi(value); err == nil && count > 0 {
+			novel.ExpectedChapters = count
+		}
 	case "tags", "标签":
-		novel.Tags = strings.Split(value, ",")
-		for i, tag := range novel.Tags {
-			novel.Tags[i] = strings.TrimSpace(tag)
+		novel.Tags = parseListValue(value)
+	case "genre", "genres", "题材":
+		novel.Genre = parseListValue(value)
+	case "status", "状态":
+		novel.Status = normalizeStatus(value)
+	case "language", "lang", "语言":
+		novel.Language = value
+	}
+}
+
+// parseListValue 解析列表值，支持 [a, b, c] 与 a, b, c 两种写法
+func parseListValue(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "[")
+	value = strings.TrimSuffix(value, "]")
+
+	items := make([]string, 0)
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '，' || r == '、'
+	}) {
+		item = strings.Trim(strings.TrimSpace(item), `"'`)
+		if item != "" {
+			items = append(items, item)
 		}
 	}
+
+	return items
 }
 
 // parseChapterFile 解析章节文件
@@ -331,7 +476,7 @@ func (p *Parser) parseChapterFile(filePath string) (*Chapter, error) {
 		ID:          chapterID,
 		Title:       title,
 		Content:     contentText,
-		HTMLContent: string(blackfriday.Run([]byte(contentText))),
+		HTMLContent: renderMarkdown(p.markdown, contentText),
 		WordCount:   len([]rune(contentText)),
 		CreatedAt:   info.ModTime(),
 		Path:        strings.TrimSuffix(fileName, ".md"),
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseListValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "玄幻, 修仙", want: []string{"玄幻", "修仙"}},
+		{value: "[玄幻, 修仙]", want: []string{"玄幻", "修仙"}},
+		{value: `["玄幻", '修仙']`, want: []string{"玄幻", "修仙"}},
+		{value: "玄幻，修仙、热血", want: []string{"玄幻", "修仙", "热血"}},
+		{value: "玄幻,, ,修仙", want: []string{"玄幻", "修仙"}},
+		{value: "", want: []string{}},
+		{value: "[]", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseListValue(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseListValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetMetaCategoryAndTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		meta         map[string]string
+		wantCategory string
+		wantSub      string
+		wantTags     []string
+	}{
+		{
+			name:         "english keys",
+			meta:         map[string]string{"category": "玄幻", "sub_category": "东方玄幻", "tags": "修仙, 热血"},
+			wantCategory: "玄幻",
+			wantSub:      "东方玄幻",
+			wantTags:     []string{"修仙", "热血"},
+		},
+		{
+			name:         "chinese keys",
+			meta:         map[string]string{"分类": "都市", "子分类": "职场", "标签": "[日常，轻松]"},
+			wantCategory: "都市",
+			wantSub:      "职场",
+			wantTags:     []string{"日常", "轻松"},
+		},
+		{
+			name:         "keys are case insensitive and trimmed",
+			meta:         map[string]string{" Category ": " 科幻 ", "TAGS": "太空、机甲"},
+			wantCategory: "科幻",
+			wantTags:     []string{"太空", "机甲"},
+		},
+	}
+
+	parser := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			novel := &Novel{}
+			for key, value := range tt.meta {
+				parser.setMeta(novel, key, value)
+			}
+			if novel.Category != tt.wantCategory {
+				t.Errorf("Category = %q, want %q", novel.Category, tt.wantCategory)
+			}
+			if novel.SubCategory != tt.wantSub {
+				t.Errorf("SubCategory = %q, want %q", novel.SubCategory, tt.wantSub)
+			}
+			if !reflect.DeepEqual(novel.Tags, tt.wantTags) {
+				t.Errorf("Tags = %q, want %q", novel.Tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestMarkdownFrontmatterCategoryAndTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantCategory string
+		wantTags     []string
+	}{
+		{
+			name:         "yaml list",
+			content:      "---\ncategory: 玄幻\ntags:\n  - 修仙\n  - 热血\n---\n# 第一章 开始\n正文\n",
+			wantCategory: "玄幻",
+			wantTags:     []string{"修仙", "热血"},
+		},
+		{
+			name:         "yaml inline string",
+			content:      "---\n分类: 都市\n标签: 日常，轻松\n---\n# 第一章 开始\n正文\n",
+			wantCategory: "都市",
+			wantTags:     []string{"日常", "轻松"},
+		},
+		{
+			name:         "toml array",
+			content:      "+++\ncategory = \"科幻\"\ntags = [\"太空\", \"机甲\"]\n+++\n# 第一章 开始\n正文\n",
+			wantCategory: "科幻",
+			wantTags:     []string{"太空", "机甲"},
+		},
+		{
+			name:         "invalid yaml falls back to key value lines",
+			content:      "---\ncategory: 历史\ntags: [三国, 架空\n---\n# 第一章 开始\n正文\n",
+			wantCategory: "历史",
+			wantTags:     []string{"三国", "架空"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "novel.md", tt.content)
+			novel := &Novel{}
+			if err := NewSingleFileStrategy(New()).Parse(novel, path); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if novel.Category != tt.wantCategory {
+				t.Errorf("Category = %q, want %q", novel.Category, tt.wantCategory)
+			}
+			if !reflect.DeepEqual(novel.Tags, tt.wantTags) {
+				t.Errorf("Tags = %q, want %q", novel.Tags, tt.wantTags)
+			}
+		})
+	}
+}
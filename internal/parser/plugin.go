@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// DecoratorPluginSymbol 插件中装饰器构造函数的符号名
+const DecoratorPluginSymbol = "NewDecorator"
+
+// DecoratorFactory 插件导出的装饰器构造函数签名
+type DecoratorFactory = func(ParserDecorator) ParserDecorator
+
+// WithPlugin 从外部Go插件（.so文件）加载装饰器
+// 插件需导出 func NewDecorator(parser.ParserDecorator) parser.ParserDecorator，
+// 且必须与主程序使用相同的工具链和依赖版本构建
+func (ep *EnhancedParser) WithPlugin(pluginPath string) (*EnhancedParser, error) {
+	factory, err := loadDecoratorPlugin(pluginPath)
+	if err != nil {
+		return ep, err
+	}
+
+	ep.decorators = append(ep.decorators, factory)
+	ep.finalDecorator = nil
+	return ep, nil
+}
+
+// loadDecoratorPlugin 打开插件并校验 NewDecorator 符号签名
+func loadDecoratorPlugin(pluginPath string) (DecoratorFactory, error) {
+	p, err := plugin.Open(pluginPath)
+	if err != nil {
+		return nil, fmt.Errorf("加载解析插件 %s 失败: %v", pluginPath, err)
+	}
+
+	sym, err := p.Lookup(DecoratorPluginSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("解析插件 %s 未导出 %s: %v", pluginPath, DecoratorPluginSymbol, err)
+	}
+
+	switch factory := sym.(type) {
+	case func(ParserDecorator) ParserDecorator:
+		return factory, nil
+	case *func(ParserDecorator) ParserDecorator:
+		// 以变量形式导出的构造函数
+		if factory == nil || *factory == nil {
+			return nil, fmt.Errorf("解析插件 %s 的 %s 为空", pluginPath, DecoratorPluginSymbol)
+		}
+		return *factory, nil
+	default:
+		return nil, fmt.Errorf("解析插件 %s 的 %s 签名无效: 期望 func(parser.ParserDecorator) parser.ParserDecorator，实际为 %T",
+			pluginPath, DecoratorPluginSymbol, sym)
+	}
+}
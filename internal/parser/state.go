@@ -15,6 +15,7 @@ type ParseState interface {
 type ParseContext struct {
 	novel          *Novel
 	currentChapter *Chapter
+	currentVolume  *Volume
 	contentLines   []string
 	chapterID      int
 	volumeID       int
@@ -24,6 +25,7 @@ type ParseContext struct {
 	chapterTypes   []ChapterType
 	inMetadata     bool
 	metadataLines  int
+	stats          TxtParseStats
 }
 
 // NewParseContext 创建解析上下文
@@ -62,9 +64,14 @@ func (pc *ParseContext) GetCurrentState() string {
 // SaveCurrentChapter 保存当前章节
 func (pc *ParseContext) SaveCurrentChapter() {
 	if pc.currentChapter != nil && len(pc.contentLines) > 0 {
-		pc.currentChapter.Content = pc.txtFormat.CleanContent(strings.Join(pc.contentLines, "\n"))
+		content := pc.txtFormat.CleanContent(strings.Join(pc.contentLines, "\n"))
+		// 剥离章节末尾的作者附言
+		pc.currentChapter.Content, pc.currentChapter.AuthorNote = ExtractAuthorNote(content)
 		pc.currentChapter.WordCount = len([]rune(pc.currentChapter.Content))
 		pc.novel.Chapters = append(pc.novel.Chapters, pc.currentChapter)
+		if pc.currentVolume != nil {
+			pc.currentVolume.Chapters = append(pc.currentVolume.Chapters, pc.currentChapter)
+		}
 		pc.contentLines = make([]string, 0)
 	}
 }
@@ -85,11 +92,13 @@ func (ms *MetadataState) HandleLine(context *ParseContext, line string, lineNumb
 
 	// 空行跳过
 	if line == "" {
+		context.stats.EmptyLines++
 		return nil
 	}
 
 	// 检查是否为分隔符，如果是则切换到内容状态
 	if context.txtFormat.IsSeparator(line) {
+		context.stats.FillerLines++
 		context.inMetadata = false
 		context.metadataLines = lineNumber
 		context.SetState(NewContentState())
@@ -98,14 +107,8 @@ func (ms *MetadataState) HandleLine(context *ParseContext, line string, lineNumb
 
 	// 尝试提取元数据
 	if key, value := context.txtFormat.ExtractMetadata(line); key != "" {
-		switch key {
-		case "title":
-			context.novel.Title = value
-		case "author":
-			context.novel.Author = value
-		case "description":
-			context.novel.Description = value
-		}
+		context.stats.FillerLines++
+		setTxtMetadata(context.novel, key, value)
 		return nil
 	}
 
@@ -115,6 +118,7 @@ func (ms *MetadataState) HandleLine(context *ParseContext, line string, lineNumb
 		return context.state.HandleLine(context, line, lineNumber)
 	}
 
+	context.stats.FillerLines++
 	return nil
 }
 
@@ -134,6 +138,7 @@ func (cs *ContentState) HandleLine(context *ParseContext, line string, lineNumbe
 
 	// 检查分隔符
 	if context.txtFormat.IsSeparator(line) {
+		context.stats.FillerLines++
 		return nil
 	}
 
@@ -141,6 +146,8 @@ func (cs *ContentState) HandleLine(context *ParseContext, line string, lineNumbe
 	chapterType, title := context.txtFormat.IdentifyChapterType(line)
 
 	if chapterType != ChapterTypeUnknown {
+		context.stats.HeaderLines++
+
 		// 保存上一章节
 		context.SaveCurrentChapter()
 
@@ -150,6 +157,9 @@ func (cs *ContentState) HandleLine(context *ParseContext, line string, lineNumbe
 			context.volumeID++
 			context.chapterID = 0
 			context.sectionID = 0
+			// 之后的章节归入新卷，直到遇到下一个卷标题
+			context.currentVolume = &Volume{ID: context.volumeID, Title: line}
+			context.novel.Volumes = append(context.novel.Volumes, context.currentVolume)
 		case ChapterTypeChapter:
 			context.chapterID++
 			context.sectionID = 0
@@ -159,9 +169,10 @@ func (cs *ContentState) HandleLine(context *ParseContext, line string, lineNumbe
 
 		// 创建新章节
 		context.currentChapter = &Chapter{
-			ID:    len(context.novel.Chapters) + 1,
-			Title: title,
-			Path:  fmt.Sprintf("chapter-%d", len(context.novel.Chapters)+1),
+			ID:      len(context.novel.Chapters) + 1,
+			Title:   title,
+			Path:    fmt.Sprintf("chapter-%d", len(context.novel.Chapters)+1),
+			heading: line,
 		}
 
 		// 记录章节类型
@@ -184,8 +195,10 @@ func (cs *ContentState) HandleLine(context *ParseContext, line string, lineNumbe
 
 	// 普通内容行
 	if line != "" {
+		context.stats.ContentLines++
 		context.contentLines = append(context.contentLines, line)
 	} else {
+		context.stats.EmptyLines++
 		context.contentLines = append(context.contentLines, "")
 	}
 
@@ -277,7 +290,15 @@ func (stp *StatefulTxtParser) Subscribe(observer ParseObserver) {
 
 // ParseWithState 使用状态模式解析
 func (stp *StatefulTxtParser) ParseWithState(novel *Novel, lines []string) error {
+	return stp.ParseWithFormat(novel, lines, stp.txtFormat)
+}
+
+// ParseWithFormat 使用状态模式及指定的格式规则解析
+func (stp *StatefulTxtParser) ParseWithFormat(novel *Novel, lines []string, format *TxtFormat) error {
 	context := NewParseContext(novel)
+	if format != nil {
+		context.txtFormat = format
+	}
 
 	stp.notifier.NotifyObservers(&ParseEventData{
 		Event:   ParseEventStart,
@@ -310,6 +331,10 @@ func (stp *StatefulTxtParser) ParseWithState(novel *Novel, lines []string) error
 	// 保存最后一章节
 	context.SaveCurrentChapter()
 
+	stats := context.stats
+	stats.TotalLines = totalLines
+	novel.ParseStats = &stats
+
 	stp.notifier.NotifyObservers(&ParseEventData{
 		Event:   ParseEventComplete,
 		Message: fmt.Sprintf("解析完成: 共%d章", len(novel.Chapters)),
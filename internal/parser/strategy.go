@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // ParseStrategy 解析策略接口
@@ -24,6 +26,7 @@ func NewSingleFileStrategy(parser *Parser) *SingleFileStrategy {
 	return &SingleFileStrategy{parser: parser}
 }
 
+// CanHandle 处理 .md 文件
 func (s *SingleFileStrategy) CanHandle(path string) bool {
 	info, err := os.Stat(path)
 	if err != nil {
@@ -36,7 +39,10 @@ func (s *SingleFileStrategy) GetName() string {
 	return "SingleFile"
 }
 
+// Parse 解析单个 Markdown 文件：开头的元数据块、以标题划分的章节及章节正文
+// 以 path 为准，便于脱离 Parser.ParseNovel 单独使用（如通过工厂创建的策略）
 func (s *SingleFileStrategy) Parse(novel *Novel, path string) error {
+	novel.Path = path
 	_, err := s.parser.parseNovelFromFile(novel)
 	return err
 }
@@ -206,40 +212,81 @@ func (s *MultiVolumeStrategy) parseVolume(novel *Novel, volumePath string) error
 	return nil
 }
 
-// StrategyManager 策略管理器
+// 默认策略优先级，数值越大越先检查
+// 文件类策略与目录类策略的 CanHandle 互不重叠，目录类中 TXT 目录先于 Markdown，多卷先于多文件
+const (
+	PriorityTxtFile      = 100
+	PriorityTxtDirectory = 90
+	PriorityDocxFile     = 80
+	PrioritySingleFile   = 70
+	PriorityMultiVolume  = 60
+	PriorityMultiFile    = 50
+)
+
+// prioritizedStrategy 带优先级的解析策略
+type prioritizedStrategy struct {
+	strategy ParseStrategy
+	priority int
+}
+
+// StrategyManager 策略管理器，按优先级从高到低选择第一个能处理路径的策略
 type StrategyManager struct {
-	strategies []ParseStrategy
+	mu         sync.RWMutex
+	strategies []prioritizedStrategy
 }
 
-// NewStrategyManager 创建策略管理器
+// NewStrategyManager 创建策略管理器并注册默认策略
 func NewStrategyManager(parser *Parser) *StrategyManager {
-	return &StrategyManager{
-		strategies: []ParseStrategy{
-			NewTxtDirectoryStrategy(parser), // 优先检查 TXT 目录
-			NewTxtFileStrategy(parser),      // 然后检查 TXT 文件
-			NewMultiVolumeStrategy(parser),  // 接着检查多卷 Markdown
-			NewMultiFileStrategy(parser),    // 然后检查多文件 Markdown
-			NewSingleFileStrategy(parser),   // 最后检查单文件 Markdown
-		},
-	}
-}
-
-// SelectStrategy 选择合适的解析策略
-func (sm *StrategyManager) SelectStrategy(path string) ParseStrategy {
-	for _, strategy := range sm.strategies {
-		if strategy.CanHandle(path) {
-			return strategy
+	sm := &StrategyManager{}
+	sm.Register(NewTxtFileStrategy(parser), PriorityTxtFile)
+	sm.Register(NewTxtDirectoryStrategy(parser), PriorityTxtDirectory)
+	sm.Register(NewDocxFileStrategy(parser), PriorityDocxFile)
+	sm.Register(NewSingleFileStrategy(parser), PrioritySingleFile)
+	sm.Register(NewMultiVolumeStrategy(parser), PriorityMultiVolume)
+	sm.Register(NewMultiFileStrategy(parser), PriorityMultiFile)
+	return sm
+}
+
+// Register 注册解析策略，priority 越大越先检查，相同优先级按注册顺序
+// 已存在同名（GetName）策略时替换原策略
+func (sm *StrategyManager) Register(strategy ParseStrategy, priority int) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	for i, entry := range sm.strategies {
+		if entry.strategy.GetName() == strategy.GetName() {
+			sm.strategies = append(sm.strategies[:i], sm.strategies[i+1:]...)
+			break
 		}
 	}
-	// 默认返回单文件策略
-	return sm.strategies[len(sm.strategies)-1]
+
+	sm.strategies = append(sm.strategies, prioritizedStrategy{strategy: strategy, priority: priority})
+	sort.SliceStable(sm.strategies, func(i, j int) bool {
+		return sm.strategies[i].priority > sm.strategies[j].priority
+	})
 }
 
-// GetAvailableStrategies 获取所有可用策略
+// SelectStrategy 选择合适的解析策略，没有策略能处理该路径时返回错误
+func (sm *StrategyManager) SelectStrategy(path string) (ParseStrategy, error) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for _, entry := range sm.strategies {
+		if entry.strategy.CanHandle(path) {
+			return entry.strategy, nil
+		}
+	}
+	return nil, fmt.Errorf("没有可以解析 %s 的策略", path)
+}
+
+// GetAvailableStrategies 按优先级获取所有可用策略
 func (sm *StrategyManager) GetAvailableStrategies() []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
 	names := make([]string, len(sm.strategies))
-	for i, strategy := range sm.strategies {
-		names[i] = strategy.GetName()
+	for i, entry := range sm.strategies {
+		names[i] = entry.strategy.GetName()
 	}
 	return names
 }
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeFile 在 dir 中写入文件并返回路径
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSingleFileStrategyCanHandle(t *testing.T) {
+	dir := t.TempDir()
+	strategy := NewSingleFileStrategy(New())
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "markdown file", path: writeFile(t, dir, "novel.md", "# 第一章 开始\n"), want: true},
+		{name: "upper case extension", path: writeFile(t, dir, "NOVEL.MD", "# 第一章 开始\n"), want: true},
+		{name: "txt file", path: writeFile(t, dir, "novel.txt", "第一章 开始\n"), want: false},
+		{name: "directory", path: dir, want: false},
+		{name: "missing file", path: filepath.Join(dir, "missing.md"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strategy.CanHandle(tt.path); got != tt.want {
+				t.Errorf("CanHandle(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSingleFileStrategyParse(t *testing.T) {
+	type chapter struct {
+		title   string
+		content string
+	}
+
+	tests := []struct {
+		name       string
+		file       string
+		content    string
+		wantTitle  string
+		wantAuthor string
+		want       []chapter
+	}{
+		{
+			name: "yaml frontmatter",
+			file: "novel.md",
+			content: "---\ntitle: 测试小说\nauthor: 张三\n---\n" +
+				"# 第一章 开始\n第一段\n\n第二段\n" +
+				"# 第二章 继续\n结尾\n",
+			wantTitle:  "测试小说",
+			wantAuthor: "张三",
+			want: []chapter{
+				{title: "开始", content: "第一段\n\n第二段"},
+				{title: "继续", content: "结尾\n"},
+			},
+		},
+		{
+			name:      "file name as default title",
+			file:      "默认标题.md",
+			content:   "# Chapter 1 Start\nHello\n",
+			wantTitle: "默认标题",
+			want:      []chapter{{title: "Start", content: "Hello\n"}},
+		},
+		{
+			name:      "text before the first heading is dropped",
+			file:      "preface.md",
+			content:   "前言\n## 第1章 起点\n正文\n## 2. 终点\n",
+			wantTitle: "preface",
+			want: []chapter{
+				{title: "起点", content: "正文"},
+				{title: "终点", content: ""},
+			},
+		},
+		{
+			name:      "no chapters",
+			file:      "empty.md",
+			content:   "只有正文，没有章节标题\n",
+			wantTitle: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), tt.file, tt.content)
+			novel := &Novel{}
+
+			if err := NewSingleFileStrategy(New()).Parse(novel, path); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if novel.Path != path {
+				t.Errorf("Path = %q, want %q", novel.Path, path)
+			}
+			if novel.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", novel.Title, tt.wantTitle)
+			}
+			if novel.Author != tt.wantAuthor {
+				t.Errorf("Author = %q, want %q", novel.Author, tt.wantAuthor)
+			}
+			if len(novel.Chapters) != len(tt.want) {
+				t.Fatalf("len(Chapters) = %d, want %d", len(novel.Chapters), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := novel.Chapters[i]
+				if got.ID != i+1 {
+					t.Errorf("Chapters[%d].ID = %d, want %d", i, got.ID, i+1)
+				}
+				if got.Title != want.title {
+					t.Errorf("Chapters[%d].Title = %q, want %q", i, got.Title, want.title)
+				}
+				if got.Content != want.content {
+					t.Errorf("Chapters[%d].Content = %q, want %q", i, got.Content, want.content)
+				}
+				if got.WordCount != len([]rune(want.content)) {
+					t.Errorf("Chapters[%d].WordCount = %d, want %d", i, got.WordCount, len([]rune(want.content)))
+				}
+				if want.content != "" && got.HTMLContent == "" {
+					t.Errorf("Chapters[%d].HTMLContent 为空", i)
+				}
+			}
+		})
+	}
+}
+
+func TestSingleFileStrategyParseMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.md")
+	if err := NewSingleFileStrategy(New()).Parse(&Novel{}, path); err == nil {
+		t.Error("Parse() error = nil, want error")
+	}
+}
+
+func TestStrategyManagerSelectsSingleFile(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "novel.md", "# 第一章 开始\n")
+
+	strategy, err := NewStrategyManager(New()).SelectStrategy(path)
+	if err != nil {
+		t.Fatalf("SelectStrategy() error = %v", err)
+	}
+	if strategy.GetName() != "SingleFile" {
+		t.Errorf("SelectStrategy() = %s, want SingleFile", strategy.GetName())
+	}
+
+	if names := NewStrategyManager(New()).GetAvailableStrategies(); !slices.Contains(names, "SingleFile") {
+		t.Errorf("GetAvailableStrategies() = %v, missing SingleFile", names)
+	}
+}
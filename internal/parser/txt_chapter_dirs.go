@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// chapterDirContentFile 章节目录中保存正文的文件
+const chapterDirContentFile = "content.txt"
+
+// chapterDirRegex 匹配 “001-章节标题” 形式的章节目录名，第一个捕获组为序号，第二个为标题
+var chapterDirRegex = regexp.MustCompile(`^(\d+)[-_.\s]+(.+)$`)
+
+// chapterDir 以目录保存的章节
+type chapterDir struct {
+	path   string
+	number int
+	title  string
+}
+
+// chapterDirs 查找 “001-章节标题/content.txt” 形式的章节目录，按序号排序
+func (s *TxtDirectoryStrategy) chapterDirs(path string) []chapterDir {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil
+	}
+
+	var dirs []chapterDir
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		match := chapterDirRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		dirPath := filepath.Join(path, entry.Name())
+		if info, err := os.Stat(filepath.Join(dirPath, chapterDirContentFile)); err != nil || info.IsDir() {
+			continue
+		}
+
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		dirs = append(dirs, chapterDir{
+			path:   dirPath,
+			number: number,
+			title:  strings.TrimSpace(match[2]),
+		})
+	}
+
+	sort.SliceStable(dirs, func(i, j int) bool {
+		if dirs[i].number != dirs[j].number {
+			return dirs[i].number < dirs[j].number
+		}
+		return filepath.Base(dirs[i].path) < filepath.Base(dirs[j].path)
+	})
+	return dirs
+}
+
+// parseChapterDirs 每个章节目录解析为一章，目录名去掉序号后作为章节标题
+func (s *TxtDirectoryStrategy) parseChapterDirs(novel *Novel, dirs []chapterDir) error {
+	format, err := s.parser.TxtFormat()
+	if err != nil {
+		return err
+	}
+
+	txtStrategy := NewTxtFileStrategy(s.parser)
+	txtStrategy.txtFormat = format
+
+	for _, dir := range dirs {
+		contentPath := filepath.Join(dir.path, chapterDirContentFile)
+		data, err := os.ReadFile(contentPath)
+		if err != nil {
+			fmt.Printf("警告：读取章节文件 %s 失败: %v\n", contentPath, err)
+			continue
+		}
+
+		text, err := detectAndDecodeEncoding(data)
+		if err != nil {
+			fmt.Printf("警告：读取章节文件 %s 失败: %v\n", contentPath, err)
+			continue
+		}
+
+		content := format.CleanContent(txtStrategy.prepareContent(text))
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		content, authorNote := ExtractAuthorNote(content)
+
+		id := len(novel.Chapters) + 1
+		novel.Chapters = append(novel.Chapters, &Chapter{
+			ID:          id,
+			Title:       dir.title,
+			Content:     content,
+			HTMLContent: txtStrategy.chapterAdapter.ConvertContentToHTML(content, "txt"),
+			AuthorNote:  authorNote,
+			WordCount:   len([]rune(content)),
+			CreatedAt:   time.Now(),
+			Path:        fmt.Sprintf("chapter-%d", id),
+		})
+	}
+
+	txtStrategy.renderIllustrations(novel, func(content string) string {
+		return txtStrategy.chapterAdapter.ConvertContentToHTML(content, "txt")
+	})
+
+	return nil
+}
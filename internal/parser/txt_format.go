@@ -1,8 +1,11 @@
 package parser
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // TxtFormat TXT 文件格式定义和解析规则
@@ -18,6 +21,9 @@ type TxtFormat struct {
 	// 分隔符规则
 	SeparatorRegex *regexp.Regexp // 章节分隔符
 
+	// 插图标记规则
+	IllustrationMarkerRegex *regexp.Regexp // 插图位置标记
+
 	// 元数据规则
 	TitleRegex    *regexp.Regexp // 小说标题
 	AuthorRegex   *regexp.Regexp // 作者
@@ -50,6 +56,9 @@ func NewTxtFormat() *TxtFormat {
 		// 分隔符：多个等号、减号、星号等
 		SeparatorRegex: regexp.MustCompile(`^\s*[=\-*~]{3,}\s*$`),
 
+		// 插图标记：[图]、[插图：说明]、<<illustration-001>>、<<< 说明 >>>
+		IllustrationMarkerRegex: regexp.MustCompile(`\[(?:图|插图)(?:\s*[：:]\s*([^\]\n]*?))?\s*\]|<{2,3}\s*([^<>\n]+?)\s*>{2,3}`),
+
 		// 小说标题：书名、标题等
 		TitleRegex: regexp.MustCompile(`(?i)^\s*(?:书名|标题|小说名|作品名)\s*[：:\s]+(.+)$`),
 
@@ -67,6 +76,98 @@ func NewTxtFormat() *TxtFormat {
 	}
 }
 
+// NewTxtFormatWithPatterns 创建 TXT 格式解析器，chapterPattern、volumePattern 非空时替换内置的章节、卷标题规则
+// 自定义规则的第一个捕获组作为标题，没有捕获组时使用整行
+func NewTxtFormatWithPatterns(chapterPattern, volumePattern string) (*TxtFormat, error) {
+	tf := NewTxtFormat()
+
+	if chapterPattern != "" {
+		re, err := regexp.Compile(chapterPattern)
+		if err != nil {
+			return nil, fmt.Errorf("章节标题正则 chapter_regex %q 无效: %v", chapterPattern, err)
+		}
+		tf.ChapterRegex = re
+	}
+
+	if volumePattern != "" {
+		re, err := regexp.Compile(volumePattern)
+		if err != nil {
+			return nil, fmt.Errorf("卷标题正则 volume_regex %q 无效: %v", volumePattern, err)
+		}
+		tf.VolumeRegex = re
+	}
+
+	return tf, nil
+}
+
+// 引号统一风格
+const (
+	QuoteStyleASCII   = "ascii"   // 统一为直引号 " '
+	QuoteStyleUnicode = "unicode" // 统一为成对的弯引号 “” ‘’
+)
+
+var (
+	// doubleQuoteVariants 双引号的各种变体
+	doubleQuoteVariants = "\"“”„‟″＂〝〞"
+	// singleQuoteVariants 单引号的各种变体
+	singleQuoteVariants = "'‘’‚‛′＇"
+)
+
+// NormalizeQuotes 将各种弯引号、全角引号统一为 ASCII 直引号
+func (tf *TxtFormat) NormalizeQuotes(content string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case strings.ContainsRune(doubleQuoteVariants, r):
+			return '"'
+		case strings.ContainsRune(singleQuoteVariants, r):
+			return '\''
+		}
+		return r
+	}, content)
+}
+
+// NormalizeQuotesTo 按指定风格统一引号
+// unicode 风格下每行内的引号按出现顺序交替为左右引号，单词内部的撇号转换为 ’
+func (tf *TxtFormat) NormalizeQuotesTo(content, style string) string {
+	normalized := tf.NormalizeQuotes(content)
+	if style != QuoteStyleUnicode {
+		return normalized
+	}
+
+	lines := strings.Split(normalized, "\n")
+	for i, line := range lines {
+		runes := []rune(line)
+		doubleOpen, singleOpen := true, true
+
+		for j, r := range runes {
+			switch r {
+			case '"':
+				if doubleOpen {
+					runes[j] = '“'
+				} else {
+					runes[j] = '”'
+				}
+				doubleOpen = !doubleOpen
+			case '\'':
+				// 单词内部的撇号（如 don't）
+				if j > 0 && j < len(runes)-1 && unicode.IsLetter(runes[j-1]) && unicode.IsLetter(runes[j+1]) {
+					runes[j] = '’'
+					continue
+				}
+				if singleOpen {
+					runes[j] = '‘'
+				} else {
+					runes[j] = '’'
+				}
+				singleOpen = !singleOpen
+			}
+		}
+		lines[i] = string(runes)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // ChapterType 章节类型
 type ChapterType int
 
@@ -146,7 +247,7 @@ func (tf *TxtFormat) IdentifyChapterType(line string) (ChapterType, string) {
 
 	// 检查卷标题
 	if matches := tf.VolumeRegex.FindStringSubmatch(line); matches != nil {
-		title := strings.TrimSpace(matches[1])
+		title := firstSubmatch(matches)
 		if title == "" {
 			// 如果没有卷名，使用整个匹配作为标题
 			title = strings.TrimSpace(strings.Split(matches[0], ":")[0])
@@ -156,7 +257,7 @@ func (tf *TxtFormat) IdentifyChapterType(line string) (ChapterType, string) {
 
 	// 检查章节标题
 	if matches := tf.ChapterRegex.FindStringSubmatch(line); matches != nil {
-		title := strings.TrimSpace(matches[1])
+		title := firstSubmatch(matches)
 		if title == "" {
 			// 如果没有章节名，使用整个匹配作为标题
 			title = strings.TrimSpace(strings.Split(matches[0], ":")[0])
@@ -176,6 +277,14 @@ func (tf *TxtFormat) IdentifyChapterType(line string) (ChapterType, string) {
 	return ChapterTypeUnknown, ""
 }
 
+// firstSubmatch 返回第一个捕获组的内容，自定义正则可能没有捕获组
+func firstSubmatch(matches []string) string {
+	if len(matches) < 2 {
+		return ""
+	}
+	return strings.TrimSpace(matches[1])
+}
+
 // IsSeparator 判断是否为分隔符
 func (tf *TxtFormat) IsSeparator(line string) bool {
 	return tf.SeparatorRegex.MatchString(line)
@@ -270,40 +379,122 @@ func (tf *TxtFormat) ExtractNumber(title string) int {
 }
 
 // parseChineseNumber 解析中文数字
+// 支持带单位的读法（十二、一百零五、十万、三亿二千万）与逐位读法（一〇五、二〇二四）
 func parseChineseNumber(chinese string) int {
-	chineseNums := map[rune]int{
+	digits := map[rune]int{
+		'零': 0, '〇': 0, '两': 2,
 		'一': 1, '二': 2, '三': 3, '四': 4, '五': 5,
-		'六': 6, '七': 7, '八': 8, '九': 9, '十': 10,
-		'百': 100, '千': 1000, '万': 10000,
+		'六': 6, '七': 7, '八': 8, '九': 9,
+	}
+	units := map[rune]int{'十': 10, '百': 100, '千': 1000}
+
+	// 不含单位时按位读出，如 一〇五 为 105
+	if !strings.ContainsAny(chinese, "十百千万亿") {
+		result := 0
+		for _, char := range chinese {
+			if val, exists := digits[char]; exists {
+				result = result*10 + val
+			}
+		}
+		return result
 	}
 
-	result := 0
-	temp := 0
-
+	// section 为万以下的部分，遇到万、亿时乘以对应单位并入 result
+	result, section, digit := 0, 0, 0
 	for _, char := range chinese {
-		if val, exists := chineseNums[char]; exists {
-			switch char {
-			case '十':
-				if temp == 0 {
-					temp = 1
-				}
-				result += temp * 10
-				temp = 0
-			case '百':
-				result += temp * 100
-				temp = 0
-			case '千':
-				result += temp * 1000
-				temp = 0
-			case '万':
-				result += temp * 10000
-				temp = 0
-			default:
-				temp = val
+		if val, exists := digits[char]; exists {
+			digit = val
+			continue
+		}
+		if unit, exists := units[char]; exists {
+			// 十、十二 等省略了开头的一
+			if digit == 0 {
+				digit = 1
 			}
+			section += digit * unit
+			digit = 0
+			continue
+		}
+		switch char {
+		case '万':
+			result += (section + digit) * 10000
+			section, digit = 0, 0
+		case '亿':
+			result = (result + section + digit) * 100000000
+			section, digit = 0, 0
 		}
 	}
 
-	result += temp
-	return result
+	return result + section + digit
+}
+
+// authorNoteRegex 匹配章节末尾的作者附言，如 【作者有话说：...】、（PS：...）
+var authorNoteRegex = regexp.MustCompile(`(?s)(?:【\s*作者(?:有话说|有话要说|的话)\s*[:：]?\s*([^】]*)】|[（(]\s*[Pp][Ss]\s*[:：]\s*([^）)]*)[）)])\s*$`)
+
+// ExtractAuthorNote 从章节内容末尾剥离作者附言，返回正文与附言
+// 末尾连续出现多条附言时按原顺序合并
+func ExtractAuthorNote(content string) (string, string) {
+	var notes []string
+	body := strings.TrimRight(content, " \t\r\n")
+
+	for {
+		loc := authorNoteRegex.FindStringSubmatchIndex(body)
+		if loc == nil {
+			break
+		}
+
+		note := ""
+		if loc[2] >= 0 {
+			note = body[loc[2]:loc[3]]
+		} else if loc[4] >= 0 {
+			note = body[loc[4]:loc[5]]
+		}
+		if note = strings.TrimSpace(note); note != "" {
+			notes = append([]string{note}, notes...)
+		}
+		body = strings.TrimRight(body[:loc[0]], " \t\r\n")
+	}
+
+	if len(notes) == 0 {
+		return content, ""
+	}
+	return body, strings.Join(notes, "\n\n")
+}
+
+// ExtractIllustrationMarkers 提取内容中的插图标记，按出现顺序返回
+func (tf *TxtFormat) ExtractIllustrationMarkers(content string) []IllustrationMarker {
+	var markers []IllustrationMarker
+	for _, loc := range tf.IllustrationMarkerRegex.FindAllStringSubmatchIndex(content, -1) {
+		markers = append(markers, IllustrationMarker{
+			Position: utf8.RuneCountInString(content[:loc[0]]),
+			Label:    illustrationLabel(content, loc),
+		})
+	}
+	return markers
+}
+
+// ReplaceIllustrationMarkers 按出现顺序替换内容中的插图标记
+func (tf *TxtFormat) ReplaceIllustrationMarkers(content string, replace func(index int, marker IllustrationMarker) string) string {
+	var sb strings.Builder
+	last := 0
+	for i, loc := range tf.IllustrationMarkerRegex.FindAllStringSubmatchIndex(content, -1) {
+		sb.WriteString(content[last:loc[0]])
+		sb.WriteString(replace(i, IllustrationMarker{
+			Position: utf8.RuneCountInString(content[:loc[0]]),
+			Label:    illustrationLabel(content, loc),
+		}))
+		last = loc[1]
+	}
+	sb.WriteString(content[last:])
+	return sb.String()
+}
+
+// illustrationLabel 取出插图标记中的说明文字
+func illustrationLabel(content string, loc []int) string {
+	for i := 2; i+1 < len(loc); i += 2 {
+		if loc[i] >= 0 {
+			return strings.TrimSpace(content[loc[i]:loc[i+1]])
+		}
+	}
+	return ""
 }
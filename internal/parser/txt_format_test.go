@@ -0,0 +1,33 @@
+package parser
+
+import "testing"
+
+func TestParseChineseNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"五", 5},
+		{"十", 10},
+		{"十二", 12},
+		{"二十", 20},
+		{"一百零五", 105},
+		{"一千零一十", 1010},
+		{"两百", 200},
+		// 逐位读法
+		{"一〇五", 105},
+		{"一零五", 105},
+		{"二〇二四", 2024},
+		// 万、亿作用于前面的整段
+		{"十万", 100000},
+		{"一万零五", 10005},
+		{"一百二十万三千", 1203000},
+		{"三亿二千万", 320000000},
+	}
+
+	for _, tt := range tests {
+		if got := parseChineseNumber(tt.input); got != tt.want {
+			t.Errorf("parseChineseNumber(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
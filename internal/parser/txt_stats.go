@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// TxtParseStats TXT 解析统计，用于排查章节数与预期不符等问题
+type TxtParseStats struct {
+	TotalLines       int    `json:"total_lines"`
+	HeaderLines      int    `json:"header_lines"`  // 识别为卷、章、节等标题的行
+	ContentLines     int    `json:"content_lines"` // 正文行
+	EmptyLines       int    `json:"empty_lines"`   // 空行
+	FillerLines      int    `json:"filler_lines"`  // 元数据、分隔符等不计入正文的行
+	DetectedEncoding string `json:"detected_encoding"`
+	DetectedStrategy string `json:"detected_strategy"`
+}
+
+// Table 以表格形式输出统计信息
+func (s *TxtParseStats) Table() string {
+	rows := []struct {
+		name  string
+		value string
+	}{
+		{"解析策略", s.DetectedStrategy},
+		{"文件编码", s.DetectedEncoding},
+		{"总行数", fmt.Sprint(s.TotalLines)},
+		{"标题行", fmt.Sprint(s.HeaderLines)},
+		{"正文行", fmt.Sprint(s.ContentLines)},
+		{"空行", fmt.Sprint(s.EmptyLines)},
+		{"填充行", fmt.Sprint(s.FillerLines)},
+	}
+
+	var sb strings.Builder
+	sb.WriteString("+----------+----------------------+\n")
+	for _, row := range rows {
+		// 中文标签占两列宽度，按显示宽度补齐
+		padding := 8 - 2*utf8.RuneCountInString(row.name)
+		sb.WriteString(fmt.Sprintf("| %s%s | %-20s |\n", row.name, strings.Repeat(" ", padding), row.value))
+	}
+	sb.WriteString("+----------+----------------------+\n")
+	return sb.String()
+}
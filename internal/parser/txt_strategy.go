@@ -3,20 +3,20 @@ package parser
 import (
 	"bufio"
 	"fmt"
+	"html"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/russross/blackfriday/v2"
 )
 
 // TxtFileStrategy TXT 文件解析策略
 type TxtFileStrategy struct {
 	parser         *Parser
 	txtFormat      *TxtFormat
-	enhancedParser *EnhancedParser
 	chapterAdapter *ChapterAdapter
 	statefulParser *StatefulTxtParser
 }
@@ -30,16 +30,12 @@ func NewTxtFileStrategy(parser *Parser) *TxtFileStrategy {
 		statefulParser: NewStatefulTxtParser(),
 	}
 
-	// 创建增强解析器
 	consoleObserver := NewConsoleObserver(false) // 设置为 false 减少输出
-	strategy.enhancedParser = NewEnhancedParser().
-		WithLogging(consoleObserver).
-		WithCaching().
-		WithValidation()
 
 	// 订阅解析事件
 	strategy.chapterAdapter.Subscribe(consoleObserver)
 	strategy.statefulParser.Subscribe(consoleObserver)
+	strategy.chapterAdapter.SetMarkdownRenderer(parser.MarkdownRenderer())
 
 	return strategy
 }
@@ -57,8 +53,9 @@ func (s *TxtFileStrategy) GetName() string {
 }
 
 func (s *TxtFileStrategy) Parse(novel *Novel, path string) error {
-	// 使用文件名作为默认标题
+	// 使用文件名作为默认标题，“作者 - 书名.txt”形式的文件名同时提供作者
 	novel.Title = strings.TrimSuffix(filepath.Base(path), ".txt")
+	NewFilenameMetadataStrategy(s.parser.Options().FilenameSeparator).Apply(novel, path)
 
 	// 读取文件内容
 	content, err := os.ReadFile(path)
@@ -66,21 +63,155 @@ func (s *TxtFileStrategy) Parse(novel *Novel, path string) error {
 		return fmt.Errorf("读取文件失败: %v", err)
 	}
 
-	lines := strings.Split(string(content), "\n")
+	// 转为 UTF-8 后再按行切分，GBK 等编码的文件直接切分会产生乱码
+	text, encodingName, err := decodeText(content)
+	if err != nil {
+		return fmt.Errorf("读取文件 %s 失败: %v", path, err)
+	}
+
+	format, err := s.parser.TxtFormat()
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(s.prepareContent(text), "\n")
 
 	// 使用状态化解析器
-	if err := s.statefulParser.ParseWithState(novel, lines); err != nil {
+	if err := s.statefulParser.ParseWithFormat(novel, lines, format); err != nil {
 		return fmt.Errorf("状态化解析失败: %v", err)
 	}
 
+	if minWords := s.parser.Options().MinChapterWords; minWords > 0 {
+		novel.Chapters = mergeShortChapters(novel.Chapters, minWords)
+		novel.Volumes = pruneVolumes(novel.Volumes, novel.Chapters)
+	}
+
+	if stats, ok := novel.ParseStats.(*TxtParseStats); ok {
+		stats.DetectedEncoding = encodingName
+		stats.DetectedStrategy = s.GetName()
+	}
+
 	// 使用适配器转换内容
 	if err := s.chapterAdapter.ConvertNovel(novel, "txt"); err != nil {
 		return fmt.Errorf("内容转换失败: %v", err)
 	}
 
+	s.renderIllustrations(novel, func(content string) string {
+		return s.chapterAdapter.ConvertContentToHTML(content, "txt")
+	})
+
 	return nil
 }
 
+// mergeShortChapters 将字数少于 minWords 的章节连同标题并入上一章，并重新编号
+// 用于过滤被误识别为章节标题的正文行（如以数字开头的段落）
+func mergeShortChapters(chapters []*Chapter, minWords int) []*Chapter {
+	var merged []*Chapter
+	for _, chapter := range chapters {
+		if len(merged) == 0 || chapter.WordCount >= minWords {
+			merged = append(merged, chapter)
+			continue
+		}
+
+		heading := chapter.heading
+		if heading == "" {
+			heading = chapter.Title
+		}
+
+		prev := merged[len(merged)-1]
+		prev.Content = strings.TrimSpace(prev.Content + "\n\n" + heading + "\n\n" + chapter.Content)
+		prev.WordCount = len([]rune(prev.Content))
+		if chapter.AuthorNote != "" {
+			prev.AuthorNote = strings.TrimSpace(prev.AuthorNote + "\n\n" + chapter.AuthorNote)
+		}
+	}
+
+	for i, chapter := range merged {
+		chapter.ID = i + 1
+		chapter.Path = fmt.Sprintf("chapter-%d", i+1)
+	}
+	return merged
+}
+
+// pruneVolumes 移除卷中已被合并的章节，并丢弃没有章节的卷，剩余的卷重新编号
+func pruneVolumes(volumes []*Volume, chapters []*Chapter) []*Volume {
+	kept := make(map[*Chapter]bool, len(chapters))
+	for _, chapter := range chapters {
+		kept[chapter] = true
+	}
+
+	var result []*Volume
+	for _, volume := range volumes {
+		var volumeChapters []*Chapter
+		for _, chapter := range volume.Chapters {
+			if kept[chapter] {
+				volumeChapters = append(volumeChapters, chapter)
+			}
+		}
+		if len(volumeChapters) == 0 {
+			continue
+		}
+		volume.Chapters = volumeChapters
+		volume.ID = len(result) + 1
+		result = append(result, volume)
+	}
+	return result
+}
+
+// renderIllustrations 识别章节中的插图标记，并在 HTML 中替换为 <figure> 占位
+// toHTML 为章节内容转 HTML 的方式，需与生成 HTMLContent 时一致
+func (s *TxtFileStrategy) renderIllustrations(novel *Novel, toHTML func(string) string) {
+	for _, chapter := range novel.Chapters {
+		chapter.IllustrationMarkers = s.txtFormat.ExtractIllustrationMarkers(chapter.Content)
+		if len(chapter.IllustrationMarkers) == 0 {
+			continue
+		}
+
+		// 标记中的尖括号会被当作 HTML 处理，先替换为占位符再转换
+		content := s.txtFormat.ReplaceIllustrationMarkers(chapter.Content, func(index int, _ IllustrationMarker) string {
+			return illustrationPlaceholder(index)
+		})
+		htmlContent := toHTML(content)
+
+		for i, marker := range chapter.IllustrationMarkers {
+			placeholder := illustrationPlaceholder(i)
+			figure := IllustrationFigureHTML(marker)
+
+			// 独占一段的标记替换整个段落，避免 <figure> 嵌套在 <p> 中
+			paragraph := regexp.MustCompile(`<p[^>]*>\s*` + regexp.QuoteMeta(placeholder) + `\s*</p>`)
+			if loc := paragraph.FindStringIndex(htmlContent); loc != nil {
+				htmlContent = htmlContent[:loc[0]] + figure + htmlContent[loc[1]:]
+			} else {
+				htmlContent = strings.Replace(htmlContent, placeholder, figure, 1)
+			}
+		}
+		chapter.HTMLContent = htmlContent
+	}
+}
+
+// illustrationPlaceholder 插图标记在 Markdown 转换期间的占位符（使用私用区字符，不会被转义）
+func illustrationPlaceholder(index int) string {
+	return fmt.Sprintf("\uE000illustration-%d\uE001", index)
+}
+
+// IllustrationFigureHTML 生成插图占位的 HTML，图片由生成器在存在对应文件时嵌入
+func IllustrationFigureHTML(marker IllustrationMarker) string {
+	caption := marker.Label
+	if caption == "" {
+		caption = "插图"
+	}
+	return fmt.Sprintf(`<figure class="illustration" data-illustration="%s"><figcaption>%s</figcaption></figure>`,
+		html.EscapeString(marker.Label), html.EscapeString(caption))
+}
+
+// prepareContent 按解析选项预处理文件内容
+func (s *TxtFileStrategy) prepareContent(content string) string {
+	if options := s.parser.Options(); options.NormalizeQuotes {
+		content = s.txtFormat.NormalizeQuotesTo(content, options.QuoteStyle)
+	}
+	return content
+}
+
 // parseContentOld 旧的解析方法（保留作为备用）
 func (s *TxtFileStrategy) parseContentOld(novel *Novel, lines []string) error {
 	var txtChapters []*TxtChapter
@@ -119,7 +250,7 @@ func (s *TxtFileStrategy) parseContentOld(novel *Novel, lines []string) error {
 		// 在元数据阶段，尝试提取元数据
 		if inMetadata && i < 50 { // 只在前50行查找元数据
 			if key, value := s.txtFormat.ExtractMetadata(line); key != "" {
-				s.setNovelMetadata(novel, key, value)
+				setTxtMetadata(novel, key, value)
 				continue
 			}
 		}
@@ -196,8 +327,8 @@ func (s *TxtFileStrategy) parseContentOld(novel *Novel, lines []string) error {
 	return s.convertToStandardChapters(novel, txtChapters)
 }
 
-// setNovelMetadata 设置小说元数据
-func (s *TxtFileStrategy) setNovelMetadata(novel *Novel, key, value string) {
+// setTxtMetadata 设置 TXT 元数据行（见 TxtFormat.ExtractMetadata）提取出的字段
+func setTxtMetadata(novel *Novel, key, value string) {
 	switch key {
 	case "title":
 		novel.Title = value
@@ -208,12 +339,14 @@ func (s *TxtFileStrategy) setNovelMetadata(novel *Novel, key, value string) {
 	case "category":
 		novel.Category = value
 	case "tags":
-		// 处理标签，支持逗号、分号、空格分隔
-		tags := strings.FieldsFunc(value, func(r rune) bool {
-			return r == ',' || r == ';' || r == '，' || r == '；'
-		})
-		for i, tag := range tags {
-			tags[i] = strings.TrimSpace(tag)
+		// 处理标签，支持逗号、分号、顿号分隔
+		tags := make([]string, 0)
+		for _, tag := range strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ';' || r == '，' || r == '；' || r == '、'
+		}) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
 		}
 		novel.Tags = tags
 	}
@@ -248,13 +381,17 @@ func (s *TxtFileStrategy) convertToStandardChapters(novel *Novel, txtChapters []
 		// 生成章节标题
 		title := s.generateChapterTitle(txtChapter)
 
+		// 剥离章节末尾的作者附言
+		content, authorNote := ExtractAuthorNote(txtChapter.Content)
+
 		// 创建标准章节
 		chapter := &Chapter{
 			ID:          chapterID,
 			Title:       title,
-			Content:     txtChapter.Content,
-			HTMLContent: string(blackfriday.Run([]byte(txtChapter.Content))),
-			WordCount:   len([]rune(txtChapter.Content)),
+			Content:     content,
+			HTMLContent: renderMarkdown(s.parser.MarkdownRenderer(), content),
+			AuthorNote:  authorNote,
+			WordCount:   len([]rune(content)),
 			CreatedAt:   time.Now(),
 			Path:        fmt.Sprintf("chapter-%d", chapterID),
 		}
@@ -343,7 +480,8 @@ func (s *TxtDirectoryStrategy) CanHandle(path string) bool {
 		}
 	}
 
-	return hasTxt
+	// 或者包含 “001-章节标题/content.txt” 形式的章节目录
+	return hasTxt || len(s.chapterDirs(path)) > 0
 }
 
 func (s *TxtDirectoryStrategy) GetName() string {
@@ -368,6 +506,11 @@ func (s *TxtDirectoryStrategy) Parse(novel *Novel, path string) error {
 		novel.Title = filepath.Base(path)
 	}
 
+	// 章节目录模式：每个子目录是一章
+	if dirs := s.chapterDirs(path); len(dirs) > 0 {
+		return s.parseChapterDirs(novel, dirs)
+	}
+
 	// 读取所有 TXT 文件
 	entries, err := os.ReadDir(path)
 	if err != nil {
@@ -410,13 +553,17 @@ func (s *TxtDirectoryStrategy) Parse(novel *Novel, path string) error {
 
 // parseMetadataFile 解析元数据文件
 func (s *TxtDirectoryStrategy) parseMetadataFile(novel *Novel, metaPath string) error {
-	file, err := os.Open(metaPath)
+	content, err := os.ReadFile(metaPath)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	text, err := detectAndDecodeEncoding(content)
+	if err != nil {
+		return fmt.Errorf("读取元数据文件 %s 失败: %v", metaPath, err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
 	var descriptionLines []string
 	inDescription := false
 
@@ -424,28 +571,11 @@ func (s *TxtDirectoryStrategy) parseMetadataFile(novel *Novel, metaPath string)
 		line := scanner.Text()
 
 		if key, value := s.txtFormat.ExtractMetadata(line); key != "" {
-			switch key {
-			case "title":
-				novel.Title = value
-			case "author":
-				novel.Author = value
-			case "description":
-				if value != "" {
-					novel.Description = value
-				} else {
-					inDescription = true
-				}
-			case "category":
-				novel.Category = value
-			case "tags":
-				// 处理标签，支持逗号、分号、空格分隔
-				tags := strings.FieldsFunc(value, func(r rune) bool {
-					return r == ',' || r == ';' || r == '，' || r == '；'
-				})
-				for i, tag := range tags {
-					tags[i] = strings.TrimSpace(tag)
-				}
-				novel.Tags = tags
+			if key == "description" && value == "" {
+				// 简介写在下面几行
+				inDescription = true
+			} else {
+				setTxtMetadata(novel, key, value)
 			}
 		} else if inDescription && strings.TrimSpace(line) != "" {
 			descriptionLines = append(descriptionLines, strings.TrimSpace(line))
@@ -460,60 +590,122 @@ func (s *TxtDirectoryStrategy) parseMetadataFile(novel *Novel, metaPath string)
 	return scanner.Err()
 }
 
-// sortTxtFiles 排序 TXT 文件
+// sortTxtFiles 按自然顺序排序 TXT 文件
 func (s *TxtDirectoryStrategy) sortTxtFiles(files []string) {
-	// 简单的数字排序
-	for i := 0; i < len(files)-1; i++ {
-		for j := i + 1; j < len(files); j++ {
-			if s.compareFilenames(files[i], files[j]) > 0 {
-				files[i], files[j] = files[j], files[i]
-			}
-		}
-	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return s.compareFilenames(files[i], files[j]) < 0
+	})
 }
 
-// compareFilenames 比较文件名
+// compareFilenames 自然顺序比较文件名
 func (s *TxtDirectoryStrategy) compareFilenames(a, b string) int {
-	nameA := filepath.Base(a)
-	nameB := filepath.Base(b)
+	nameA := strings.TrimSuffix(filepath.Base(a), filepath.Ext(a))
+	nameB := strings.TrimSuffix(filepath.Base(b), filepath.Ext(b))
+
+	if result := naturalCompare(nameA, nameB); result != 0 {
+		return result
+	}
+
+	// 自然顺序相同时（如 001 与 1），按原始文件名排序保证稳定
+	return strings.Compare(filepath.Base(a), filepath.Base(b))
+}
+
+// naturalToken 自然排序的分段
+type naturalToken struct {
+	text     string
+	number   int
+	isNumber bool
+	width    int
+}
 
-	// 提取数字
-	numA := s.extractNumberFromFilename(nameA)
-	numB := s.extractNumberFromFilename(nameB)
+// naturalCompare 自然顺序比较两个字符串
+// 阿拉伯数字段与中文数字段按数值比较，其余部分按忽略大小写的字符串比较
+func naturalCompare(a, b string) int {
+	tokensA := tokenizeNatural(a)
+	tokensB := tokenizeNatural(b)
 
-	if numA != numB {
-		return numA - numB
+	for i := 0; i < len(tokensA) && i < len(tokensB); i++ {
+		ta, tb := tokensA[i], tokensB[i]
+
+		switch {
+		case ta.isNumber && tb.isNumber:
+			if ta.number != tb.number {
+				if ta.number < tb.number {
+					return -1
+				}
+				return 1
+			}
+			// 数值相同时前导零较少的排前面
+			if ta.width != tb.width {
+				if ta.width < tb.width {
+					return -1
+				}
+				return 1
+			}
+		case ta.isNumber:
+			return -1
+		case tb.isNumber:
+			return 1
+		default:
+			if result := strings.Compare(strings.ToLower(ta.text), strings.ToLower(tb.text)); result != 0 {
+				return result
+			}
+		}
 	}
 
-	// 如果数字相同，按字符串排序
-	if nameA < nameB {
+	switch {
+	case len(tokensA) < len(tokensB):
 		return -1
-	} else if nameA > nameB {
+	case len(tokensA) > len(tokensB):
 		return 1
 	}
 	return 0
 }
 
-// extractNumberFromFilename 从文件名提取数字
-func (s *TxtDirectoryStrategy) extractNumberFromFilename(filename string) int {
-	// 移除扩展名
-	name := strings.TrimSuffix(filename, ".txt")
-
-	// 尝试提取开头的数字
-	for i, char := range name {
-		if char >= '0' && char <= '9' {
-			// 找到数字的结束位置
-			j := i
-			for j < len(name) && name[j] >= '0' && name[j] <= '9' {
+// tokenizeNatural 将字符串切分为数字段与文本段
+func tokenizeNatural(s string) []naturalToken {
+	var tokens []naturalToken
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		j := i
+		switch {
+		case isASCIIDigit(runes[i]):
+			for j < len(runes) && isASCIIDigit(runes[j]) {
+				j++
+			}
+			digits := string(runes[i:j])
+			number, err := strconv.Atoi(strings.TrimLeft(digits, "0"))
+			if err != nil {
+				number = 0
+			}
+			tokens = append(tokens, naturalToken{text: digits, number: number, isNumber: true, width: len(digits)})
+		case isChineseNumeral(runes[i]):
+			for j < len(runes) && isChineseNumeral(runes[j]) {
 				j++
 			}
-			if num, err := strconv.Atoi(name[i:j]); err == nil {
-				return num
+			numerals := string(runes[i:j])
+			tokens = append(tokens, naturalToken{text: numerals, number: parseChineseNumber(numerals), isNumber: true})
+		default:
+			for j < len(runes) && !isASCIIDigit(runes[j]) && !isChineseNumeral(runes[j]) {
+				j++
 			}
+			tokens = append(tokens, naturalToken{text: string(runes[i:j])})
 		}
+		i = j
 	}
 
-	return 0
+	return tokens
+}
+
+// isASCIIDigit 判断是否为阿拉伯数字
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// isChineseNumeral 判断是否为中文数字
+func isChineseNumeral(r rune) bool {
+	return strings.ContainsRune("零〇一二两三四五六七八九十百千万亿", r)
 }
 
 // parseTextFile 解析单个文本文件
@@ -523,10 +715,20 @@ func (s *TxtDirectoryStrategy) parseTextFile(filePath string, chapterID *int) ([
 		return nil, err
 	}
 
-	lines := strings.Split(string(content), "\n")
+	text, err := detectAndDecodeEncoding(content)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件 %s 失败: %v", filePath, err)
+	}
+
+	format, err := s.parser.TxtFormat()
+	if err != nil {
+		return nil, err
+	}
 
 	// 使用 TXT 文件策略解析
 	txtStrategy := NewTxtFileStrategy(s.parser)
+	txtStrategy.txtFormat = format
+	lines := strings.Split(txtStrategy.prepareContent(text), "\n")
 
 	// 创建临时小说对象
 	tempNovel := &Novel{
@@ -537,6 +739,9 @@ func (s *TxtDirectoryStrategy) parseTextFile(filePath string, chapterID *int) ([
 	if err := txtStrategy.parseContentOld(tempNovel, lines); err != nil {
 		return nil, err
 	}
+	txtStrategy.renderIllustrations(tempNovel, func(content string) string {
+		return renderMarkdown(s.parser.MarkdownRenderer(), content)
+	})
 
 	// 重新分配章节ID
 	var chapters []*Chapter
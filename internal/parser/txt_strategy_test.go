@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNaturalCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"arabic by value", "第2章", "第10章", -1},
+		{"chinese by value", "第二章", "第十章", -1},
+		{"chinese ten thousands", "第九千章", "第一万章", -1},
+		{"digit-style chinese", "第一〇五章", "第一百零四章", 1},
+		{"mixed chinese before arabic", "第九章", "第10章", -1},
+		{"mixed arabic before chinese", "第11章", "第十章", 1},
+		{"mixed equal value", "第十一章", "第11章", -1},
+		{"equal strings", "第1章", "第1章", 0},
+		{"case insensitive text", "Chapter1", "chapter1", 0},
+		{"equal prefix shorter first", "chapter", "chapter1", -1},
+		{"equal prefix longer last", "第1章 上", "第1章", 1},
+		{"number before text", "1", "a", -1},
+		{"leading zeros by value", "01", "2", -1},
+		{"leading zeros equal value", "1", "001", -1},
+		{"leading zeros equal value reversed", "007", "7", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := naturalCompare(tt.a, tt.b); got != tt.want {
+				t.Errorf("naturalCompare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortTxtFiles(t *testing.T) {
+	files := []string{
+		"novel/10.txt",
+		"novel/2.txt",
+		"novel/001.txt",
+		"novel/1.txt",
+		"novel/第十一章.txt",
+		"novel/第二章.txt",
+		"novel/第10章.txt",
+	}
+	want := []string{
+		"novel/1.txt",
+		"novel/001.txt",
+		"novel/2.txt",
+		"novel/10.txt",
+		"novel/第二章.txt",
+		"novel/第10章.txt",
+		"novel/第十一章.txt",
+	}
+
+	(&TxtDirectoryStrategy{}).sortTxtFiles(files)
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("sortTxtFiles() = %q, want %q", files, want)
+	}
+}
+
+func TestSetTxtMetadata(t *testing.T) {
+	tests := []struct {
+		name         string
+		key, value   string
+		wantCategory string
+		wantTags     []string
+	}{
+		{name: "category", key: "category", value: "玄幻", wantCategory: "玄幻"},
+		{name: "comma separated tags", key: "tags", value: "修仙,热血", wantTags: []string{"修仙", "热血"}},
+		{name: "mixed separators", key: "tags", value: "修仙；热血、升级，系统;爽文", wantTags: []string{"修仙", "热血", "升级", "系统", "爽文"}},
+		{name: "empty tags dropped", key: "tags", value: "修仙, ,，热血", wantTags: []string{"修仙", "热血"}},
+		{name: "unknown key ignored", key: "publisher", value: "出版社"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			novel := &Novel{}
+			setTxtMetadata(novel, tt.key, tt.value)
+			if novel.Category != tt.wantCategory {
+				t.Errorf("Category = %q, want %q", novel.Category, tt.wantCategory)
+			}
+			if !reflect.DeepEqual(novel.Tags, tt.wantTags) {
+				t.Errorf("Tags = %q, want %q", novel.Tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestTxtFileMetadataCategoryAndTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       string
+		wantCategory string
+		wantTags     []string
+	}{
+		{
+			name:         "chinese labels",
+			header:       "书名：测试小说\n作者：张三\n分类：玄幻\n标签：修仙、热血\n",
+			wantCategory: "玄幻",
+			wantTags:     []string{"修仙", "热血"},
+		},
+		{
+			name:         "english labels",
+			header:       "Author: Someone\nCategory: 科幻\nTags: 太空; 机甲\n",
+			wantCategory: "科幻",
+			wantTags:     []string{"太空", "机甲"},
+		},
+		{
+			name:   "no metadata",
+			header: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := tt.header + "==========\n" +
+				"第一章 开始\n" + "这是第一章的正文内容，用于测试元数据的解析。\n" +
+				"第二章 继续\n" + "这是第二章的正文内容，用于测试元数据的解析。\n"
+			path := writeFile(t, t.TempDir(), "novel.txt", content)
+
+			novel := &Novel{}
+			if err := NewTxtFileStrategy(New()).Parse(novel, path); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if novel.Category != tt.wantCategory {
+				t.Errorf("Category = %q, want %q", novel.Category, tt.wantCategory)
+			}
+			if len(novel.Tags) != 0 || len(tt.wantTags) != 0 {
+				if !reflect.DeepEqual(novel.Tags, tt.wantTags) {
+					t.Errorf("Tags = %q, want %q", novel.Tags, tt.wantTags)
+				}
+			}
+			if len(novel.Chapters) != 2 {
+				t.Errorf("len(Chapters) = %d, want 2", len(novel.Chapters))
+			}
+		})
+	}
+}
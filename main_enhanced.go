@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -100,14 +102,18 @@ func (app *Application) initializeDI(configPath string, generatorType factory.Ge
 		}
 
 		return suite.Generator, nil
-	})
+	}).DependsOn((*config.Config)(nil))
 
 	// 注册错误管理器
 	builder.AddSingleton((*chain.ErrorManager)(nil), func(container *di.Container) (interface{}, error) {
 		return chain.NewErrorManager(), nil
 	})
 
-	app.container = builder.Build()
+	container, err := builder.Build()
+	if err != nil {
+		return fmt.Errorf("构建依赖注入容器失败: %w", err)
+	}
+	app.container = container
 
 	// 设置服务定位器
 	di.GetServiceLocator().SetContainer(app.container)
@@ -197,12 +203,40 @@ func (app *Application) Generate() error {
 	app.logger.Info("开始生成网站")
 
 	if err := app.facade.GenerateWebsite(); err != nil {
+		// 严格模式下的部分失败需要返回给调用方，不交给错误处理链降级处理
+		var partial *generator.PartialBuildError
+		if errors.As(err, &partial) {
+			return err
+		}
 		return app.errorManager.HandleError(err, chain.SeverityError, "application", "generate", nil)
 	}
 
 	return nil
 }
 
+// GenerateCovers 仅生成封面
+func (app *Application) GenerateCovers() error {
+	app.logger.Info("开始生成封面")
+
+	if err := app.facade.GenerateCovers(); err != nil {
+		return app.errorManager.HandleError(err, chain.SeverityError, "application", "generate_covers", nil)
+	}
+
+	return nil
+}
+
+// CheckLinks 检查站内链接
+func (app *Application) CheckLinks() ([]generator.BrokenLink, error) {
+	app.logger.Info("开始检查站内链接")
+
+	broken, err := app.facade.CheckLinks()
+	if err != nil {
+		return nil, app.errorManager.HandleError(err, chain.SeverityError, "application", "check_links", nil)
+	}
+
+	return broken, nil
+}
+
 // Serve 启动服务器
 func (app *Application) Serve(port int) error {
 	app.logger.Info("启动服务器，端口:", port)
@@ -216,6 +250,40 @@ func (app *Application) Serve(port int) error {
 	return nil
 }
 
+// Watch 监听文件变化并自动重建
+func (app *Application) Watch(ctx context.Context, options generator.WatchOptions) error {
+	app.logger.Info("开始监听文件变化")
+
+	if err := app.facade.WatchWebsite(ctx, options); err != nil {
+		return app.errorManager.HandleError(err, chain.SeverityError, "application", "watch", nil)
+	}
+
+	return nil
+}
+
+// WatchConfig 监听配置文件，变化时使用新配置重新初始化生成器并重新生成网站
+// applyOverrides 用于在新配置上重新应用命令行参数
+func (app *Application) WatchConfig(ctx context.Context, applyOverrides func(*config.Config)) error {
+	err := app.facade.WatchConfig(ctx, func(cfg *config.Config) {
+		if applyOverrides != nil {
+			applyOverrides(cfg)
+		}
+		app.facade.ReloadConfig(cfg)
+
+		fmt.Printf("🔄 检测到配置文件变化，重新生成站点...\n")
+		if err := app.Generate(); err != nil {
+			fmt.Printf("❌ 重新生成失败: %v\n", err)
+			return
+		}
+		fmt.Printf("✅ 已应用新配置并重新生成站点\n")
+	})
+	if err != nil {
+		return app.errorManager.HandleError(err, chain.SeverityError, "application", "watch_config", nil)
+	}
+
+	return nil
+}
+
 // Deploy 部署网站
 func (app *Application) Deploy() error {
 	app.logger.Info("开始部署网站")
@@ -262,16 +330,25 @@ func (app *Application) Shutdown() {
 
 func main() {
 	var (
-		configPath    = flag.String("config", "config.yaml", "配置文件路径")
+		configPath    = flag.String("config", "", "配置文件路径 (默认自动查找 config.yaml、config.yml、creeper.yaml、.creeper/config.yaml)")
 		inputDir      = flag.String("input", "novels", "小说文件输入目录")
 		outputDir     = flag.String("output", "dist", "静态站点输出目录")
 		serve         = flag.Bool("serve", false, "生成后启动本地服务器")
 		port          = flag.Int("port", 8080, "本地服务器端口")
 		generatorType = flag.String("generator", "enhanced", "生成器类型 (static|enhanced|minimal)")
 		verbose       = flag.Bool("verbose", false, "详细输出")
+		epub          = flag.Bool("epub", false, "生成站点时将每部小说导出为 EPUB (novels/<标题>/<标题>.epub)")
+		workers       = flag.Int("workers", 0, "并行解析与渲染的并发数 (默认使用配置中的 build.workers，未配置时为 CPU 核数)")
 		status        = flag.Bool("status", false, "显示系统状态")
 		deploy        = flag.Bool("deploy", false, "生成后自动部署")
 		test          = flag.Bool("test", false, "测试TXT解析功能")
+		coversOnly    = flag.Bool("covers-only", false, "仅重新生成小说封面，不生成 HTML 页面")
+		cpuProfile    = flag.String("profile", "", "生成期间的CPU性能分析输出文件 (pprof)")
+		memProfile    = flag.String("memprofile", "", "生成结束后的堆内存分析输出文件 (pprof)")
+		checkLinks    = flag.Bool("check-links", false, "生成后检查所有站内链接，存在失效链接时以状态码 1 退出")
+		watch         = flag.Bool("watch", false, "监听输入目录，小说文件变化时重新生成")
+		watchTmpl     = flag.Bool("watch-templates", false, "监听 theme.templates_dir，模板变化时只重新渲染相关页面")
+		liveReload    = flag.Bool("livereload", false, "配合 -serve 使用：小说文件变化时增量重建并自动刷新浏览器")
 	)
 	flag.Parse()
 
@@ -281,6 +358,15 @@ func main() {
 		return
 	}
 
+	// 未指定配置文件时自动查找
+	if *configPath == "" {
+		found, err := config.FindConfigFile(config.DefaultSearchPaths())
+		if err != nil {
+			found = "config.yaml"
+		}
+		*configPath = found
+	}
+
 	// 创建应用程序
 	app := NewApplication()
 
@@ -302,6 +388,11 @@ func main() {
 		log.Fatalf("应用程序初始化失败: %v", err)
 	}
 
+	app.facade.SetVerbose(*verbose)
+	app.facade.SetWorkers(*workers)
+	app.facade.SetExportEPUB(*epub)
+	app.facade.SetLiveReload(*serve && *liveReload)
+
 	// 如果只是查看状态
 	if *status {
 		status := app.GetStatus()
@@ -323,11 +414,43 @@ func main() {
 		if err := app.facade.UpdateConfig(updates); err != nil {
 			log.Fatalf("更新配置失败: %v", err)
 		}
+
+		// 同步到中介者中的配置组件，输入目录变化时会使解析缓存失效
+		if err := app.mediator.Send(&mediator.Message{
+			Type: "update_config",
+			To:   mediator.ConfigComponent,
+			Data: updates,
+		}); err != nil {
+			log.Printf("⚠️  同步配置失败: %v", err)
+		}
+	}
+
+	// 仅生成封面
+	if *coversOnly {
+		if err := app.GenerateCovers(); err != nil {
+			log.Fatalf("生成封面失败: %v", err)
+		}
+
+		fmt.Printf("✅ 封面生成完成！\n")
+		fmt.Printf("📁 输出目录: %s\n", *outputDir)
+		return
 	}
 
 	// 生成网站
-	if err := app.Generate(); err != nil {
-		log.Fatalf("生成网站失败: %v", err)
+	stopProfile, err := startCPUProfile(*cpuProfile)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	genErr := app.Generate()
+	stopProfile()
+
+	if err := writeHeapProfile(*memProfile); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+
+	if genErr != nil {
+		log.Fatalf("生成网站失败: %v", genErr)
 	}
 
 	fmt.Printf("✅ 静态站点生成完成！\n")
@@ -339,6 +462,26 @@ func main() {
 		fmt.Printf("📊 系统状态: %v\n", status)
 	}
 
+	// 检查站内链接
+	if *checkLinks {
+		fmt.Printf("🔗 检查站内链接...\n")
+
+		broken, err := app.CheckLinks()
+		if err != nil {
+			log.Fatalf("链接检查失败: %v", err)
+		}
+
+		if len(broken) > 0 {
+			for _, link := range broken {
+				fmt.Printf("❌ %s\n", link)
+			}
+			fmt.Printf("发现 %d 个失效链接\n", len(broken))
+			os.Exit(1)
+		}
+
+		fmt.Printf("✅ 未发现失效链接\n")
+	}
+
 	// 部署网站
 	if *deploy {
 		fmt.Printf("🚀 开始部署网站...\n")
@@ -354,8 +497,39 @@ func main() {
 		}
 	}
 
+	// 监听文件变化，与服务器同时启用时在后台运行；热重载模式由服务器负责监听
+	if (*watch || *watchTmpl) && !(*serve && *liveReload) {
+		options := generator.WatchOptions{Input: *watch, Templates: *watchTmpl}
+		if !*serve {
+			fmt.Printf("按 Ctrl+C 停止监听\n")
+			if err := app.Watch(context.Background(), options); err != nil {
+				log.Fatalf("文件监听失败: %v", err)
+			}
+			return
+		}
+
+		go func() {
+			if err := app.Watch(context.Background(), options); err != nil {
+				log.Printf("⚠️  文件监听失败: %v", err)
+			}
+		}()
+	}
+
 	// 启动服务器
 	if *serve {
+		// 开发服务器运行期间修改配置文件立即生效
+		go func() {
+			err := app.WatchConfig(context.Background(), func(cfg *config.Config) {
+				if *inputDir != "novels" || *outputDir != "dist" {
+					cfg.InputDir = *inputDir
+					cfg.OutputDir = *outputDir
+				}
+			})
+			if err != nil {
+				log.Printf("⚠️  配置文件监听失败: %v", err)
+			}
+		}()
+
 		fmt.Printf("🚀 启动本地服务器 http://localhost:%d\n", *port)
 		fmt.Printf("按 Ctrl+C 停止服务器\n")
 
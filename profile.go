@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startCPUProfile 开始CPU性能分析，返回停止函数
+func startCPUProfile(path string) (func(), error) {
+	if path == "" {
+		return func() {}, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("创建CPU分析文件失败: %v", err)
+	}
+
+	if err := pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("启动CPU分析失败: %v", err)
+	}
+
+	return func() {
+		pprof.StopCPUProfile()
+		file.Close()
+		fmt.Printf("🔬 CPU分析已写入 %s，使用以下命令查看:\n", path)
+		fmt.Printf("   go tool pprof %s\n", path)
+	}, nil
+}
+
+// writeHeapProfile 写入堆内存分析文件
+func writeHeapProfile(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建内存分析文件失败: %v", err)
+	}
+	defer file.Close()
+
+	// 先执行GC，使堆统计反映当前存活对象
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("写入内存分析失败: %v", err)
+	}
+
+	fmt.Printf("🔬 内存分析已写入 %s，使用以下命令查看:\n", path)
+	fmt.Printf("   go tool pprof -sample_index=inuse_space %s\n", path)
+	return nil
+}